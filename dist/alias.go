@@ -0,0 +1,71 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+// aliasTable samples indices with probabilities proportional to a set of
+// weights in constant time per sample, using Vose's alias method. Index i is
+// chosen uniformly, and then kept with probability prob[i] or replaced by
+// alias[i].
+type aliasTable struct {
+	prob  []float64
+	alias []int
+}
+
+// newAliasTable returns an alias table for the weights, which must be
+// non-negative with a positive finite sum. Construction takes O(len(weights))
+// time.
+func newAliasTable(weights []float64) aliasTable {
+	n := len(weights)
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	t := aliasTable{prob: make([]float64, n), alias: make([]int, n)}
+	// Scale the weights to average 1, and pair each column below 1 with
+	// one above to fill it.
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / sum
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s := small[len(small)-1]
+		small = small[:len(small)-1]
+		l := large[len(large)-1]
+		t.prob[s] = scaled[s]
+		t.alias[s] = l
+		scaled[l] -= 1 - scaled[s]
+		if scaled[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// The remaining columns are full up to rounding error.
+	for _, i := range large {
+		t.prob[i] = 1
+	}
+	for _, i := range small {
+		t.prob[i] = 1
+	}
+	return t
+}
+
+// sample returns a random index drawn from src.
+func (t aliasTable) sample(src Source) int {
+	n := len(t.prob)
+	i := int(randFloat64(src) * float64(n))
+	if i == n {
+		i = n - 1
+	}
+	if randFloat64(src) < t.prob[i] {
+		return i
+	}
+	return t.alias[i]
+}
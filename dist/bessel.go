@@ -0,0 +1,65 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// besselAsymptotic is the argument above which the modified Bessel functions
+// are evaluated with their asymptotic expansion instead of the power series.
+const besselAsymptotic = 30
+
+// besselI0e returns the exponentially scaled modified Bessel function of the
+// first kind of order zero, exp(-|x|) I0(x).
+func besselI0e(x float64) float64 {
+	return besselIe(0, math.Abs(x))
+}
+
+// besselI1e returns the exponentially scaled modified Bessel function of the
+// first kind of order one, exp(-|x|) I1(x).
+func besselI1e(x float64) float64 {
+	if x < 0 {
+		return -besselIe(1, -x)
+	}
+	return besselIe(1, x)
+}
+
+// besselIe returns exp(-x) I_n(x) for x >= 0 and small integer n.
+func besselIe(n int, x float64) float64 {
+	if math.IsInf(x, 1) {
+		return 0
+	}
+	if x <= besselAsymptotic {
+		// I_n(x) = \sum_k (x/2)^(2k+n) / (k! (k+n)!). All terms are positive
+		// so the sum is accurate for moderate x.
+		term := 1.0
+		for k := 1; k <= n; k++ {
+			term *= 0.5 * x / float64(k)
+		}
+		sum := term
+		q := 0.25 * x * x
+		for k := 1; ; k++ {
+			term *= q / float64(k*(k+n))
+			sum += term
+			if term <= 1e-17*sum {
+				break
+			}
+		}
+		return sum * math.Exp(-x)
+	}
+	// I_n(x) ~ e^x / sqrt(2πx) \sum_k (-1)^k a_k(n) / x^k with
+	// a_k(n) = \prod_{j=1}^k (4n^2 - (2j-1)^2) / (k! 8^k).
+	mu := 4 * float64(n*n)
+	term := 1.0
+	sum := term
+	for k := 1; k < 30; k++ {
+		j := float64(2*k - 1)
+		term *= (j*j - mu) / (8 * float64(k) * x)
+		sum += term
+		if math.Abs(term) <= 1e-17*math.Abs(sum) {
+			break
+		}
+	}
+	return sum / math.Sqrt(2*math.Pi*x)
+}
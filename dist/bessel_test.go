@@ -0,0 +1,32 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "testing"
+
+func TestBesselIe(t *testing.T) {
+	for _, test := range []struct {
+		x, i0e, i1e float64
+	}{
+		{0, 1, 0},
+		{0.5, 0.6450352704491501, 0.1564208031848717},
+		{1, 0.46575960759364043, 0.20791041534970844},
+		{5, 0.18354081260932836, 0.16397226694454237},
+		{29, 0.07440746822222559, 0.07311311793938836},
+		{31, 0.07194649669698383, 0.07077639283438568},
+		{50, 0.05656162664745419, 0.0559931238928954},
+		{200, 0.028227159949111916, 0.02815650339483292},
+	} {
+		if got := besselI0e(test.x); !equalRel(got, test.i0e, 1e-14) {
+			t.Errorf("besselI0e mismatch at %v. Expected %v, Found %v", test.x, test.i0e, got)
+		}
+		if got := besselI1e(test.x); !equalRel(got, test.i1e, 1e-14) {
+			t.Errorf("besselI1e mismatch at %v. Expected %v, Found %v", test.x, test.i1e, got)
+		}
+		if got := besselI1e(-test.x); !equalRel(got, -test.i1e, 1e-14) {
+			t.Errorf("besselI1e mismatch at %v. Expected %v, Found %v", -test.x, -test.i1e, got)
+		}
+	}
+}
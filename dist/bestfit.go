@@ -0,0 +1,213 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/gonum/stat"
+)
+
+// BestFit fits each of the candidate distributions to the samples by maximum
+// likelihood and returns the candidate with the lowest Bayesian information
+// criterion
+//  BIC = k log n - 2 log L
+// where k is the number of parameters, n the number of samples and L the
+// maximized likelihood, along with a table of the scores. The table has the
+// entries
+//  <name>.LogLikelihood, <name>.AIC and <name>.BIC
+// for each candidate, where AIC = 2k - 2 log L and name is the type name of
+// the candidate, followed by #2, #3, … for repeated types.
+//
+// BIC is used for the selection because it penalizes parameters more heavily
+// than AIC as n grows, so it identifies the true family when one candidate is
+// a special case of another, such as the exponential of the Weibull.
+//
+// Each candidate is fitted in place starting from its current parameters,
+// which should be a reasonable initial estimate. Candidates whose maximized
+// log-likelihood is not finite, for example because a sample is outside
+// their support, are never selected. BestFit returns nil if no candidate can
+// be selected.
+func BestFit(samples []float64, candidates []ParametricDist) (ParametricDist, map[string]float64) {
+	scores := make(map[string]float64)
+	seen := make(map[string]int)
+	n := float64(len(samples))
+	var best ParametricDist
+	bestBIC := math.Inf(1)
+	for _, d := range candidates {
+		name := reflect.Indirect(reflect.ValueOf(d)).Type().Name()
+		seen[name]++
+		if c := seen[name]; c > 1 {
+			name += "#" + strconv.Itoa(c)
+		}
+		ll := maximizeLikelihood(d, samples, nil, nil)
+		k := float64(d.NumParameters())
+		bic := k*math.Log(n) - 2*ll
+		scores[name+".LogLikelihood"] = ll
+		scores[name+".AIC"] = 2*k - 2*ll
+		scores[name+".BIC"] = bic
+		if !math.IsNaN(ll) && !math.IsInf(ll, 0) && bic < bestBIC {
+			best, bestBIC = d, bic
+		}
+	}
+	return best, scores
+}
+
+// DistributionFromSamples chooses a family of distributions from the support
+// of the samples, fits each by maximum likelihood, and returns the fit with
+// the lowest Akaike information criterion, AIC = 2k - 2 log L, together with
+// the type name of its family. The shortlist of families is
+//  integer-valued and non-negative: Poisson
+//  in (0, 1): Kumaraswamy
+//  positive: Exponential, Gamma and Weibull
+//  otherwise: Normal and Laplace
+// taking the first row that matches. Each fit starts from moment or
+// quantile estimates. It is a convenient first look at data; BestFit
+// compares an explicit list of candidates, and choosing the families by
+// hand from knowledge of the data is preferable where it is possible.
+//
+// DistributionFromSamples returns nil and "" if there are fewer than two
+// distinct samples, if a sample is not finite, or if no fit has a finite
+// log-likelihood.
+func DistributionFromSamples(samples []float64) (ParametricDist, string) {
+	if len(samples) < 2 {
+		return nil, ""
+	}
+	integer, unit, positive := true, true, true
+	var mean float64
+	distinct := false
+	for _, x := range samples {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return nil, ""
+		}
+		if x != samples[0] {
+			distinct = true
+		}
+		integer = integer && x >= 0 && x == math.Floor(x)
+		unit = unit && x > 0 && x < 1
+		positive = positive && x > 0
+		mean += x
+	}
+	if !distinct {
+		return nil, ""
+	}
+	n := float64(len(samples))
+	mean /= n
+	var variance float64
+	for _, x := range samples {
+		variance += (x - mean) * (x - mean)
+	}
+	variance /= n
+	sorted := make([]float64, len(samples))
+	copy(sorted, samples)
+	sort.Float64s(sorted)
+	median := stat.Quantile(0.5, stat.Empirical, sorted, nil)
+
+	var candidates []ParametricDist
+	switch {
+	case integer:
+		candidates = []ParametricDist{&Poisson{Lambda: mean}}
+	case unit:
+		candidates = []ParametricDist{&Kumaraswamy{A: 1, B: 1}}
+	case positive:
+		// The Weibull shape is from the approximation K ≈ cv^-1.086 to the
+		// coefficient of variation.
+		k := math.Pow(math.Sqrt(variance)/mean, -1.086)
+		candidates = []ParametricDist{
+			&Exponential{Rate: 1 / mean},
+			&Gamma{Alpha: mean * mean / variance, Beta: mean / variance},
+			&Weibull{K: k, Lambda: mean / math.Gamma(1+1/k)},
+		}
+	default:
+		var mad float64
+		for _, x := range samples {
+			mad += math.Abs(x - median)
+		}
+		candidates = []ParametricDist{
+			&Normal{Mu: mean, Sigma: math.Sqrt(variance)},
+			&Laplace{Mu: median, Scale: mad / n},
+		}
+	}
+	var best ParametricDist
+	bestAIC := math.Inf(1)
+	for _, d := range candidates {
+		ll := maximizeLikelihood(d, samples, nil, nil)
+		aic := 2*float64(d.NumParameters()) - 2*ll
+		if !math.IsNaN(ll) && !math.IsInf(ll, 0) && aic < bestAIC {
+			best, bestAIC = d, aic
+		}
+	}
+	if best == nil {
+		return nil, ""
+	}
+	return best, reflect.Indirect(reflect.ValueOf(best)).Type().Name()
+}
+
+// FitBatch fits a distribution to each of the datasets by maximum likelihood
+// and returns the fitted distributions, with element i fitted to datasets[i].
+// The distribution for each dataset is returned by a call to newDist, and is
+// fitted in place starting from its parameters, which should be a reasonable
+// initial estimate.
+//
+// The datasets are fitted concurrently by runtime.GOMAXPROCS(0) goroutines,
+// so newDist may be called concurrently and must return a distinct
+// distribution, with its own Source if it has one, on every call. The results
+// are the same as fitting each dataset in turn.
+func FitBatch(datasets [][]float64, newDist func() ParametricDist) []ParametricDist {
+	fits := make([]ParametricDist, len(datasets))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				d := newDist()
+				maximizeLikelihood(d, datasets[i], nil, nil)
+				fits[i] = d
+			}
+		}()
+	}
+	for i := range datasets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return fits
+}
+
+// LikelihoodRatioTest fits the full and reduced models to the samples by
+// maximum likelihood and returns the likelihood-ratio statistic
+//  lr = 2 (log L_full - log L_reduced)
+// together with its p-value under the asymptotic chi-squared distribution
+// with df = full.NumParameters() - reduced.NumParameters() degrees of
+// freedom. The reduced model must be nested in the full one, that is a
+// special case obtained by fixing some of its parameters, so that a small
+// p-value is evidence against the reduced model. For example, the
+// exponential is the Weibull with K = 1.
+//
+// Both models are fitted in place starting from their current parameters.
+// The statistic is clamped at zero, since a nested model cannot fit better
+// except through the finite precision of the maximization.
+// LikelihoodRatioTest panics if the full model does not have more
+// parameters than the reduced one.
+func LikelihoodRatioTest(full, reduced ParametricDist, samples []float64) (lr, pValue float64, df int) {
+	df = full.NumParameters() - reduced.NumParameters()
+	if df <= 0 {
+		panic("dist: full model must have more parameters than the reduced model")
+	}
+	llFull := maximizeLikelihood(full, samples, nil, nil)
+	llReduced := maximizeLikelihood(reduced, samples, nil, nil)
+	lr = math.Max(0, 2*(llFull-llReduced))
+	// The chi-squared distribution with df degrees of freedom is the gamma
+	// distribution with shape df/2 and rate 1/2.
+	pValue = Gamma{Alpha: float64(df) / 2, Beta: 0.5}.Survival(lr)
+	return lr, pValue, df
+}
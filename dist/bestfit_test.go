@@ -0,0 +1,190 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestBestFit(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	candidates := func() []ParametricDist {
+		return []ParametricDist{
+			&Normal{Mu: 1, Sigma: 1},
+			&Laplace{Mu: 1, Scale: 1},
+			&Exponential{Rate: 1},
+			&Weibull{K: 1, Lambda: 1},
+		}
+	}
+	for _, test := range []struct {
+		truth Rander
+		want  string
+	}{
+		{Weibull{K: 2.5, Lambda: 3, Source: src}, "Weibull"},
+		{Exponential{Rate: 0.5, Source: src}, "Exponential"},
+		{Laplace{Mu: 4, Scale: 1, Source: src}, "Laplace"},
+		{Normal{Mu: 4, Sigma: 1, Source: src}, "Normal"},
+	} {
+		samples := make([]float64, 2000)
+		for i := range samples {
+			samples[i] = test.truth.Rand()
+		}
+		best, scores := BestFit(samples, candidates())
+		if best == nil {
+			t.Errorf("No best fit for %v", test.truth)
+			continue
+		}
+		if got := typeName(best); got != test.want {
+			t.Errorf("Best fit mismatch for %v. Expected %v, Found %v with scores %v", test.truth, test.want, got, scores)
+		}
+		for _, key := range []string{"LogLikelihood", "AIC", "BIC"} {
+			if _, ok := scores[test.want+"."+key]; !ok {
+				t.Errorf("Score %s.%s missing", test.want, key)
+			}
+		}
+		ll := scores[test.want+".LogLikelihood"]
+		k := float64(best.NumParameters())
+		if got, want := scores[test.want+".BIC"], k*math.Log(2000)-2*ll; !equalRel(got, want, 1e-14) {
+			t.Errorf("BIC mismatch. Expected %v, Found %v", want, got)
+		}
+	}
+
+	// Negative samples are outside the support of the exponential and
+	// Weibull distributions.
+	samples := []float64{-1, 0.5, 2, 3}
+	best, scores := BestFit(samples, []ParametricDist{&Exponential{Rate: 1}, &Exponential{Rate: 2}, &Normal{Mu: 1, Sigma: 1}})
+	if typeName(best) != "Normal" {
+		t.Errorf("Best fit mismatch with unsupported samples. Found %v", best)
+	}
+	if _, ok := scores["Exponential#2.AIC"]; !ok {
+		t.Errorf("Repeated candidate type missing from scores %v", scores)
+	}
+	if ll := scores["Exponential.LogLikelihood"]; !math.IsInf(ll, -1) && !math.IsNaN(ll) {
+		t.Errorf("Exponential log-likelihood with negative sample mismatch. Found %v", ll)
+	}
+}
+
+func typeName(d interface{}) string {
+	switch d.(type) {
+	case *Normal:
+		return "Normal"
+	case *Laplace:
+		return "Laplace"
+	case *Exponential:
+		return "Exponential"
+	case *Weibull:
+		return "Weibull"
+	}
+	return ""
+}
+
+func TestDistributionFromSamples(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		truth Rander
+		want  []string
+	}{
+		{Weibull{K: 2.5, Lambda: 3, Source: src}, []string{"Weibull"}},
+		{Gamma{Alpha: 3, Beta: 2, Source: src}, []string{"Gamma"}},
+		{Exponential{Rate: 0.5, Source: src}, []string{"Exponential", "Gamma", "Weibull"}},
+		{Kumaraswamy{A: 2, B: 5, Source: src}, []string{"Kumaraswamy"}},
+		{Poisson{Lambda: 3, Source: src}, []string{"Poisson"}},
+		{Normal{Mu: 1, Sigma: 2, Source: src}, []string{"Normal"}},
+		{Laplace{Mu: 1, Scale: 2, Source: src}, []string{"Laplace"}},
+	} {
+		x := make([]float64, 2000)
+		for i := range x {
+			x[i] = test.truth.Rand()
+		}
+		d, name := DistributionFromSamples(x)
+		if d == nil {
+			t.Errorf("No distribution chosen for samples from %v", test.truth)
+			continue
+		}
+		found := false
+		for _, w := range test.want {
+			found = found || name == w
+		}
+		if !found {
+			t.Errorf("Wrong family for samples from %v. Expected one of %v, Found %v", test.truth, test.want, name)
+		}
+		if got := reflect.Indirect(reflect.ValueOf(d)).Type().Name(); got != name {
+			t.Errorf("Name mismatch with the distribution. Expected %v, Found %v", got, name)
+		}
+	}
+	if d, name := DistributionFromSamples([]float64{2, 2, 2}); d != nil || name != "" {
+		t.Errorf("Distribution chosen for constant samples. Found %v", name)
+	}
+}
+
+func TestFitBatch(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	datasets := make([][]float64, 50)
+	for i := range datasets {
+		w := Weibull{K: 0.5 + 0.1*float64(i), Lambda: 1 + float64(i%7), Source: src}
+		datasets[i] = make([]float64, 200)
+		for j := range datasets[i] {
+			datasets[i][j] = w.Rand()
+		}
+	}
+	newDist := func() ParametricDist { return &Weibull{K: 1, Lambda: 1} }
+	fits := FitBatch(datasets, newDist)
+	if len(fits) != len(datasets) {
+		t.Fatalf("Wrong number of fits. Expected %d, Found %d", len(datasets), len(fits))
+	}
+	for i, x := range datasets {
+		want := newDist()
+		maximizeLikelihood(want, x, nil, nil)
+		if got := *fits[i].(*Weibull); got != *want.(*Weibull) {
+			t.Errorf("Fit mismatch for dataset %d. Expected %v, Found %v", i, want, got)
+		}
+	}
+	if fits := FitBatch(nil, newDist); len(fits) != 0 {
+		t.Errorf("Expected no fits for no datasets, Found %v", fits)
+	}
+}
+
+func TestLikelihoodRatioTest(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		truth    Rander
+		reject   bool
+		pCompare float64
+	}{
+		{Exponential{Rate: 0.5, Source: src}, false, 0.05},
+		{Weibull{K: 1.5, Lambda: 2, Source: src}, true, 1e-6},
+	} {
+		samples := make([]float64, 500)
+		for i := range samples {
+			samples[i] = test.truth.Rand()
+		}
+		full := &Weibull{K: 1, Lambda: 1}
+		reduced := &Exponential{Rate: 1}
+		stat, p, df := LikelihoodRatioTest(full, reduced, samples)
+		if df != 1 {
+			t.Errorf("Degrees of freedom mismatch. Expected 1, Found %v", df)
+		}
+		var llFull, llReduced float64
+		for _, x := range samples {
+			llFull += full.LogProb(x)
+			llReduced += reduced.LogProb(x)
+		}
+		if want := math.Max(0, 2*(llFull-llReduced)); math.Abs(stat-want) > 1e-10*math.Max(1, want) {
+			t.Errorf("Statistic mismatch for %v. Expected %v, Found %v", test.truth, want, stat)
+		}
+		if test.reject && p > test.pCompare {
+			t.Errorf("Reduced model not rejected for %v. Expected p < %v, Found %v", test.truth, test.pCompare, p)
+		}
+		if !test.reject && p < test.pCompare {
+			t.Errorf("Reduced model rejected for %v. Expected p > %v, Found %v", test.truth, test.pCompare, p)
+		}
+	}
+	if !panics(func() { LikelihoodRatioTest(&Exponential{Rate: 1}, &Weibull{K: 1, Lambda: 1}, []float64{1, 2}) }) {
+		t.Errorf("expected panic for non-nested parameter counts")
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"fmt"
+	"math"
+)
+
+// Binomial represents the binomial distribution (https://en.wikipedia.org/wiki/Binomial_distribution).
+// Valid range for x is the integers in [0, N].
+type Binomial struct {
+	// N is the number of trials. N must be a non-negative integer.
+	N float64
+	// P is the probability of success in each trial. Valid range is [0, 1].
+	P float64
+	// Source of random numbers
+	Source Source
+}
+
+// NewBinomial returns a binomial distribution with n trials and success
+// probability p that samples from src. NewBinomial returns an error if n is
+// not a non-negative integer or if p is not in [0, 1].
+func NewBinomial(n, p float64, src Source) (Binomial, error) {
+	b := Binomial{N: n, P: p, Source: src}
+	if err := b.Validate(); err != nil {
+		return Binomial{}, err
+	}
+	return b, nil
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (b Binomial) CDF(x float64) float64 {
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 {
+		return 0
+	}
+	if x >= b.N {
+		return 1
+	}
+	var cdf float64
+	for k := 0; k <= int(math.Floor(x)); k++ {
+		cdf += math.Exp(b.logProbInt(k))
+	}
+	if cdf > 1 {
+		return 1
+	}
+	return cdf
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution.
+func (b Binomial) ExKurtosis() float64 {
+	v := b.P * (1 - b.P)
+	return (1 - 6*v) / (b.N * v)
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x. LogProb returns -Inf if x is outside [0, N] or is
+// not an integer.
+//
+// The binomial coefficient is computed in log space, so LogProb remains finite
+// for large N.
+func (b Binomial) LogProb(x float64) float64 {
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 || x > b.N || math.Floor(x) != x {
+		return math.Inf(-1)
+	}
+	return b.logProbInt(int(x))
+}
+
+// logProbInt returns the log probability of the integer 0 ≤ k ≤ N.
+func (b Binomial) logProbInt(k int) float64 {
+	n := int(b.N)
+	// Handle P at the boundaries explicitly to avoid 0 * -Inf.
+	switch {
+	case b.P == 0:
+		if k == 0 {
+			return 0
+		}
+		return math.Inf(-1)
+	case b.P == 1:
+		if k == n {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+	return logChoose(n, k) + float64(k)*math.Log(b.P) + float64(n-k)*math.Log1p(-b.P)
+}
+
+// MarshalParameters implements the ParameterMarshaler interface.
+func (b Binomial) MarshalParameters(p []Parameter) {
+	if len(p) != b.NumParameters() {
+		panic("binomial: improper parameter length")
+	}
+	p[0].Name = "N"
+	p[0].Value = b.N
+	p[1].Name = "P"
+	p[1].Value = b.P
+	return
+}
+
+// Mean returns the mean of the probability distribution.
+func (b Binomial) Mean() float64 {
+	return b.N * b.P
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Binomial) NumParameters() int {
+	return 2
+}
+
+// Prob computes the value of the probability density function at x.
+func (b Binomial) Prob(x float64) float64 {
+	return math.Exp(b.LogProb(x))
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (b Binomial) Rand() float64 {
+	p := math.Min(b.P, 1-b.P)
+	var k float64
+	switch {
+	case b.N < 25:
+		for i := 0; i < int(b.N); i++ {
+			if randFloat64(b.Source) < p {
+				k++
+			}
+		}
+	case b.N*p < 1:
+		// The bound of the rejection method below fails for a small mean,
+		// so invert the CDF by sequential search, which takes about one
+		// step on average.
+		u := randFloat64(b.Source)
+		r := p / (1 - p)
+		pk := math.Exp(b.N * math.Log1p(-p))
+		cdf := pk
+		for u > cdf && k < b.N {
+			pk *= r * (b.N - k) / (k + 1)
+			k++
+			cdf += pk
+		}
+	default:
+		// Rejection sampling with a Lorentzian comparison function, from
+		// Numerical Recipes in C, section 7.3.
+		am := b.N * p
+		sq := math.Sqrt(2 * am * (1 - p))
+		g, _ := math.Lgamma(b.N + 1)
+		logP := math.Log(p)
+		logQ := math.Log1p(-p)
+		for {
+			var y float64
+			for {
+				y = math.Tan(math.Pi * randFloat64(b.Source))
+				k = sq*y + am
+				if k >= 0 && k < b.N+1 {
+					break
+				}
+			}
+			k = math.Floor(k)
+			lk, _ := math.Lgamma(k + 1)
+			lnk, _ := math.Lgamma(b.N - k + 1)
+			t := 1.2 * sq * (1 + y*y) * math.Exp(g-lk-lnk+k*logP+(b.N-k)*logQ)
+			if randFloat64(b.Source) <= t {
+				break
+			}
+		}
+	}
+	if p != b.P {
+		return b.N - k
+	}
+	return k
+}
+
+// Skewness returns the skewness of the distribution.
+func (b Binomial) Skewness() float64 {
+	return (1 - 2*b.P) / b.StdDev()
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (b Binomial) StdDev() float64 {
+	return math.Sqrt(b.Variance())
+}
+
+// String implements the fmt.Stringer interface.
+func (b Binomial) String() string {
+	return formatParams(&b)
+}
+
+// SupportRange returns the smallest and largest values with non-zero
+// probability, 0 and N.
+func (b Binomial) SupportRange() (lo, hi float64) {
+	return 0, b.N
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (b Binomial) Survival(x float64) float64 {
+	return 1 - b.CDF(x)
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface.
+func (b *Binomial) UnmarshalParameters(p []Parameter) {
+	if len(p) != b.NumParameters() {
+		panic("binomial: incorrect number of parameters to set")
+	}
+	if p[0].Name != "N" {
+		panic("binomial: " + panicNameMismatch)
+	}
+	if p[1].Name != "P" {
+		panic("binomial: " + panicNameMismatch)
+	}
+	b.N = p[0].Value
+	b.P = p[1].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if N is a non-negative integer
+// and P is in [0, 1].
+func (b Binomial) Validate() error {
+	if !(b.N >= 0) || math.IsInf(b.N, 1) || math.Floor(b.N) != b.N {
+		return fmt.Errorf("binomial: N must be a non-negative integer, found %v", b.N)
+	}
+	return checkProbability("binomial", "P", b.P)
+}
+
+// Variance returns the variance of the probability distribution.
+func (b Binomial) Variance() float64 {
+	return b.N * b.P * (1 - b.P)
+}
@@ -0,0 +1,91 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBinomialLogProb(t *testing.T) {
+	for _, test := range []struct {
+		n, p, x, want float64
+	}{
+		{10, 0.25, 3, -1.3851658477400912},
+		{2000, 0.5, 1000, -4.026367582410558},
+		{5000, 0.3, 1500, -4.397273953191188},
+		{5000, 0.3, 1000, -132.92179428696},
+	} {
+		b := Binomial{N: test.n, P: test.p}
+		got := b.LogProb(test.x)
+		if math.IsInf(got, 0) || math.IsNaN(got) {
+			t.Errorf("LogProb not finite for N = %v, P = %v, x = %v. Found %v", test.n, test.p, test.x, got)
+			continue
+		}
+		if math.Abs(got-test.want) > 1e-9*math.Max(1, math.Abs(test.want)) {
+			t.Errorf("LogProb mismatch for N = %v, P = %v, x = %v. Expected %v, Found %v", test.n, test.p, test.x, test.want, got)
+		}
+	}
+	b := Binomial{N: 10, P: 0.25}
+	for _, x := range []float64{-1, 11, 2.5} {
+		if !math.IsInf(b.LogProb(x), -1) {
+			t.Errorf("LogProb outside the support is not -Inf at x = %v", x)
+		}
+	}
+}
+
+func TestBinomialCDF(t *testing.T) {
+	b := Binomial{N: 6, P: 0.4}
+	var want float64
+	for k := 0; k <= 6; k++ {
+		want += b.Prob(float64(k))
+		if got := b.CDF(float64(k)); math.Abs(got-want) > 1e-14 {
+			t.Errorf("CDF mismatch at %d. Expected %v, Found %v", k, want, got)
+		}
+	}
+	if math.Abs(want-1) > 1e-14 {
+		t.Errorf("Probabilities do not sum to 1. Found %v", want)
+	}
+}
+
+func TestBinomialRand(t *testing.T) {
+	for _, test := range []struct {
+		n, p float64
+	}{
+		{10, 0.3},
+		{100, 0.2},
+		{1000, 0.9},
+	} {
+		b := Binomial{N: test.n, P: test.p, Source: rand.New(rand.NewSource(1))}
+		x := make([]float64, 100000)
+		for i := range x {
+			x[i] = b.Rand()
+		}
+		checkMeanVariance(t, x, b.Mean(), b.Variance(), "Binomial")
+	}
+
+	// The frequencies match the probabilities when the mean is small.
+	for _, test := range []struct {
+		n, p float64
+	}{
+		{100, 0.001},
+		{40, 0.98},
+	} {
+		b := Binomial{N: test.n, P: test.p, Source: rand.New(rand.NewSource(1))}
+		const samples = 200000
+		count := make(map[float64]int)
+		for i := 0; i < samples; i++ {
+			count[b.Rand()]++
+		}
+		for x, c := range count {
+			want := b.Prob(x)
+			got := float64(c) / samples
+			if math.Abs(got-want) > 5*math.Sqrt(want*(1-want)/samples)+1e-4 {
+				t.Errorf("Frequency mismatch for N = %v, P = %v at %v. Expected %v, Found %v", test.n, test.p, x, want, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// BoundaryTol is the absolute distance outside the support of a continuous
+// distribution within which an argument of Prob, LogProb, CDF, Survival and
+// their variants is treated as lying on the boundary. Data computed by
+// subtraction can fall just outside a closed support, for example at -1e-16
+// instead of 0, and with BoundaryTol set to a small positive value such a
+// point has the density and probabilities of the boundary rather than those
+// of a point outside the support.
+//
+// The tolerance applies to the lower bound 0 of the distributions supported
+// on [0, +∞), and to both bounds of Uniform, TruncatedNormal and Kumaraswamy.
+// The default, 0, keeps the exact comparisons. BoundaryTol should be set
+// before the distributions are used, and not while they are being evaluated
+// concurrently.
+var BoundaryTol float64
+
+// snapToSupport returns the nearer bound of [lo, hi] if x is outside it by
+// at most BoundaryTol, and x otherwise.
+func snapToSupport(x, lo, hi float64) float64 {
+	if BoundaryTol == 0 {
+		return x
+	}
+	if x < lo && x >= lo-BoundaryTol {
+		return lo
+	}
+	if x > hi && x <= hi+BoundaryTol {
+		return hi
+	}
+	return x
+}
+
+// snapNonNegative returns snapToSupport(x, 0, +∞).
+func snapNonNegative(x float64) float64 {
+	return snapToSupport(x, 0, math.Inf(1))
+}
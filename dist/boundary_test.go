@@ -0,0 +1,58 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBoundaryTol(t *testing.T) {
+	defer func(tol float64) { BoundaryTol = tol }(BoundaryTol)
+
+	e := Exponential{Rate: 2}
+	u := Uniform{Min: -1, Max: 3}
+	w := Weibull{K: 0.5, Lambda: 1}
+	const eps = 1e-16
+	for _, test := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"Exponential.Prob", e.Prob(-eps), 0},
+		{"Exponential.LogProb", e.LogProb(-eps), math.Inf(-1)},
+		{"Uniform.Prob", u.Prob(3 + 4*eps), 0},
+		{"Weibull.Prob", w.Prob(-eps), 0},
+	} {
+		if test.got != test.want {
+			t.Errorf("%s mismatch outside the support without tolerance. Expected %v, Found %v", test.name, test.want, test.got)
+		}
+	}
+
+	BoundaryTol = 1e-12
+	for _, test := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"Exponential.Prob", e.Prob(-eps), e.Prob(0)},
+		{"Exponential.LogProb", e.LogProb(-eps), e.LogProb(0)},
+		{"Exponential.ProbSlice", e.ProbSlice([]float64{-eps})[0], e.Prob(0)},
+		{"Uniform.Prob", u.Prob(3 + 4*eps), u.Prob(3)},
+		{"Uniform.Prob lower", u.Prob(-1 - eps), u.Prob(-1)},
+		{"Uniform.CDF", u.CDF(-1 - eps), 0},
+		{"Weibull.Prob", w.Prob(-eps), math.Inf(1)},
+		{"Weibull.LogProbSlice", w.LogProbSlice([]float64{-eps})[0], w.LogProb(0)},
+		{"Weibull.Survival", w.Survival(-eps), 1},
+		{"Gamma.LogProb", (Gamma{Alpha: 1, Beta: 2}).LogProb(-eps), math.Log(2)},
+		{"TruncatedNormal.Prob", (TruncatedNormal{Mu: 0, Sigma: 1, Lower: 0, Upper: 1}).Prob(1 + 4*eps),
+			(TruncatedNormal{Mu: 0, Sigma: 1, Lower: 0, Upper: 1}).Prob(1)},
+		// Points farther outside than the tolerance are unaffected.
+		{"Exponential.Prob far", e.Prob(-1e-9), 0},
+		{"Uniform.Prob far", u.Prob(3.001), 0},
+	} {
+		if test.got != test.want {
+			t.Errorf("%s mismatch with tolerance. Expected %v, Found %v", test.name, test.want, test.got)
+		}
+	}
+}
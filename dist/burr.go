@@ -0,0 +1,251 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// Burr represents the Burr type XII distribution, also known as the
+// Singh-Maddala distribution
+// (https://en.wikipedia.org/wiki/Burr_distribution).
+// The cumulative distribution function is
+//  CDF(x) = 1 - (1 + (x/λ)^C)^(-K)
+// Valid range for x is (0,+∞). The moment of order r exists only if r < C*K.
+type Burr struct {
+	C      float64 // First shape parameter
+	K      float64 // Second shape parameter
+	Lambda float64 // Scale parameter
+	Source Source
+}
+
+// NewBurr returns a Burr distribution with shapes c and k and scale lambda
+// that samples from src. NewBurr returns an error if any parameter is not
+// positive and finite.
+func NewBurr(c, k, lambda float64, src Source) (Burr, error) {
+	b := Burr{C: c, K: k, Lambda: lambda, Source: src}
+	if err := b.Validate(); err != nil {
+		return Burr{}, err
+	}
+	return b, nil
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (b Burr) CDF(x float64) float64 {
+	x = snapNonNegative(x)
+	if x <= 0 {
+		return 0
+	}
+	return -math.Expm1(-b.K * math.Log1p(math.Pow(x/b.Lambda, b.C)))
+}
+
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with n intervals.
+func (b Burr) IntegrateProb(lo, hi float64, n int) float64 {
+	return integrateProb(b, lo, hi, n)
+}
+
+// InverseSurvival returns the inverse of the survival function.
+func (b Burr) InverseSurvival(q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	return b.Lambda * math.Pow(math.Expm1(-math.Log(q)/b.K), 1/b.C)
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (b Burr) LogProb(x float64) float64 {
+	x = snapNonNegative(x)
+	if x < 0 {
+		return math.Inf(-1)
+	}
+	if math.IsInf(x, 1) {
+		return math.Inf(-1)
+	}
+	if x == 0 {
+		switch {
+		case b.C < 1:
+			return math.Inf(1)
+		case b.C == 1:
+			return math.Log(b.K / b.Lambda)
+		}
+		return math.Inf(-1)
+	}
+	z := x / b.Lambda
+	return math.Log(b.C*b.K/b.Lambda) + (b.C-1)*math.Log(z) - (b.K+1)*math.Log1p(math.Pow(z, b.C))
+}
+
+// MarshalParameters implements the ParameterMarshaler interface
+func (b Burr) MarshalParameters(p []Parameter) {
+	if len(p) != b.NumParameters() {
+		panic("burr: improper parameter length")
+	}
+	p[0].Name = "C"
+	p[0].Value = b.C
+	p[1].Name = "K"
+	p[1].Value = b.K
+	p[2].Name = "λ"
+	p[2].Value = b.Lambda
+	return
+}
+
+// Mean returns the mean of the probability distribution. The mean is +Inf
+// if C*K <= 1.
+func (b Burr) Mean() float64 {
+	if b.C*b.K <= 1 {
+		return math.Inf(1)
+	}
+	return b.rawMoment(1)
+}
+
+// Median returns the median of the probability distribution.
+func (b Burr) Median() float64 {
+	return b.Quantile(0.5)
+}
+
+// Mode returns the mode of the probability distribution.
+func (b Burr) Mode() float64 {
+	if b.C <= 1 {
+		return 0
+	}
+	return b.Lambda * math.Pow((b.C-1)/(b.C*b.K+1), 1/b.C)
+}
+
+// Moment returns the k-th raw moment E[X^k] of the distribution, or the k-th
+// central moment E[(X-μ)^k] if central is true. The raw moments are
+// λ^k K B(K - k/C, 1 + k/C) for k < C*K and +Inf otherwise, and the central
+// moments are computed from them. Moment panics if k is negative.
+func (b Burr) Moment(k int, central bool) float64 {
+	checkMomentOrder(k)
+	if float64(k) >= b.C*b.K {
+		return math.Inf(1)
+	}
+	raw := func(j int) float64 {
+		return b.rawMoment(float64(j))
+	}
+	if central {
+		return centralFromRaw(k, raw)
+	}
+	return raw(k)
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (b Burr) NormalizationCheck() float64 {
+	return normalization(b)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Burr) NumParameters() int {
+	return 3
+}
+
+// Prob computes the value of the probability density function at x.
+func (b Burr) Prob(x float64) float64 {
+	return math.Exp(b.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+// Quantile(0) is 0 and Quantile(1) is +Inf, the bounds of the support.
+func (b Burr) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	return b.Lambda * math.Pow(math.Expm1(-math.Log1p(-p)/b.K), 1/b.C)
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (b Burr) Rand() float64 {
+	return b.Quantile(randFloat64(b.Source))
+}
+
+// RandAntithetic returns n samples drawn from the distribution in antithetic
+// pairs, as described for randAntithetic.
+func (b Burr) RandAntithetic(n int) []float64 {
+	return randAntithetic(b, n, b.Source)
+}
+
+// rawMoment returns E[X^r] = λ^r K B(K - r/C, 1 + r/C), which is finite for
+// r < C*K.
+func (b Burr) rawMoment(r float64) float64 {
+	s := r / b.C
+	lg1, _ := math.Lgamma(b.K - s)
+	lg2, _ := math.Lgamma(1 + s)
+	lg3, _ := math.Lgamma(b.K)
+	return math.Pow(b.Lambda, r) * math.Exp(lg1+lg2-lg3)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (b Burr) StdDev() float64 {
+	return math.Sqrt(b.Variance())
+}
+
+// StratifiedSample returns n stratified samples from the distribution, as
+// described for stratifiedSample, drawing uniforms from src or, if src is nil,
+// from the Source of the distribution.
+func (b Burr) StratifiedSample(n int, src Source) []float64 {
+	if src == nil {
+		src = b.Source
+	}
+	return stratifiedSample(b, n, src)
+}
+
+// String implements the fmt.Stringer interface.
+func (b Burr) String() string {
+	return formatParams(&b)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (b Burr) Survival(x float64) float64 {
+	x = snapNonNegative(x)
+	if x <= 0 {
+		return 1
+	}
+	return math.Exp(-b.K * math.Log1p(math.Pow(x/b.Lambda, b.C)))
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface
+func (b *Burr) UnmarshalParameters(p []Parameter) {
+	if len(p) != b.NumParameters() {
+		panic("burr: incorrect number of parameters to set")
+	}
+	if p[0].Name != "C" {
+		panic("burr: " + panicNameMismatch)
+	}
+	if p[1].Name != "K" {
+		panic("burr: " + panicNameMismatch)
+	}
+	if !isLambda(p[2].Name) {
+		panic("burr: " + panicNameMismatch)
+	}
+	b.C = p[0].Value
+	b.K = p[1].Value
+	b.Lambda = p[2].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if C, K and λ are positive and
+// finite.
+func (b Burr) Validate() error {
+	return firstError(
+		checkPositive("burr", "C", b.C),
+		checkPositive("burr", "K", b.K),
+		checkPositive("burr", "λ", b.Lambda),
+	)
+}
+
+// Variance returns the variance of the probability distribution. The variance
+// is +Inf if 1 < C*K <= 2 and NaN if C*K <= 1, where the mean is also
+// infinite.
+func (b Burr) Variance() float64 {
+	ck := b.C * b.K
+	if ck <= 1 {
+		return math.NaN()
+	}
+	if ck <= 2 {
+		return math.Inf(1)
+	}
+	m := b.rawMoment(1)
+	return b.rawMoment(2) - m*m
+}
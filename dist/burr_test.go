@@ -0,0 +1,91 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBurrQuantile(t *testing.T) {
+	for _, b := range []Burr{
+		{C: 0.5, K: 1, Lambda: 1},
+		{C: 2, K: 3, Lambda: 1},
+		{C: 5, K: 0.5, Lambda: 10},
+		{C: 1, K: 1, Lambda: 2},
+	} {
+		for _, p := range []float64{1e-10, 0.001, 0.1, 0.5, 0.9, 0.999, 1 - 1e-10} {
+			x := b.Quantile(p)
+			if cdf := b.CDF(x); !equalRel(cdf, p, 1e-10) {
+				t.Errorf("CDF(Quantile) mismatch for %#v at %v. Expected %v, Found %v", b, p, p, cdf)
+			}
+			if s := b.Survival(x); !equalRel(s, 1-p, 1e-8) {
+				t.Errorf("Survival(Quantile) mismatch for %#v at %v. Expected %v, Found %v", b, p, 1-p, s)
+			}
+		}
+		if q := b.Quantile(0); q != 0 {
+			t.Errorf("Quantile(0) mismatch for %#v. Expected 0, Found %v", b, q)
+		}
+		if q := b.Quantile(1); !math.IsInf(q, 1) {
+			t.Errorf("Quantile(1) mismatch for %#v. Expected +Inf, Found %v", b, q)
+		}
+		if b.C < 1 {
+			// The density is unbounded at zero.
+			continue
+		}
+		for _, x := range []float64{0.5, 1, 3} {
+			lo := b.Quantile(1e-12)
+			if cdf := integrate(b.Prob, lo, x, 20000) + b.CDF(lo); math.Abs(cdf-b.CDF(x)) > 1e-7 {
+				t.Errorf("CDF mismatch at %v for %#v. Expected %v, Found %v", x, b, cdf, b.CDF(x))
+			}
+		}
+	}
+}
+
+func TestBurrMoments(t *testing.T) {
+	for _, b := range []Burr{
+		{C: 3, K: 2, Lambda: 1},
+		{C: 4, K: 1.5, Lambda: 2},
+		{C: 10, K: 1, Lambda: 0.5},
+	} {
+		hi := b.Quantile(1 - 1e-13)
+		mean := integrate(func(x float64) float64 { return x * b.Prob(x) }, 0, hi, 200000)
+		variance := integrate(func(x float64) float64 { return (x - mean) * (x - mean) * b.Prob(x) }, 0, hi, 200000)
+		if !equalRel(b.Mean(), mean, 1e-4) {
+			t.Errorf("Mean mismatch for %#v. Expected %v, Found %v", b, mean, b.Mean())
+		}
+		if !equalRel(b.Variance(), variance, 1e-3) {
+			t.Errorf("Variance mismatch for %#v. Expected %v, Found %v", b, variance, b.Variance())
+		}
+		if m := b.Mode(); b.Prob(m) < b.Prob(m*0.99) || b.Prob(m) < b.Prob(m*1.01) {
+			t.Errorf("Mode of %#v is not a maximum", b)
+		}
+
+		b.Source = rand.New(rand.NewSource(1))
+		x := make([]float64, 100000)
+		for i := range x {
+			x[i] = b.Rand()
+		}
+		checkMeanVariance(t, x, b.Mean(), b.Variance(), "Burr")
+	}
+}
+
+func TestBurrUndefinedMoments(t *testing.T) {
+	b := Burr{C: 1, K: 0.5, Lambda: 1}
+	if m := b.Mean(); !math.IsInf(m, 1) {
+		t.Errorf("Mean mismatch for C*K <= 1. Expected +Inf, Found %v", m)
+	}
+	if v := b.Variance(); !math.IsNaN(v) {
+		t.Errorf("Variance mismatch for C*K <= 1. Expected NaN, Found %v", v)
+	}
+	b = Burr{C: 1.5, K: 1, Lambda: 1}
+	if m := b.Mean(); math.IsInf(m, 0) || math.IsNaN(m) {
+		t.Errorf("Mean mismatch for 1 < C*K <= 2. Expected finite, Found %v", m)
+	}
+	if v := b.Variance(); !math.IsInf(v, 1) {
+		t.Errorf("Variance mismatch for 1 < C*K <= 2. Expected +Inf, Found %v", v)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gonum/stat"
+)
+
+// Categorical represents a random variable taking the values 0, 1, …, n-1
+// with probabilities proportional to the elements of Weights
+// (https://en.wikipedia.org/wiki/Categorical_distribution).
+//
+// Weights is a slice, so copying a Categorical by assignment shares the
+// weights between the copies. Use Clone to obtain an independent copy.
+type Categorical struct {
+	// Weights are the unnormalized probabilities of each category. They
+	// must be non-negative and finite, with a positive sum.
+	Weights []float64
+	// Source of random numbers
+	Source Source
+}
+
+// NewCategorical returns a Categorical distribution with the given weights
+// that samples from src. The weights are copied. NewCategorical returns an
+// error if any weight is negative or not finite, or if the weights sum to
+// zero.
+func NewCategorical(weights []float64, src Source) (Categorical, error) {
+	c := Categorical{Weights: append([]float64(nil), weights...), Source: src}
+	if err := c.Validate(); err != nil {
+		return Categorical{}, err
+	}
+	return c, nil
+}
+
+// CategoricalFromLogits returns a Categorical distribution whose probabilities
+// are the softmax of the logits, computed by stat.Softmax, that samples from
+// src. This is the usual parameterization of a gate that selects among
+// experts from unconstrained scores. CategoricalFromLogits returns an error
+// if a logit is NaN or +Inf, or if all the logits are -Inf.
+func CategoricalFromLogits(logits []float64, src Source) (Categorical, error) {
+	return NewCategorical(stat.Softmax(logits), src)
+}
+
+// sum returns the sum of the weights.
+func (c Categorical) sum() float64 {
+	var s float64
+	for _, w := range c.Weights {
+		s += w
+	}
+	return s
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (c Categorical) CDF(x float64) float64 {
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 {
+		return 0
+	}
+	var cum float64
+	for i, w := range c.Weights {
+		if float64(i) > x {
+			break
+		}
+		cum += w
+	}
+	return math.Min(cum/c.sum(), 1)
+}
+
+// Clone returns a copy of the distribution that does not share its weights
+// with the original. The Source is shared.
+func (c Categorical) Clone() Categorical {
+	c.Weights = append([]float64(nil), c.Weights...)
+	return c
+}
+
+// Entropy returns the entropy of the distribution in nats.
+func (c Categorical) Entropy() float64 {
+	s := c.sum()
+	var e float64
+	for _, w := range c.Weights {
+		if w > 0 {
+			p := w / s
+			e -= p * math.Log(p)
+		}
+	}
+	return e
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x. LogProb returns -Inf if x is not one of the
+// categories.
+func (c Categorical) LogProb(x float64) float64 {
+	return math.Log(c.Prob(x))
+}
+
+// Mean returns the mean of the probability distribution.
+func (c Categorical) Mean() float64 {
+	var m float64
+	for i, w := range c.Weights {
+		m += float64(i) * w
+	}
+	return m / c.sum()
+}
+
+// Prob computes the value of the probability density function at x.
+func (c Categorical) Prob(x float64) float64 {
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 || x >= float64(len(c.Weights)) || math.Floor(x) != x {
+		return 0
+	}
+	return c.Weights[int(x)] / c.sum()
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (c Categorical) Rand() float64 {
+	u := randFloat64(c.Source) * c.sum()
+	last := 0
+	for i, w := range c.Weights {
+		if w == 0 {
+			continue
+		}
+		last = i
+		u -= w
+		if u < 0 {
+			break
+		}
+	}
+	return float64(last)
+}
+
+// RandGumbelMax returns a random sample drawn from the distribution using the
+// Gumbel-max trick, returning the index i that maximizes
+//  log(Weights[i]) + G_i
+// for independent standard Gumbel variables G_i = -log(E_i), with E_i
+// exponential. The sample has the same distribution as one from Rand.
+//
+// Each sample costs O(len(Weights)) draws from Source and needs no
+// normalization or cumulative table, so the method suits weights that change
+// between draws, or that are only known as unnormalized log probabilities,
+// and it is the basis of the Gumbel-softmax relaxation used for
+// differentiable sampling. When many samples are drawn from fixed weights an
+// alias table, with O(1) cost per sample after O(len(Weights)) setup, is
+// cheaper.
+func (c Categorical) RandGumbelMax() float64 {
+	best := math.Inf(-1)
+	idx := -1
+	for i, w := range c.Weights {
+		if w == 0 {
+			continue
+		}
+		v := math.Log(w) - math.Log(randExpFloat64(c.Source))
+		if v > best {
+			best, idx = v, i
+		}
+	}
+	return float64(idx)
+}
+
+// SupportRange returns the smallest and largest categories, 0 and
+// len(Weights)-1. Categories within the range may have zero weight.
+func (c Categorical) SupportRange() (lo, hi float64) {
+	return 0, float64(len(c.Weights) - 1)
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if every weight is non-negative
+// and finite and the weights have a positive sum.
+func (c Categorical) Validate() error {
+	for i, w := range c.Weights {
+		if err := checkNonNegative("categorical", fmt.Sprintf("Weights[%d]", i), w); err != nil {
+			return err
+		}
+	}
+	if s := c.sum(); !(s > 0) || math.IsInf(s, 1) {
+		return fmt.Errorf("categorical: Weights must have a positive finite sum, found %v", s)
+	}
+	return nil
+}
+
+// Variance returns the variance of the probability distribution.
+func (c Categorical) Variance() float64 {
+	m := c.Mean()
+	var v float64
+	for i, w := range c.Weights {
+		d := float64(i) - m
+		v += d * d * w
+	}
+	return v / c.sum()
+}
@@ -0,0 +1,112 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestCategoricalClone(t *testing.T) {
+	c := Categorical{Weights: []float64{1, 2, 3}}
+	d := c.Clone()
+	d.Weights[0] = 10
+	if c.Weights[0] != 1 {
+		t.Errorf("Clone shares weights with the original. Expected 1, Found %v", c.Weights[0])
+	}
+	if got := c.Prob(0); !equalRel(got, 1.0/6, 1e-15) {
+		t.Errorf("Prob mismatch after mutating clone. Expected %v, Found %v", 1.0/6, got)
+	}
+
+	w := []float64{1, 1}
+	e, err := NewCategorical(w, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w[0] = 5
+	if e.Weights[0] != 1 {
+		t.Errorf("NewCategorical did not copy weights. Expected 1, Found %v", e.Weights[0])
+	}
+}
+
+func TestCategoricalFromLogits(t *testing.T) {
+	c, err := CategoricalFromLogits([]float64{0, math.Log(2), math.Log(3)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []float64{1.0 / 6, 2.0 / 6, 3.0 / 6} {
+		if got := c.Prob(float64(i)); !equalRel(got, want, 1e-15) {
+			t.Errorf("Prob mismatch at %d. Expected %v, Found %v", i, want, got)
+		}
+	}
+	for _, logits := range [][]float64{
+		{math.NaN(), 0},
+		{math.Inf(1), 0},
+		{math.Inf(-1), math.Inf(-1)},
+	} {
+		if _, err := CategoricalFromLogits(logits, nil); err == nil {
+			t.Errorf("CategoricalFromLogits did not return an error for %v", logits)
+		}
+	}
+}
+
+func TestCategorical(t *testing.T) {
+	c := Categorical{Weights: []float64{1, 0, 3, 4}, Source: rand.New(rand.NewSource(1))}
+	probs := []float64{0.125, 0, 0.375, 0.5}
+	var mean, cdf float64
+	for i, p := range probs {
+		x := float64(i)
+		if got := c.Prob(x); !equalRel(got, p, 1e-15) {
+			t.Errorf("Prob mismatch at %v. Expected %v, Found %v", x, p, got)
+		}
+		cdf += p
+		if got := c.CDF(x + 0.5); !equalRel(got, cdf, 1e-15) {
+			t.Errorf("CDF mismatch at %v. Expected %v, Found %v", x+0.5, cdf, got)
+		}
+		mean += x * p
+	}
+	if got := c.Mean(); !equalRel(got, mean, 1e-15) {
+		t.Errorf("Mean mismatch. Expected %v, Found %v", mean, got)
+	}
+	for _, x := range []float64{-1, 0.5, 4} {
+		if got := c.Prob(x); got != 0 {
+			t.Errorf("Prob mismatch at %v. Expected 0, Found %v", x, got)
+		}
+	}
+
+	const n = 100000
+	counts := make([]float64, len(probs))
+	for i := 0; i < n; i++ {
+		counts[int(c.Rand())]++
+	}
+	for i, p := range probs {
+		if got := counts[i] / n; math.Abs(got-p) > 0.01 {
+			t.Errorf("Rand frequency mismatch for %v. Expected %v, Found %v", i, p, got)
+		}
+	}
+
+	if _, err := NewCategorical([]float64{0, 0}, nil); err == nil {
+		t.Errorf("expected error for zero weights")
+	}
+	if _, err := NewCategorical([]float64{1, -1}, nil); err == nil {
+		t.Errorf("expected error for negative weight")
+	}
+}
+
+func TestCategoricalRandGumbelMax(t *testing.T) {
+	c := Categorical{Weights: []float64{1, 0, 3, 4, 0.1}, Source: rand.New(rand.NewSource(1))}
+	const n = 100000
+	counts := make([]float64, len(c.Weights))
+	for i := 0; i < n; i++ {
+		counts[int(c.RandGumbelMax())]++
+	}
+	for i := range c.Weights {
+		p := c.Prob(float64(i))
+		if got := counts[i] / n; math.Abs(got-p) > 5*math.Sqrt(p*(1-p)/n) {
+			t.Errorf("RandGumbelMax frequency mismatch for %v. Expected %v, Found %v", i, p, got)
+		}
+	}
+}
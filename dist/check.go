@@ -0,0 +1,192 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"fmt"
+	"math"
+)
+
+// CheckProbLog verifies that Prob(x) and exp(LogProb(x)) agree to within the
+// relative tolerance tol at each of the values in xs. It returns an error
+// describing the first disagreement, or nil if there is none. CheckProbLog
+// is intended for testing distribution implementations.
+func CheckProbLog(d interface {
+	Prob(float64) float64
+	LogProb(float64) float64
+}, xs []float64, tol float64) error {
+	for _, x := range xs {
+		p := d.Prob(x)
+		e := math.Exp(d.LogProb(x))
+		if p == e {
+			continue
+		}
+		if math.IsNaN(p) || math.IsNaN(e) || math.Abs(p-e) > tol*math.Max(math.Abs(p), math.Abs(e)) {
+			return fmt.Errorf("dist: Prob(%v) = %v but exp(LogProb(%v)) = %v", x, p, x, e)
+		}
+	}
+	return nil
+}
+
+// Derivable is a distribution with analytic derivatives of the log of the
+// probability density function.
+type Derivable interface {
+	ParametricDist
+	DLogProbDX(x float64) float64
+}
+
+// CheckDerivatives compares the analytic derivatives DLogProbDX and
+// DLogProbDParam of d at x against central finite differences of LogProb.
+// It returns an error describing the first derivative that differs by more
+// than tol relative to max(1, |numerical derivative|), or nil if there is
+// none. The finite differences are skipped if LogProb(x) is not finite.
+//
+// CheckDerivatives also reports elements of the slice that DLogProbDParam
+// does not set. The parameters of d are restored before returning.
+// CheckDerivatives is intended for testing distribution implementations.
+func CheckDerivatives(d Derivable, x float64, tol float64) error {
+	n := d.NumParameters()
+	zero := make([]float64, n)
+	one := make([]float64, n)
+	for i := range one {
+		one[i] = 1
+	}
+	d.DLogProbDParam(x, zero)
+	d.DLogProbDParam(x, one)
+	for i := range zero {
+		if zero[i] != one[i] && !(math.IsNaN(zero[i]) && math.IsNaN(one[i])) {
+			return fmt.Errorf("dist: DLogProbDParam(%v) does not set element %d", x, i)
+		}
+	}
+	if lp := d.LogProb(x); math.IsNaN(lp) || math.IsInf(lp, 0) {
+		return nil
+	}
+
+	check := func(name string, analytic, numerical float64) error {
+		if math.Abs(analytic-numerical) > tol*math.Max(1, math.Abs(numerical)) {
+			return fmt.Errorf("dist: %s at %v is %v, numerical derivative is %v", name, x, analytic, numerical)
+		}
+		return nil
+	}
+
+	h := derivStep * math.Max(1, math.Abs(x))
+	numerical := (d.LogProb(x+h) - d.LogProb(x-h)) / (2 * h)
+	if err := check("DLogProbDX", d.DLogProbDX(x), numerical); err != nil {
+		return err
+	}
+
+	params := make([]Parameter, n)
+	d.MarshalParameters(params)
+	orig := make([]Parameter, n)
+	copy(orig, params)
+	defer d.UnmarshalParameters(orig)
+	for i := range params {
+		v := orig[i].Value
+		h := derivStep * math.Max(1, math.Abs(v))
+		params[i].Value = v + h
+		d.UnmarshalParameters(params)
+		up := d.LogProb(x)
+		params[i].Value = v - h
+		d.UnmarshalParameters(params)
+		down := d.LogProb(x)
+		params[i].Value = v
+		numerical := (up - down) / (2 * h)
+		if err := check(fmt.Sprintf("DLogProbDParam %s", orig[i].Name), zero[i], numerical); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckMarshalRoundTrip verifies that the parameters of d survive a
+// MarshalParameters and UnmarshalParameters round trip, and that each
+// parameter set by UnmarshalParameters is the one returned in the same
+// position by MarshalParameters. d must also have a NumParameters method.
+// It returns an error describing the first problem found, including a panic
+// in either method, or nil if there is none. The parameters of d are restored
+// before returning. CheckMarshalRoundTrip is intended for testing
+// distribution implementations.
+func CheckMarshalRoundTrip(d ParameterMarshaler) (err error) {
+	np, ok := d.(interface {
+		NumParameters() int
+	})
+	if !ok {
+		return fmt.Errorf("dist: %T has no NumParameters method", d)
+	}
+	n := np.NumParameters()
+	orig := make([]Parameter, n)
+	params := make([]Parameter, n)
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("dist: marshal round trip panicked: %v", r)
+		}
+	}()
+	d.MarshalParameters(orig)
+	defer d.UnmarshalParameters(orig)
+
+	same := func(a, b float64) bool {
+		return a == b || (math.IsNaN(a) && math.IsNaN(b))
+	}
+	for i := -1; i < n; i++ {
+		copy(params, orig)
+		if i >= 0 {
+			// Move a single parameter so that a value unmarshaled into
+			// the wrong field is detected.
+			params[i].Value = 2*math.Abs(params[i].Value) + 1
+		}
+		d.UnmarshalParameters(params)
+		got := make([]Parameter, n)
+		d.MarshalParameters(got)
+		for j := range got {
+			if got[j].Name != params[j].Name {
+				return fmt.Errorf("dist: parameter %d name changed from %q to %q", j, params[j].Name, got[j].Name)
+			}
+			if !same(got[j].Value, params[j].Value) {
+				return fmt.Errorf("dist: parameter %s unmarshaled as %v, marshaled as %v", params[j].Name, params[j].Value, got[j].Value)
+			}
+		}
+	}
+	return nil
+}
+
+// cdfLimitTol is the tolerance used by CheckCDF for the limits of the
+// cumulative distribution function at ±∞.
+const cdfLimitTol = 1e-12
+
+// CheckCDF verifies that the cumulative distribution function of d is in
+// [0, 1] and non-decreasing at n+1 equally spaced points on [lo, hi], and
+// that it approaches 0 at -∞ and 1 at +∞. It returns an error describing
+// the first violation, or nil if there is none. CheckCDF is intended for
+// testing distribution implementations.
+//
+// CheckCDF panics if lo >= hi or n < 1.
+func CheckCDF(d CDFer, lo, hi float64, n int) error {
+	if !(lo < hi) {
+		panic("dist: invalid CDF check interval")
+	}
+	if n < 1 {
+		panic("dist: too few CDF check points")
+	}
+	if p := d.CDF(math.Inf(-1)); !(math.Abs(p) <= cdfLimitTol) {
+		return fmt.Errorf("dist: CDF(-Inf) = %v, want 0", p)
+	}
+	if p := d.CDF(math.Inf(1)); !(math.Abs(p-1) <= cdfLimitTol) {
+		return fmt.Errorf("dist: CDF(+Inf) = %v, want 1", p)
+	}
+	prevX := math.Inf(-1)
+	prev := 0.0
+	for i := 0; i <= n; i++ {
+		x := lo + (hi-lo)*float64(i)/float64(n)
+		p := d.CDF(x)
+		if !(p >= 0 && p <= 1) {
+			return fmt.Errorf("dist: CDF(%v) = %v is outside [0, 1]", x, p)
+		}
+		if p < prev {
+			return fmt.Errorf("dist: CDF decreases from %v at %v to %v at %v", prev, prevX, p, x)
+		}
+		prevX, prev = x, p
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"testing"
+)
+
+// weibullZeroLogProb has the LogProb of Weibull before negative x was fixed
+// to return -Inf.
+type weibullZeroLogProb struct {
+	Weibull
+}
+
+func (w weibullZeroLogProb) LogProb(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return w.Weibull.LogProb(x)
+}
+
+func TestCheckProbLog(t *testing.T) {
+	xs := []float64{-10, -1, -1e-10, 0, 1e-10, 0.1, 0.5, 1, 2, 5, 10, 100}
+	if err := CheckProbLog(weibullZeroLogProb{Weibull{K: 2, Lambda: 1}}, xs, 1e-14); err == nil {
+		t.Errorf("CheckProbLog did not flag LogProb of 0 for negative x")
+	}
+
+	for _, d := range []interface {
+		Prob(float64) float64
+		LogProb(float64) float64
+	}{
+		Weibull{K: 0.5, Lambda: 1},
+		Weibull{K: 2, Lambda: 3},
+		Normal{Mu: 1, Sigma: 2},
+		Exponential{Rate: 2},
+		Laplace{Mu: 1, Scale: 2},
+		Uniform{Min: -1, Max: 3},
+		TruncatedNormal{Mu: 0, Sigma: 1, Lower: -1, Upper: 2},
+		HalfNormal{Sigma: 2},
+		FoldedNormal{Mu: 1, Sigma: 2},
+		GeneralizedGamma{A: 1, D: 2, P: 1.5},
+		InverseGamma{Alpha: 2, Beta: 3},
+		Kumaraswamy{A: 2, B: 5},
+		Rician{Nu: 1, Sigma: 1},
+		Burr{C: 2, K: 3, Lambda: 1},
+		LogLogistic{Alpha: 1, Beta: 0.5},
+		Poisson{Lambda: 3},
+		Binomial{N: 10, P: 0.3},
+	} {
+		if err := CheckProbLog(d, xs, 1e-14); err != nil {
+			t.Errorf("%T: %v", d, err)
+		}
+	}
+
+	if err := CheckProbLog(Normal{Mu: 0, Sigma: 1}, []float64{math.NaN()}, 1e-14); err == nil {
+		t.Errorf("CheckProbLog did not flag NaN")
+	}
+}
+
+// brokenWeibull has the sign of ∂LogProb / ∂λ flipped.
+type brokenWeibull struct {
+	*Weibull
+}
+
+func (w brokenWeibull) DLogProbDParam(x float64, deriv []float64) {
+	w.Weibull.DLogProbDParam(x, deriv)
+	deriv[1] = -deriv[1]
+}
+
+// unsetWeibull reproduces a DLogProbDParam that set deriv[0] twice at zero
+// and left deriv[1] unset.
+type unsetWeibull struct {
+	*Weibull
+}
+
+func (w unsetWeibull) DLogProbDParam(x float64, deriv []float64) {
+	if x == 0 {
+		deriv[0] = math.NaN()
+		deriv[0] = math.NaN()
+		return
+	}
+	w.Weibull.DLogProbDParam(x, deriv)
+}
+
+func TestCheckDerivatives(t *testing.T) {
+	for _, w := range []Weibull{
+		{K: 0.5, Lambda: 1},
+		{K: 1, Lambda: 2},
+		{K: 3, Lambda: 0.5},
+	} {
+		orig := w
+		for _, x := range []float64{-1, 0, 0.1, 0.5, 1, 2, 4} {
+			if err := CheckDerivatives(&w, x, 1e-6); err != nil {
+				t.Errorf("%#v: %v", orig, err)
+			}
+			if w != orig {
+				t.Fatalf("Parameters not restored. Expected %#v, Found %#v", orig, w)
+			}
+		}
+		if err := CheckDerivatives(brokenWeibull{&w}, 1.3, 1e-6); err == nil {
+			t.Errorf("%#v: sign error in DLogProbDParam not detected", orig)
+		}
+		if err := CheckDerivatives(unsetWeibull{&w}, 0, 1e-6); err == nil {
+			t.Errorf("%#v: unset element of DLogProbDParam not detected", orig)
+		}
+	}
+	for _, d := range []Derivable{
+		&Normal{Mu: 1, Sigma: 2},
+		&Exponential{Rate: 3},
+		&Laplace{Mu: 1, Scale: 2},
+		&Gamma{Alpha: 2.5, Beta: 3},
+	} {
+		for _, x := range []float64{-2, 0.5, 3} {
+			if err := CheckDerivatives(d, x, 1e-6); err != nil {
+				t.Errorf("%T: %v", d, err)
+			}
+		}
+	}
+}
+
+// swappedWeibull unmarshals its parameters into the wrong fields.
+type swappedWeibull struct {
+	Weibull
+}
+
+func (w *swappedWeibull) UnmarshalParameters(p []Parameter) {
+	w.Weibull.UnmarshalParameters(p)
+	w.K, w.Lambda = w.Lambda, w.K
+}
+
+func TestCheckMarshalRoundTrip(t *testing.T) {
+	if err := CheckMarshalRoundTrip(&swappedWeibull{Weibull{K: 2, Lambda: 2}}); err == nil {
+		t.Errorf("CheckMarshalRoundTrip did not flag swapped parameters")
+	}
+	for _, d := range []ParameterMarshaler{
+		&Binomial{N: 10, P: 0.3},
+		&Burr{C: 2, K: 3, Lambda: 1.5},
+		&Erlang{K: 3, Lambda: 2},
+		&Exponential{Rate: 2},
+		&FoldedNormal{Mu: 1, Sigma: 2},
+		&Gamma{Alpha: 2, Beta: 3},
+		&GeneralizedGamma{A: 1, D: 2, P: 3},
+		&HalfNormal{Sigma: 2},
+		&InverseGamma{Alpha: 2, Beta: 3},
+		&Kumaraswamy{A: 2, B: 5},
+		&Laplace{Mu: 1, Scale: 2},
+		&LogLogistic{Alpha: 2, Beta: 3},
+		&Normal{Mu: 1, Sigma: 2},
+		&PearsonIII{Mu: 1, Sigma: 2, Gamma: 0.5},
+		&Poisson{Lambda: 3},
+		&Rician{Nu: 1, Sigma: 2},
+		&TruncatedNormal{Mu: 0, Sigma: 1, Lower: -1, Upper: 2},
+		&Tweedie{Mu: 2, Phi: 1, Power: 1.5},
+		&Uniform{Min: -1, Max: 3},
+		&Weibull{K: 2, Lambda: 3},
+	} {
+		if err := CheckMarshalRoundTrip(d); err != nil {
+			t.Errorf("%T: %v", d, err)
+		}
+	}
+}
+
+func TestUnmarshalLambdaAliases(t *testing.T) {
+	for _, name := range []string{"λ", "lambda", "Lambda"} {
+		var w Weibull
+		w.UnmarshalParameters([]Parameter{{Name: "K", Value: 2}, {Name: name, Value: 3}})
+		if w.K != 2 || w.Lambda != 3 {
+			t.Errorf("Weibull unmarshal with %q mismatch. Expected {2 3}, Found {%v %v}", name, w.K, w.Lambda)
+		}
+		var b Burr
+		b.UnmarshalParameters([]Parameter{{Name: "C", Value: 1}, {Name: "K", Value: 2}, {Name: name, Value: 3}})
+		if b.Lambda != 3 {
+			t.Errorf("Burr unmarshal with %q mismatch. Expected 3, Found %v", name, b.Lambda)
+		}
+		var p Poisson
+		p.UnmarshalParameters([]Parameter{{Name: name, Value: 4}})
+		if p.Lambda != 4 {
+			t.Errorf("Poisson unmarshal with %q mismatch. Expected 4, Found %v", name, p.Lambda)
+		}
+	}
+	if !panics(func() {
+		var w Weibull
+		w.UnmarshalParameters([]Parameter{{Name: "K", Value: 2}, {Name: "lam", Value: 3}})
+	}) {
+		t.Errorf("expected panic for unknown parameter name")
+	}
+}
+
+// brokenCDF has a cumulative distribution function that decreases above 1.
+type brokenCDF struct{}
+
+func (brokenCDF) CDF(x float64) float64 {
+	switch {
+	case x < 0:
+		return 0
+	case x < 1:
+		return x
+	case x < 2:
+		return 2 - x
+	}
+	return 1
+}
+
+func TestCheckCDF(t *testing.T) {
+	if err := CheckCDF(brokenCDF{}, -1, 3, 100); err == nil {
+		t.Errorf("CheckCDF did not flag a decreasing CDF")
+	}
+	if err := CheckCDF(Normal{Mu: 0, Sigma: math.NaN()}, -1, 1, 100); err == nil {
+		t.Errorf("CheckCDF did not flag a NaN CDF")
+	}
+	for _, test := range []struct {
+		d      CDFer
+		lo, hi float64
+	}{
+		{Weibull{K: 0.5, Lambda: 1}, -1, 20},
+		{Weibull{K: 5, Lambda: 2}, -1, 5},
+		{Normal{Mu: 1, Sigma: 2}, -15, 15},
+		{Exponential{Rate: 2}, -1, 10},
+		{Laplace{Mu: 1, Scale: 2}, -20, 20},
+		{Gamma{Alpha: 2, Beta: 3}, -1, 10},
+		{Poisson{Lambda: 3}, -1, 20},
+	} {
+		if err := CheckCDF(test.d, test.lo, test.hi, 1000); err != nil {
+			t.Errorf("%v: %v", test.d, err)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// UpperTailBound returns the Chernoff bound on the upper tail probability
+// P(X ≥ a),
+//  P(X ≥ a) ≤ min_{t≥0} exp(-ta) MGF(t),
+// which holds for any distribution whose moment generating function is finite
+// near zero. MGF must return +Inf where it is not finite. The bound is 1 if a
+// is at or below the mean.
+//
+// The exponent log MGF(t) - ta is convex in t, so its minimum is bracketed by
+// doubling t and then located by golden section search.
+func UpperTailBound(d interface {
+	MGF(float64) float64
+}, a float64) float64 {
+	f := func(t float64) float64 {
+		v := math.Log(d.MGF(t)) - t*a
+		if math.IsNaN(v) {
+			return math.Inf(1)
+		}
+		return v
+	}
+	// Bracket the minimum in [lo, hi]. By convexity, if f(x) < f(xPrev)
+	// with xPrev < x then the minimum is after xPrev, and once f stops
+	// decreasing it is before x.
+	var lo, xPrev, fPrev float64
+	hi := 1.0
+	for fx := f(hi); fx < fPrev && !math.IsInf(fx, 0); fx = f(hi) {
+		lo = xPrev
+		xPrev, fPrev = hi, fx
+		hi *= 2
+	}
+
+	const invPhi = 0.6180339887498949 // (√5 - 1) / 2
+	x1 := hi - invPhi*(hi-lo)
+	x2 := lo + invPhi*(hi-lo)
+	f1, f2 := f(x1), f(x2)
+	for i := 0; i < 200 && hi-lo > 1e-12*math.Max(1, hi); i++ {
+		if f1 <= f2 {
+			hi, x2, f2 = x2, x1, f1
+			x1 = hi - invPhi*(hi-lo)
+			f1 = f(x1)
+		} else {
+			lo, x1, f1 = x1, x2, f2
+			x2 = lo + invPhi*(hi-lo)
+			f2 = f(x2)
+		}
+	}
+	return math.Min(1, math.Exp(math.Min(0, math.Min(f1, f2))))
+}
@@ -0,0 +1,68 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUpperTailBound(t *testing.T) {
+	for _, test := range []struct {
+		dist interface {
+			MGF(float64) float64
+			Survival(float64) float64
+			Mean() float64
+		}
+		discrete bool
+	}{
+		{Normal{Mu: 1, Sigma: 2}, false},
+		{Exponential{Rate: 0.01}, false},
+		{Exponential{Rate: 3}, false},
+		{Gamma{Alpha: 2.5, Beta: 0.5}, false},
+		{Poisson{Lambda: 4}, true},
+	} {
+		mean := test.dist.Mean()
+		for _, a := range []float64{mean - 1, mean, mean + 1, 2 * mean, 5 * mean, 20 * mean} {
+			bound := UpperTailBound(test.dist, a)
+			// P(X ≥ a), which for the Poisson at integer a is P(X > a-1).
+			tail := test.dist.Survival(a)
+			if test.discrete {
+				tail = test.dist.Survival(math.Ceil(a) - 1)
+			}
+			// Allow for the rounding of the survival function computed as
+			// 1 - CDF.
+			if bound < tail-1e-15 {
+				t.Errorf("UpperTailBound below the tail for %v at %v. Tail %v, Found %v", test.dist, a, tail, bound)
+			}
+			if a <= mean && !equalRel(bound, 1, 1e-14) {
+				t.Errorf("UpperTailBound mismatch for %v at %v below the mean. Expected 1, Found %v", test.dist, a, bound)
+			}
+		}
+	}
+
+	// The Chernoff bound for the normal is exp(-z²/2). The tail is at least
+	// φ(z) z/(1+z²), so the bound exceeds it by at most √(2π) (1+z²)/z.
+	n := Normal{Mu: 1, Sigma: 2}
+	for _, z := range []float64{1, 2, 3, 5} {
+		a := n.Mu + z*n.Sigma
+		bound := UpperTailBound(n, a)
+		if want := math.Exp(-z * z / 2); !equalRel(bound, want, 1e-8) {
+			t.Errorf("UpperTailBound mismatch for %v at %v. Expected %v, Found %v", n, a, want, bound)
+		}
+		if ratio := bound / n.Survival(a); ratio > math.Sqrt(2*math.Pi)*(1+z*z)/z {
+			t.Errorf("UpperTailBound not tight for %v at %v. Ratio to tail %v", n, a, ratio)
+		}
+	}
+
+	// Exponential: the bound is (a·rate) exp(1 - a·rate) for a ≥ mean.
+	e := Exponential{Rate: 3}
+	for _, a := range []float64{1, 2, 10} {
+		want := a * e.Rate * math.Exp(1-a*e.Rate)
+		if bound := UpperTailBound(e, a); !equalRel(bound, want, 1e-8) {
+			t.Errorf("UpperTailBound mismatch for %v at %v. Expected %v, Found %v", e, a, want, bound)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+)
+
+// ConcurrentRander generates random samples from a distribution and is safe
+// for concurrent use by multiple goroutines. A *rand.Rand may not be shared
+// between goroutines, so ConcurrentRander keeps a pool of distribution
+// instances, each with its own source of random numbers.
+//
+// The sources are seeded deterministically, but the sequence of samples seen
+// by a goroutine depends on the scheduling of the calls to Rand.
+type ConcurrentRander struct {
+	pool sync.Pool
+	seed int64
+}
+
+// NewConcurrentRander returns a ConcurrentRander sampling from the
+// distributions returned by newDist. newDist is called with a new source each
+// time an additional instance of the distribution is needed, and the returned
+// distribution must draw its samples from that source. The sources are seeded
+// from seed.
+func NewConcurrentRander(newDist func(src *rand.Rand) Rander, seed int64) *ConcurrentRander {
+	c := &ConcurrentRander{seed: seed}
+	c.pool.New = func() interface{} {
+		s := atomic.AddInt64(&c.seed, 1)
+		return newDist(rand.New(rand.NewSource(s)))
+	}
+	return c
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (c *ConcurrentRander) Rand() float64 {
+	d := c.pool.Get().(Rander)
+	x := d.Rand()
+	c.pool.Put(d)
+	return x
+}
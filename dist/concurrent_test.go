@@ -0,0 +1,38 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentRander(t *testing.T) {
+	w := Weibull{K: 1.5, Lambda: 2}
+	c := NewConcurrentRander(func(src *rand.Rand) Rander {
+		d := w
+		d.Source = src
+		return d
+	}, 1)
+
+	const (
+		goroutines = 16
+		perRoutine = 10000
+	)
+	x := make([]float64, goroutines*perRoutine)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(samples []float64) {
+			defer wg.Done()
+			for j := range samples {
+				samples[j] = c.Rand()
+			}
+		}(x[i*perRoutine : (i+1)*perRoutine])
+	}
+	wg.Wait()
+	checkMeanVariance(t, x, w.Mean(), w.Variance(), "ConcurrentRander")
+}
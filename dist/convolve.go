@@ -0,0 +1,82 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// Convolve returns the probability density function of X+Y, where X and Y
+// are independent with densities a and b. The density at z is the integral
+//  ∫ a(z-y) b(y) dy
+// over [lo, hi], which should contain almost all of the probability of Y. It
+// is evaluated with Simpson's rule on n equal intervals, rounded up to an even
+// number, so each evaluation of the returned function calls a.Prob n+1
+// times. The values of b on the grid are computed once.
+//
+// The sum is known in closed form in the following cases, for which the
+// exact density is returned and lo, hi and n are ignored:
+//  Normal + Normal: Normal with the sum of the means and of the variances
+//  Gamma + Gamma with equal Beta: Gamma with the sum of the shapes
+//  Poisson + Poisson: Poisson with the sum of the rates
+// For Poisson the returned function is the probability mass function.
+//
+// Convolve panics if lo >= hi or n < 2.
+func Convolve(a, b Prober, lo, hi float64, n int) func(float64) float64 {
+	if p, ok := convolveExact(a, b); ok {
+		return p.Prob
+	}
+	if !(lo < hi) {
+		panic("dist: invalid convolution interval")
+	}
+	if n < 2 {
+		panic("dist: too few convolution intervals")
+	}
+	if n%2 == 1 {
+		n++
+	}
+	h := (hi - lo) / float64(n)
+	y := make([]float64, n+1)
+	wb := make([]float64, n+1)
+	for i := range y {
+		y[i] = lo + float64(i)*h
+		w := 2.0
+		switch {
+		case i == 0 || i == n:
+			w = 1
+		case i%2 == 1:
+			w = 4
+		}
+		wb[i] = w * h / 3 * b.Prob(y[i])
+	}
+	return func(z float64) float64 {
+		var sum float64
+		for i, w := range wb {
+			if w == 0 {
+				continue
+			}
+			sum += w * a.Prob(z-y[i])
+		}
+		return sum
+	}
+}
+
+// convolveExact returns the distribution of the sum of independent samples
+// from a and b if it is known in closed form.
+func convolveExact(a, b Prober) (Prober, bool) {
+	switch a := a.(type) {
+	case Normal:
+		if b, ok := b.(Normal); ok {
+			return Normal{Mu: a.Mu + b.Mu, Sigma: math.Hypot(a.Sigma, b.Sigma)}, true
+		}
+	case Gamma:
+		if b, ok := b.(Gamma); ok && a.Beta == b.Beta {
+			return Gamma{Alpha: a.Alpha + b.Alpha, Beta: a.Beta}, true
+		}
+	case Poisson:
+		if b, ok := b.(Poisson); ok {
+			return Poisson{Lambda: a.Lambda + b.Lambda}, true
+		}
+	}
+	return nil, false
+}
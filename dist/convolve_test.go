@@ -0,0 +1,78 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"testing"
+)
+
+// opaqueProber hides the concrete type of a distribution from Convolve.
+type opaqueProber struct {
+	Prober
+}
+
+func TestConvolveNormal(t *testing.T) {
+	a := Normal{Mu: 1, Sigma: 2}
+	b := Normal{Mu: -3, Sigma: 0.5}
+	want := Normal{Mu: -2, Sigma: math.Sqrt(4.25)}
+	numeric := Convolve(opaqueProber{a}, opaqueProber{b}, b.Mu-10*b.Sigma, b.Mu+10*b.Sigma, 400)
+	exact := Convolve(a, b, 0, 1, 2)
+	for _, z := range []float64{-12, -5, -2, 0, 3, 8} {
+		if got := numeric(z); math.Abs(got-want.Prob(z)) > 1e-10 {
+			t.Errorf("Numerical convolution mismatch at %v. Expected %v, Found %v", z, want.Prob(z), got)
+		}
+		if got := exact(z); !equalRel(got, want.Prob(z), 1e-14) {
+			t.Errorf("Exact convolution mismatch at %v. Expected %v, Found %v", z, want.Prob(z), got)
+		}
+	}
+}
+
+func TestConvolveExact(t *testing.T) {
+	ga := Gamma{Alpha: 2, Beta: 3}
+	gb := Gamma{Alpha: 0.5, Beta: 3}
+	gw := Gamma{Alpha: 2.5, Beta: 3}
+	exact := Convolve(ga, gb, 0, 1, 2)
+	for _, z := range []float64{0.1, 0.5, 1, 3} {
+		if got := exact(z); !equalRel(got, gw.Prob(z), 1e-14) {
+			t.Errorf("Gamma convolution mismatch at %v. Expected %v, Found %v", z, gw.Prob(z), got)
+		}
+	}
+
+	// Gamma distributions with different rates are convolved numerically.
+	gc := Gamma{Alpha: 3, Beta: 1}
+	f := Convolve(ga, gc, 0, 40, 4000)
+	dz := 0.01
+	var total, mean float64
+	for z := dz / 2; z < 50; z += dz {
+		p := f(z)
+		total += p * dz
+		mean += z * p * dz
+	}
+	if math.Abs(total-1) > 1e-4 {
+		t.Errorf("Numerical Gamma convolution does not integrate to 1. Found %v", total)
+	}
+	if want := ga.Mean() + gc.Mean(); math.Abs(mean-want) > 1e-3 {
+		t.Errorf("Numerical Gamma convolution mean mismatch. Expected %v, Found %v", want, mean)
+	}
+
+	pa := Poisson{Lambda: 2}
+	pb := Poisson{Lambda: 3.5}
+	pw := Poisson{Lambda: 5.5}
+	pf := Convolve(pa, pb, 0, 1, 2)
+	for k := 0.0; k < 15; k++ {
+		var sum float64
+		for j := 0.0; j <= k; j++ {
+			sum += pa.Prob(j) * pb.Prob(k-j)
+		}
+		if got := pf(k); !equalRel(got, sum, 1e-12) || !equalRel(got, pw.Prob(k), 1e-14) {
+			t.Errorf("Poisson convolution mismatch at %v. Expected %v, Found %v", k, sum, got)
+		}
+	}
+
+	if !panics(func() { Convolve(opaqueProber{ga}, opaqueProber{gb}, 1, 0, 10) }) {
+		t.Errorf("expected panic for empty interval")
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCumulants(t *testing.T) {
+	for _, d := range []interface {
+		Cumulants(int) []float64
+		Mean() float64
+		Variance() float64
+		Skewness() float64
+		ExKurtosis() float64
+	}{
+		Normal{Mu: 1, Sigma: 2},
+		Poisson{Lambda: 3},
+		Exponential{Rate: 2},
+		Gamma{Alpha: 2.5, Beta: 3},
+		Erlang{K: 3, Lambda: 2},
+	} {
+		k := d.Cumulants(4)
+		for _, m := range []struct {
+			name      string
+			got, want float64
+		}{
+			{"Mean", k[0], d.Mean()},
+			{"Variance", k[1], d.Variance()},
+			{"Skewness", k[2] / math.Pow(k[1], 1.5), d.Skewness()},
+			{"ExKurtosis", k[3] / (k[1] * k[1]), d.ExKurtosis()},
+		} {
+			if math.Abs(m.got-m.want) > 1e-14*math.Max(1, math.Abs(m.want)) {
+				t.Errorf("%s mismatch for %v. Expected %v, Found %v", m.name, d, m.want, m.got)
+			}
+		}
+		if got := d.Cumulants(0); len(got) != 0 {
+			t.Errorf("Expected no cumulants for %v, Found %v", d, got)
+		}
+	}
+
+	// Cumulants add for sums of independent variables, and the sum of
+	// independent Poisson variables is Poisson.
+	a, b := Poisson{Lambda: 2}, Poisson{Lambda: 3.5}
+	ka, kb := a.Cumulants(6), b.Cumulants(6)
+	sum := Poisson{Lambda: a.Lambda + b.Lambda}.Cumulants(6)
+	for j := range sum {
+		if !equalRel(ka[j]+kb[j], sum[j], 1e-15) {
+			t.Errorf("Cumulant %d of the Poisson sum mismatch. Expected %v, Found %v", j+1, sum[j], ka[j]+kb[j])
+		}
+	}
+
+	if got, want := (Exponential{Rate: 2}).Cumulants(5)[4], 24.0/32; !equalRel(got, want, 1e-15) {
+		t.Errorf("Fifth exponential cumulant mismatch. Expected %v, Found %v", want, got)
+	}
+	if !panics(func() { (Normal{Mu: 0, Sigma: 1}).Cumulants(-1) }) {
+		t.Errorf("Cumulants did not panic with a negative count")
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gonum/floats"
+)
+
+// Curve returns n evenly spaced points xs over [lo, hi] and the values of
+// the probability density function of d at those points, suitable for
+// plotting.
+//
+// If lo or hi is infinite, the bound is chosen from d, which must then also
+// implement Quantiler. The bound of the support given by Quantile(0) or
+// Quantile(1) is used if it is finite, and otherwise Quantile(0.001) or
+// Quantile(0.999).
+//
+// Curve panics if n < 2, or if a bound is infinite and d is not a Quantiler.
+func Curve(d Prober, lo, hi float64, n int) (xs, ys []float64) {
+	xs = curvePoints(d, lo, hi, n)
+	ys = make([]float64, n)
+	for i, x := range xs {
+		ys[i] = d.Prob(x)
+	}
+	return xs, ys
+}
+
+// CDFCurve returns n evenly spaced points xs over [lo, hi] and the values of
+// the cumulative distribution function of d at those points. The bounds are
+// chosen as described in Curve.
+func CDFCurve(d CDFer, lo, hi float64, n int) (xs, ys []float64) {
+	xs = curvePoints(d, lo, hi, n)
+	ys = make([]float64, n)
+	for i, x := range xs {
+		ys[i] = d.CDF(x)
+	}
+	return xs, ys
+}
+
+// HazardPlotPoints returns the distinct failure times and the Nelson-Aalen
+// estimate of the cumulative hazard at each of them, for plotting against
+// the cumulative hazard of a fitted distribution. At the distinct time t_j
+// with d_j failures and n_j observations still at risk the estimate is
+//  H(t_j) = Σ_{i≤j} d_i / n_i
+// so tied failures are counted together. The failures slice is not modified.
+func HazardPlotPoints(failures []float64) (times, cumHazard []float64) {
+	x := make([]float64, len(failures))
+	copy(x, failures)
+	sort.Float64s(x)
+	var h float64
+	for i := 0; i < len(x); {
+		j := i + 1
+		for j < len(x) && x[j] == x[i] {
+			j++
+		}
+		h += float64(j-i) / float64(len(x)-i)
+		times = append(times, x[i])
+		cumHazard = append(cumHazard, h)
+		i = j
+	}
+	return times, cumHazard
+}
+
+// curvePoints returns the points at which a curve of d is evaluated.
+func curvePoints(d interface{}, lo, hi float64, n int) []float64 {
+	if n < 2 {
+		panic("dist: curve must have at least two points")
+	}
+	if math.IsInf(lo, 0) || math.IsInf(hi, 0) {
+		q, ok := d.(Quantiler)
+		if !ok {
+			panic("dist: infinite curve bound without a quantile function")
+		}
+		if math.IsInf(lo, 0) {
+			lo = finiteQuantile(q, 0, 0.001)
+		}
+		if math.IsInf(hi, 0) {
+			hi = finiteQuantile(q, 1, 0.999)
+		}
+	}
+	return floats.Span(make([]float64, n), lo, hi)
+}
+
+// finiteQuantile returns the quantile of q at the support bound p if it is
+// finite, and the quantile at pInner otherwise.
+func finiteQuantile(q Quantiler, p, pInner float64) float64 {
+	x := q.Quantile(p)
+	if math.IsInf(x, 0) {
+		x = q.Quantile(pInner)
+	}
+	return x
+}
@@ -0,0 +1,93 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestCurve(t *testing.T) {
+	inf := math.Inf(1)
+	for _, test := range []struct {
+		dist interface {
+			Prober
+			CDFer
+			Quantiler
+		}
+		lo, hi         float64
+		wantLo, wantHi float64
+		n              int
+	}{
+		{Normal{Mu: 1, Sigma: 2}, -3, 5, -3, 5, 9},
+		{Normal{Mu: 1, Sigma: 2}, -inf, inf, Normal{Mu: 1, Sigma: 2}.Quantile(0.001), Normal{Mu: 1, Sigma: 2}.Quantile(0.999), 100},
+		{Weibull{K: 1.5, Lambda: 2}, -inf, inf, 0, Weibull{K: 1.5, Lambda: 2}.Quantile(0.999), 50},
+		{Uniform{Min: -1, Max: 3}, -inf, inf, -1, 3, 2},
+		{Exponential{Rate: 2}, 0.5, inf, 0.5, Exponential{Rate: 2}.Quantile(0.999), 10},
+	} {
+		for _, curve := range []struct {
+			name string
+			fn   func(float64) float64
+			xs   []float64
+			ys   []float64
+		}{
+			{"Curve", test.dist.Prob, nil, nil},
+			{"CDFCurve", test.dist.CDF, nil, nil},
+		} {
+			if curve.name == "Curve" {
+				curve.xs, curve.ys = Curve(test.dist, test.lo, test.hi, test.n)
+			} else {
+				curve.xs, curve.ys = CDFCurve(test.dist, test.lo, test.hi, test.n)
+			}
+			if len(curve.xs) != test.n || len(curve.ys) != test.n {
+				t.Errorf("%s length mismatch for %#v. Expected %d, Found %d and %d", curve.name, test.dist, test.n, len(curve.xs), len(curve.ys))
+				continue
+			}
+			if curve.xs[0] != test.wantLo || curve.xs[test.n-1] != test.wantHi {
+				t.Errorf("%s range mismatch for %#v. Expected [%v, %v], Found [%v, %v]", curve.name, test.dist, test.wantLo, test.wantHi, curve.xs[0], curve.xs[test.n-1])
+			}
+			step := (test.wantHi - test.wantLo) / float64(test.n-1)
+			for i, x := range curve.xs {
+				if math.Abs(x-(test.wantLo+float64(i)*step)) > 1e-12 {
+					t.Errorf("%s points not evenly spaced for %#v at %d", curve.name, test.dist, i)
+				}
+				if curve.ys[i] != curve.fn(x) {
+					t.Errorf("%s value mismatch for %#v at %v. Expected %v, Found %v", curve.name, test.dist, x, curve.fn(x), curve.ys[i])
+				}
+			}
+		}
+	}
+}
+
+func TestHazardPlotPoints(t *testing.T) {
+	times, h := HazardPlotPoints([]float64{3, 1, 2, 3, 1, 3})
+	wantTimes := []float64{1, 2, 3}
+	wantH := []float64{2.0 / 6, 2.0/6 + 1.0/4, 2.0/6 + 1.0/4 + 1}
+	if len(times) != len(wantTimes) || len(h) != len(wantH) {
+		t.Fatalf("HazardPlotPoints length mismatch. Expected %v, Found %v", len(wantTimes), len(times))
+	}
+	for i := range wantTimes {
+		if times[i] != wantTimes[i] || !equalRel(h[i], wantH[i], 1e-14) {
+			t.Errorf("HazardPlotPoints mismatch at %v. Expected (%v, %v), Found (%v, %v)", i, wantTimes[i], wantH[i], times[i], h[i])
+		}
+	}
+
+	w := Weibull{K: 1.5, Lambda: 2, Source: rand.New(rand.NewSource(1))}
+	samples := make([]float64, 10000)
+	for i := range samples {
+		samples[i] = w.Rand()
+	}
+	times, h = HazardPlotPoints(samples)
+	hi := w.Quantile(0.8)
+	for i, x := range times {
+		if x > hi {
+			break
+		}
+		if want := w.CumHazard(x); math.Abs(h[i]-want) > 0.08 {
+			t.Errorf("Nelson-Aalen mismatch at %v. Expected %v, Found %v", x, want, h[i])
+		}
+	}
+}
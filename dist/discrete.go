@@ -0,0 +1,49 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// DiscreteDist is a distribution over the integers. Prob returns the
+// probability mass at x, and SupportRange returns the smallest and largest
+// integers that may have non-zero probability, where hi may be +Inf.
+type DiscreteDist interface {
+	Prob(x float64) float64
+	SupportRange() (lo, hi float64)
+}
+
+// discreteTailMass is the probability left unsummed in the tail of a
+// distribution with infinite support.
+const discreteTailMass = 1e-15
+
+// Entropy returns the Shannon entropy of d in nats,
+//  H = -Σ_x p(x) log p(x),
+// summed over the integers in the support of d. If the support is infinite,
+// the sum stops once the remaining probability is below 1e-15 or the
+// probabilities underflow, and the neglected tail contributes negligibly to
+// the entropy of distributions with light tails.
+func Entropy(d DiscreteDist) float64 {
+	lo, hi := d.SupportRange()
+	var h, mass float64
+	for x := lo; x <= hi; x++ {
+		p := d.Prob(x)
+		if p > 0 {
+			h -= p * math.Log(p)
+		}
+		mass += p
+		// Rounding may keep the total mass just below 1, so also stop once
+		// the probabilities have underflowed in the tail.
+		if math.IsInf(hi, 1) && (mass >= 1-discreteTailMass || (mass > 0 && p == 0)) {
+			break
+		}
+	}
+	return h
+}
+
+// Perplexity returns exp(Entropy(d)), the number of equally likely outcomes
+// that would have the same entropy as d.
+func Perplexity(d DiscreteDist) float64 {
+	return math.Exp(Entropy(d))
+}
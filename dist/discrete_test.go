@@ -0,0 +1,45 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEntropyDiscrete(t *testing.T) {
+	for _, n := range []int{1, 2, 6, 100} {
+		w := make([]float64, n)
+		for i := range w {
+			w[i] = 3
+		}
+		c := Categorical{Weights: w}
+		if got, want := Entropy(c), math.Log(float64(n)); math.Abs(got-want) > 1e-13 {
+			t.Errorf("Entropy mismatch for uniform categorical over %v. Expected %v, Found %v", n, want, got)
+		}
+		if got := Perplexity(c); !equalRel(got, float64(n), 1e-13) {
+			t.Errorf("Perplexity mismatch for uniform categorical over %v. Expected %v, Found %v", n, n, got)
+		}
+	}
+
+	c := Categorical{Weights: []float64{0, 1, 0, 3}}
+	if got, want := Entropy(c), c.Entropy(); math.Abs(got-want) > 1e-14 {
+		t.Errorf("Entropy mismatch for %v. Expected %v, Found %v", c, want, got)
+	}
+
+	// A binomial with one trial is a Bernoulli.
+	b := Binomial{N: 1, P: 0.3}
+	if got, want := Entropy(b), -0.3*math.Log(0.3)-0.7*math.Log(0.7); math.Abs(got-want) > 1e-14 {
+		t.Errorf("Entropy mismatch for %v. Expected %v, Found %v", b, want, got)
+	}
+
+	// For large λ the Poisson entropy is approximately
+	//  log(2πeλ)/2 - 1/(12λ) - 1/(24λ²).
+	p := Poisson{Lambda: 50}
+	want := 0.5*math.Log(2*math.Pi*math.E*p.Lambda) - 1/(12*p.Lambda) - 1/(24*p.Lambda*p.Lambda)
+	if got := Entropy(p); math.Abs(got-want) > 1e-5 {
+		t.Errorf("Entropy mismatch for %v. Expected %v, Found %v", p, want, got)
+	}
+}
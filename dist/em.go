@@ -0,0 +1,82 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// FitEM fits a finite mixture of the components to the samples by the
+// expectation-maximization algorithm, and returns the mixing weights, which
+// sum to 1. The components are fitted in place starting from their current
+// parameters, with equal weights. Each iteration computes the
+// responsibilities, the posterior probability that each sample came from
+// each component, then sets the weights to the mean responsibilities and
+// refits each component by maximum likelihood with the responsibilities as
+// sample weights. Each iteration does not decrease the log-likelihood
+//  Σ_i log Σ_j weights[j] exp(components[j].LogProb(samples[i])).
+// FitEM stops after maxIter iterations, or once an iteration increases the
+// log-likelihood by at most tol times its magnitude.
+//
+// The likelihood of a mixture has local maxima, and the components should
+// start at distinct parameters, since identical components stay identical.
+// FitEM panics if there are no samples or no components, or if maxIter is
+// negative.
+func FitEM(samples []float64, components []ParametricDist, maxIter int, tol float64) (weights []float64) {
+	if len(samples) == 0 {
+		panic("dist: no samples")
+	}
+	k := len(components)
+	if k == 0 {
+		panic("dist: no mixture components")
+	}
+	if maxIter < 0 {
+		panic("dist: negative iteration count")
+	}
+	n := len(samples)
+	weights = make([]float64, k)
+	for j := range weights {
+		weights[j] = 1 / float64(k)
+	}
+	resp := make([][]float64, k)
+	for j := range resp {
+		resp[j] = make([]float64, n)
+	}
+	logp := make([]float64, k)
+	ll := math.Inf(-1)
+	for iter := 0; iter < maxIter; iter++ {
+		// E step.
+		var llNew float64
+		for i, x := range samples {
+			max := math.Inf(-1)
+			for j, d := range components {
+				logp[j] = math.Log(weights[j]) + d.LogProb(x)
+				max = math.Max(max, logp[j])
+			}
+			var sum float64
+			for j := range logp {
+				logp[j] = math.Exp(logp[j] - max)
+				sum += logp[j]
+			}
+			for j := range logp {
+				resp[j][i] = logp[j] / sum
+			}
+			llNew += max + math.Log(sum)
+		}
+		if llNew-ll <= tol*math.Abs(llNew) {
+			break
+		}
+		ll = llNew
+
+		// M step.
+		for j, d := range components {
+			var w float64
+			for _, r := range resp[j] {
+				w += r
+			}
+			weights[j] = w / float64(n)
+			maximizeLikelihood(d, samples, resp[j], nil)
+		}
+	}
+	return weights
+}
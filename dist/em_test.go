@@ -0,0 +1,72 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFitEM(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	truth := []Normal{{Mu: 0, Sigma: 1}, {Mu: 6, Sigma: 0.5}}
+	truthWeights := []float64{0.4, 0.6}
+	x := make([]float64, 2000)
+	for i := range x {
+		c := truth[0]
+		if rnd.Float64() >= truthWeights[0] {
+			c = truth[1]
+		}
+		x[i] = c.Mu + c.Sigma*rnd.NormFloat64()
+	}
+	start := func() []ParametricDist {
+		return []ParametricDist{&Normal{Mu: -1, Sigma: 2}, &Normal{Mu: 5, Sigma: 2}}
+	}
+
+	components := start()
+	weights := FitEM(x, components, 200, 1e-12)
+	var sum float64
+	for i, want := range truth {
+		got := *components[i].(*Normal)
+		if math.Abs(got.Mu-want.Mu) > 0.1 || math.Abs(got.Sigma-want.Sigma) > 0.1*want.Sigma {
+			t.Errorf("Component %d mismatch. Expected %v, Found %v", i, want, got)
+		}
+		if math.Abs(weights[i]-truthWeights[i]) > 0.03 {
+			t.Errorf("Weight %d mismatch. Expected %v, Found %v", i, truthWeights[i], weights[i])
+		}
+		sum += weights[i]
+	}
+	if math.Abs(sum-1) > 1e-14 {
+		t.Errorf("Weights do not sum to 1. Found %v", sum)
+	}
+
+	// Running one more iteration from the same start never decreases the
+	// log-likelihood.
+	prev := math.Inf(-1)
+	for iter := 0; iter < 20; iter++ {
+		components := start()
+		weights := FitEM(x, components, iter, 0)
+		var ll float64
+		for _, v := range x {
+			var p float64
+			for j, d := range components {
+				p += weights[j] * math.Exp(d.LogProb(v))
+			}
+			ll += math.Log(p)
+		}
+		if ll < prev-1e-9*math.Abs(prev) {
+			t.Errorf("Log-likelihood decreased at iteration %d from %v to %v", iter, prev, ll)
+		}
+		prev = ll
+	}
+
+	if !panics(func() { FitEM(nil, start(), 10, 0) }) {
+		t.Errorf("FitEM did not panic with no samples")
+	}
+	if !panics(func() { FitEM(x, nil, 10, 0) }) {
+		t.Errorf("FitEM did not panic with no components")
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"fmt"
+	"math"
+)
+
+// Erlang represents the Erlang distribution, the distribution of the sum of K
+// independent exponential variables with rate λ
+// (https://en.wikipedia.org/wiki/Erlang_distribution). It is the gamma
+// distribution with integer shape K and rate λ, and is the waiting time for
+// the K-th event of a Poisson process. For integer shapes its CDF is a finite
+// Poisson sum, which is cheaper than the incomplete gamma function.
+// Valid range for x is [0,+∞).
+type Erlang struct {
+	K      int     // Shape parameter, the number of exponential stages
+	Lambda float64 // Rate parameter of each stage
+	Source Source
+}
+
+// NewErlang returns an Erlang distribution with shape k and rate lambda that
+// samples from src. NewErlang returns an error if k is not positive or if
+// lambda is not positive and finite.
+func NewErlang(k int, lambda float64, src Source) (Erlang, error) {
+	e := Erlang{K: k, Lambda: lambda, Source: src}
+	if err := e.Validate(); err != nil {
+		return Erlang{}, err
+	}
+	return e, nil
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (e Erlang) CDF(x float64) float64 {
+	cdf, _ := e.tails(x)
+	return cdf
+}
+
+// Cumulants returns the first n cumulants κ_1, …, κ_n of the distribution,
+//  κ_j = K (j-1)! / λ^j.
+func (e Erlang) Cumulants(n int) []float64 {
+	if n < 0 {
+		panic("dist: negative cumulant count")
+	}
+	k := make([]float64, n)
+	c := float64(e.K) / e.Lambda
+	for j := range k {
+		k[j] = c
+		c *= float64(j+1) / e.Lambda
+	}
+	return k
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution.
+func (e Erlang) ExKurtosis() float64 {
+	return 6 / float64(e.K)
+}
+
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with n intervals.
+func (e Erlang) IntegrateProb(lo, hi float64, n int) float64 {
+	return integrateProb(e, lo, hi, n)
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (e Erlang) LogProb(x float64) float64 {
+	x = snapNonNegative(x)
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 || math.IsInf(x, 1) {
+		return math.Inf(-1)
+	}
+	k := float64(e.K)
+	if x == 0 {
+		if e.K == 1 {
+			return math.Log(e.Lambda)
+		}
+		return math.Inf(-1)
+	}
+	return k*math.Log(e.Lambda) + (k-1)*math.Log(x) - e.Lambda*x - logFactorial(e.K-1)
+}
+
+// MarshalParameters implements the ParameterMarshaler interface
+func (e Erlang) MarshalParameters(p []Parameter) {
+	if len(p) != e.NumParameters() {
+		panic("erlang: improper parameter length")
+	}
+	p[0].Name = "K"
+	p[0].Value = float64(e.K)
+	p[1].Name = "λ"
+	p[1].Value = e.Lambda
+}
+
+// Mean returns the mean of the probability distribution.
+func (e Erlang) Mean() float64 {
+	return float64(e.K) / e.Lambda
+}
+
+// Mode returns the mode of the probability distribution.
+func (e Erlang) Mode() float64 {
+	return float64(e.K-1) / e.Lambda
+}
+
+// Moment returns the k-th raw moment E[X^k] of the distribution, or the k-th
+// central moment E[(X-μ)^k] if central is true. The moments are those of
+// the gamma distribution with shape K and rate Lambda. Moment panics if k is
+// negative.
+func (e Erlang) Moment(k int, central bool) float64 {
+	return Gamma{Alpha: float64(e.K), Beta: e.Lambda}.Moment(k, central)
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (e Erlang) NormalizationCheck() float64 {
+	return normalization(e)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Erlang) NumParameters() int {
+	return 2
+}
+
+// Prob computes the value of the probability density function at x.
+func (e Erlang) Prob(x float64) float64 {
+	return math.Exp(e.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+// Quantile(0) is 0 and Quantile(1) is +Inf, the bounds of the support.
+func (e Erlang) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	return gammaIncRegInv(float64(e.K), p) / e.Lambda
+}
+
+// Rand returns a random sample drawn from the distribution, computed as the
+// sum of K exponential samples.
+func (e Erlang) Rand() float64 {
+	var x float64
+	for i := 0; i < e.K; i++ {
+		x += randExpFloat64(e.Source)
+	}
+	return x / e.Lambda
+}
+
+// Skewness returns the skewness of the distribution.
+func (e Erlang) Skewness() float64 {
+	return 2 / math.Sqrt(float64(e.K))
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (e Erlang) StdDev() float64 {
+	return math.Sqrt(float64(e.K)) / e.Lambda
+}
+
+// String implements the fmt.Stringer interface.
+func (e Erlang) String() string {
+	return formatParams(&e)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (e Erlang) Survival(x float64) float64 {
+	_, sf := e.tails(x)
+	return sf
+}
+
+// tails returns the CDF and survival function at x. The survival function is
+// the probability of fewer than K events of a Poisson process with rate λ in
+// time x,
+//  Survival(x) = Σ_{n=0}^{K-1} e^{-λx} (λx)^n / n!,
+// and the CDF is the rest of the Poisson sum. The smaller of the two is
+// summed directly and the other is its complement, so both keep their
+// relative precision in the tails. The terms are computed in logarithmic
+// space so that they do not underflow for large λx.
+func (e Erlang) tails(x float64) (cdf, sf float64) {
+	x = snapNonNegative(x)
+	switch {
+	case math.IsNaN(x):
+		return math.NaN(), math.NaN()
+	case x <= 0:
+		return 0, 1
+	case math.IsInf(x, 1):
+		return 1, 0
+	}
+	mu := e.Lambda * x
+	logMu := math.Log(mu)
+	logTerm := func(n int) float64 {
+		return -mu + float64(n)*logMu - logFactorial(n)
+	}
+	if mu < float64(e.K) {
+		// The terms from K upwards decrease by at least a factor
+		// λx/(K+1) each, so the series converges geometrically.
+		first := logTerm(e.K)
+		var sum float64
+		term := 1.0
+		for n := e.K; term > 1e-17*sum; n++ {
+			sum += term
+			term *= mu / float64(n+1)
+		}
+		cdf = math.Min(1, math.Exp(first)*sum)
+		return cdf, 1 - cdf
+	}
+	// The terms increase up to n = K-1 ≤ λx, so the last is the largest.
+	max := logTerm(e.K - 1)
+	var sum float64
+	for n := 0; n < e.K; n++ {
+		sum += math.Exp(logTerm(n) - max)
+	}
+	sf = math.Min(1, math.Exp(max)*sum)
+	return 1 - sf, sf
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface
+func (e *Erlang) UnmarshalParameters(p []Parameter) {
+	if len(p) != e.NumParameters() {
+		panic("erlang: incorrect number of parameters to set")
+	}
+	if p[0].Name != "K" {
+		panic("erlang: " + panicNameMismatch)
+	}
+	if !isLambda(p[1].Name) {
+		panic("erlang: " + panicNameMismatch)
+	}
+	if k := p[0].Value; math.Floor(k) != k || math.Abs(k) > math.MaxInt32 {
+		panic("erlang: non-integer shape")
+	}
+	e.K = int(p[0].Value)
+	e.Lambda = p[1].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if K is a positive integer and
+// λ is positive and finite.
+func (e Erlang) Validate() error {
+	if e.K < 1 {
+		return fmt.Errorf("erlang: K must be a positive integer, found %v", e.K)
+	}
+	return checkPositive("erlang", "λ", e.Lambda)
+}
+
+// Variance returns the variance of the probability distribution.
+func (e Erlang) Variance() float64 {
+	return float64(e.K) / (e.Lambda * e.Lambda)
+}
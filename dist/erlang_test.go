@@ -0,0 +1,105 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestErlangGamma(t *testing.T) {
+	for _, e := range []Erlang{
+		{K: 1, Lambda: 2},
+		{K: 3, Lambda: 0.5},
+		{K: 10, Lambda: 1},
+		{K: 200, Lambda: 4},
+	} {
+		g := Gamma{Alpha: float64(e.K), Beta: e.Lambda}
+		for _, p := range []float64{1e-10, 0.001, 0.1, 0.5, 0.9, 0.999, 1 - 1e-10} {
+			x := g.Quantile(p)
+			if got, want := e.CDF(x), g.CDF(x); !equalRel(got, want, 1e-10) {
+				t.Errorf("CDF mismatch for %v at %v. Expected %v, Found %v", e, x, want, got)
+			}
+			if got, want := e.Survival(x), g.Survival(x); !equalRel(got, want, 1e-10) {
+				t.Errorf("Survival mismatch for %v at %v. Expected %v, Found %v", e, x, want, got)
+			}
+			if got, want := e.LogProb(x), g.LogProb(x); !equalRel(got, want, 1e-12) {
+				t.Errorf("LogProb mismatch for %v at %v. Expected %v, Found %v", e, x, want, got)
+			}
+			if got := e.Quantile(p); !equalRel(got, x, 1e-12) {
+				t.Errorf("Quantile mismatch for %v at %v. Expected %v, Found %v", e, p, x, got)
+			}
+		}
+		for _, m := range []struct {
+			name      string
+			got, want float64
+		}{
+			{"Mean", e.Mean(), g.Mean()},
+			{"Mode", e.Mode(), g.Mode()},
+			{"StdDev", e.StdDev(), g.StdDev()},
+			{"Variance", e.Variance(), g.Variance()},
+			{"Skewness", e.Skewness(), g.Skewness()},
+			{"ExKurtosis", e.ExKurtosis(), g.ExKurtosis()},
+		} {
+			if !equalRel(m.got, m.want, 1e-14) {
+				t.Errorf("%s mismatch for %v. Expected %v, Found %v", m.name, e, m.want, m.got)
+			}
+		}
+	}
+	if got := (Erlang{K: 1, Lambda: 2}).Prob(0); got != 2 {
+		t.Errorf("Prob(0) mismatch for K = 1. Expected 2, Found %v", got)
+	}
+	// Far in the upper tail the terms of the Poisson sum underflow
+	// individually.
+	e := Erlang{K: 5, Lambda: 1}
+	if got, want := e.Survival(800), (Gamma{Alpha: 5, Beta: 1}).Survival(800); !equalRel(got, want, 1e-10) {
+		t.Errorf("Survival mismatch for %v at 800. Expected %v, Found %v", e, want, got)
+	}
+}
+
+func TestErlangRand(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	e := Erlang{K: 4, Lambda: 2, Source: src}
+	exp := Exponential{Rate: 2, Source: rand.New(rand.NewSource(2))}
+	const n = 100000
+	x := make([]float64, n)
+	sums := make([]float64, n)
+	for i := range x {
+		x[i] = e.Rand()
+		for j := 0; j < e.K; j++ {
+			sums[i] += exp.Rand()
+		}
+	}
+	checkMeanVariance(t, x, e.Mean(), e.Variance(), "Erlang")
+	checkMeanVariance(t, sums, e.Mean(), e.Variance(), "Sum of exponentials")
+	// The empirical CDFs of the two constructions agree with the Erlang CDF.
+	for _, q := range []float64{0.5, 1, 2, 4} {
+		var below, belowSum float64
+		for i := range x {
+			if x[i] <= q {
+				below++
+			}
+			if sums[i] <= q {
+				belowSum++
+			}
+		}
+		want := e.CDF(q)
+		tol := 5 * math.Sqrt(want*(1-want)/n)
+		if got := below / n; math.Abs(got-want) > tol {
+			t.Errorf("Empirical CDF mismatch at %v. Expected %v, Found %v", q, want, got)
+		}
+		if got := belowSum / n; math.Abs(got-want) > tol {
+			t.Errorf("Sum of exponentials CDF mismatch at %v. Expected %v, Found %v", q, want, got)
+		}
+	}
+}
+
+func TestErlangUnmarshalNonInteger(t *testing.T) {
+	var e Erlang
+	if !panics(func() { e.UnmarshalParameters([]Parameter{{"K", 2.5}, {"λ", 1}}) }) {
+		t.Errorf("expected panic for non-integer shape")
+	}
+}
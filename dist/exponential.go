@@ -6,7 +6,6 @@ package dist
 
 import (
 	"math"
-	"math/rand"
 
 	"github.com/gonum/floats"
 	"github.com/gonum/stat"
@@ -15,17 +14,66 @@ import (
 // Exponential represents the exponential distribution (https://en.wikipedia.org/wiki/Exponential_distribution).
 type Exponential struct {
 	Rate   float64
-	Source *rand.Rand
+	Source Source
+}
+
+// NewExponential returns an exponential distribution with the given rate
+// that samples from src. NewExponential returns an error if rate is not
+// positive and finite.
+func NewExponential(rate float64, src Source) (Exponential, error) {
+	e := Exponential{Rate: rate, Source: src}
+	if err := e.Validate(); err != nil {
+		return Exponential{}, err
+	}
+	return e, nil
 }
 
 // CDF computes the value of the cumulative density function at x.
 func (e Exponential) CDF(x float64) float64 {
+	x = snapNonNegative(x)
 	if x < 0 {
 		return 0
 	}
 	return 1 - math.Exp(-e.Rate*x)
 }
 
+// CDFSlice returns the value of the cumulative density function at each of
+// the values in xs. See CDFSliceTo.
+func (e Exponential) CDFSlice(xs []float64) []float64 {
+	return e.CDFSliceTo(make([]float64, len(xs)), xs)
+}
+
+// CDFSliceTo stores in dst the value of the cumulative density function at
+// each of the values in xs, and returns dst.
+// CDFSliceTo panics if len(dst) != len(xs).
+func (e Exponential) CDFSliceTo(dst, xs []float64) []float64 {
+	if len(dst) != len(xs) {
+		panic("dist: slice length mismatch")
+	}
+	for i, x := range xs {
+		x = snapNonNegative(x)
+		if x < 0 {
+			dst[i] = 0
+			continue
+		}
+		dst[i] = 1 - math.Exp(-e.Rate*x)
+	}
+	return dst
+}
+
+// CGF returns the cumulant generating function K(s) = -log(1 - s/rate) and
+// its first and second derivatives with respect to s.
+func (e Exponential) CGF(s float64) (k, dk, d2k float64) {
+	r := e.Rate - s
+	return -math.Log1p(-s / e.Rate), 1 / r, 1 / (r * r)
+}
+
+// CGFDomain returns the open interval (-∞, rate) on which the cumulant
+// generating function is finite.
+func (e Exponential) CGFDomain() (lo, hi float64) {
+	return math.Inf(-1), e.Rate
+}
+
 // ConjugateUpdate updates the parameters of the distribution from the sufficient
 // statistics of a set of samples. The sufficient statistics, suffStat, have been
 // observed with nSamples observations. The prior values of the distribution are those
@@ -56,6 +104,21 @@ func (e *Exponential) ConjugateUpdate(suffStat []float64, nSamples float64, prio
 	priorStrength[0] = totalSamples
 }
 
+// Cumulants returns the first n cumulants κ_1, …, κ_n of the distribution,
+//  κ_j = (j-1)! / rate^j.
+func (e Exponential) Cumulants(n int) []float64 {
+	if n < 0 {
+		panic("dist: negative cumulant count")
+	}
+	k := make([]float64, n)
+	c := 1 / e.Rate
+	for j := range k {
+		k[j] = c
+		c *= float64(j+1) / e.Rate
+	}
+	return k
+}
+
 // DLogProbDX returns the derivative of the log of the probability with
 // respect to the input x.
 //
@@ -115,14 +178,41 @@ func (e *Exponential) Fit(samples, weights []float64) {
 	e.ConjugateUpdate(suffStat, nSamples, []float64{0})
 }
 
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with n intervals.
+func (e Exponential) IntegrateProb(lo, hi float64, n int) float64 {
+	return integrateProb(e, lo, hi, n)
+}
+
+// InverseSurvival returns the inverse of the survival function.
+func (e Exponential) InverseSurvival(q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	if q == 1 {
+		return 0
+	}
+	return -math.Log(q) / e.Rate
+}
+
 // LogProb computes the natural logarithm of the value of the probability density function at x.
 func (e Exponential) LogProb(x float64) float64 {
+	x = snapNonNegative(x)
 	if x < 0 {
 		return math.Inf(-1)
 	}
 	return math.Log(e.Rate) - e.Rate*x
 }
 
+// MGF returns the moment generating function E[exp(sX)] = rate/(rate - s),
+// which is +Inf for s ≥ rate.
+func (e Exponential) MGF(s float64) float64 {
+	if s >= e.Rate {
+		return math.Inf(1)
+	}
+	return e.Rate / (e.Rate - s)
+}
+
 // MarshalParameters implements the ParameterMarshaler interface
 func (e Exponential) MarshalParameters(p []Parameter) {
 	nParam := e.NumParameters()
@@ -149,6 +239,31 @@ func (Exponential) Mode() float64 {
 	return 0
 }
 
+// Moment returns the k-th raw moment E[X^k] of the distribution, or the k-th
+// central moment E[(X-μ)^k] if central is true. The raw moments are
+// k!/Rate^k, and the central moments are computed from them. Moment panics
+// if k is negative.
+func (e Exponential) Moment(k int, central bool) float64 {
+	checkMomentOrder(k)
+	raw := func(j int) float64 {
+		m := 1.0
+		for i := 1; i <= j; i++ {
+			m *= float64(i) / e.Rate
+		}
+		return m
+	}
+	if central {
+		return centralFromRaw(k, raw)
+	}
+	return raw(k)
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (e Exponential) NormalizationCheck() float64 {
+	return normalization(e)
+}
+
 // NumParameters returns the number of parameters in the distribution.
 func (Exponential) NumParameters() int {
 	return 1
@@ -163,25 +278,62 @@ func (e Exponential) Prob(x float64) float64 {
 	return math.Exp(e.LogProb(x))
 }
 
+// ProbSlice returns the value of the probability density function at each
+// of the values in xs. See ProbSliceTo.
+func (e Exponential) ProbSlice(xs []float64) []float64 {
+	return e.ProbSliceTo(make([]float64, len(xs)), xs)
+}
+
+// ProbSliceTo stores in dst the value of the probability density function at
+// each of the values in xs, and returns dst. The logarithm of the rate
+// is computed once for the whole slice.
+// ProbSliceTo panics if len(dst) != len(xs).
+func (e Exponential) ProbSliceTo(dst, xs []float64) []float64 {
+	if len(dst) != len(xs) {
+		panic("dist: slice length mismatch")
+	}
+	logRate := math.Log(e.Rate)
+	for i, x := range xs {
+		x = snapNonNegative(x)
+		if x < 0 {
+			dst[i] = 0
+			continue
+		}
+		dst[i] = math.Exp(logRate - e.Rate*x)
+	}
+	return dst
+}
+
 // Quantile returns the inverse of the cumulative probability distribution.
+// Quantile(0) is 0 and Quantile(1) is +Inf, the bounds of the support.
 func (e Exponential) Quantile(p float64) float64 {
-	if p < 0 || p > 1 {
+	if !(p >= 0 && p <= 1) {
 		panic("dist: percentile out of bounds")
 	}
+	if p == 0 {
+		return 0
+	}
 	return -math.Log(1-p) / e.Rate
 }
 
 // Rand returns a random sample drawn from the distribution.
 func (e Exponential) Rand() float64 {
-	var rnd float64
-	if e.Source == nil {
-		rnd = rand.ExpFloat64()
-	} else {
-		rnd = e.Source.ExpFloat64()
-	}
+	rnd := randExpFloat64(e.Source)
 	return rnd / e.Rate
 }
 
+// RandAntithetic returns n samples drawn from the distribution in antithetic
+// pairs, as described for randAntithetic.
+func (e Exponential) RandAntithetic(n int) []float64 {
+	return randAntithetic(e, n, e.Source)
+}
+
+// SaddlepointProb returns the saddlepoint approximation to the density at x.
+// See CGFer for details.
+func (e Exponential) SaddlepointProb(x float64) float64 {
+	return saddlepointProb(e, x)
+}
+
 // Skewness returns the skewness of the distribution.
 func (Exponential) Skewness() float64 {
 	return 2
@@ -192,6 +344,21 @@ func (e Exponential) StdDev() float64 {
 	return 1 / e.Rate
 }
 
+// StratifiedSample returns n stratified samples from the distribution, as
+// described for stratifiedSample, drawing uniforms from src or, if src is nil,
+// from the Source of the distribution.
+func (e Exponential) StratifiedSample(n int, src Source) []float64 {
+	if src == nil {
+		src = e.Source
+	}
+	return stratifiedSample(e, n, src)
+}
+
+// String implements the fmt.Stringer interface.
+func (e Exponential) String() string {
+	return formatParams(&e)
+}
+
 // SuffStat computes the sufficient statistics of set of samples to update
 // the distribution. The sufficient statistics are stored in place, and the
 // effective number of samples are returned.
@@ -223,6 +390,7 @@ func (Exponential) SuffStat(samples, weights, suffStat []float64) (nSamples floa
 
 // Survival returns the survival function (complementary CDF) at x.
 func (e Exponential) Survival(x float64) float64 {
+	x = snapNonNegative(x)
 	if x < 0 {
 		return 1
 	}
@@ -240,6 +408,12 @@ func (e *Exponential) UnmarshalParameters(p []Parameter) {
 	e.Rate = p[0].Value
 }
 
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Rate is positive and finite.
+func (e Exponential) Validate() error {
+	return checkPositive("exponential", "Rate", e.Rate)
+}
+
 // Variance returns the variance of the probability distribution.
 func (e Exponential) Variance() float64 {
 	return 1 / (e.Rate * e.Rate)
@@ -0,0 +1,42 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// logFactorialTableSize is the number of values of log(n!) that are stored
+// in logFactorialTable.
+const logFactorialTableSize = 256
+
+// logFactorialTable holds log(n!) for n < logFactorialTableSize.
+var logFactorialTable [logFactorialTableSize]float64
+
+func init() {
+	for i := 2; i < logFactorialTableSize; i++ {
+		logFactorialTable[i] = logFactorialTable[i-1] + math.Log(float64(i))
+	}
+}
+
+// logFactorial returns the natural logarithm of n!. Small values are looked up
+// in a table, and larger values are computed from the log-gamma function so
+// that n! itself is never formed.
+//
+// logFactorial panics if n < 0.
+func logFactorial(n int) float64 {
+	if n < 0 {
+		panic("dist: negative factorial")
+	}
+	if n < logFactorialTableSize {
+		return logFactorialTable[n]
+	}
+	lg, _ := math.Lgamma(float64(n) + 1)
+	return lg
+}
+
+// logChoose returns the natural logarithm of the binomial coefficient
+// n choose k.
+func logChoose(n, k int) float64 {
+	return logFactorial(n) - logFactorial(k) - logFactorial(n-k)
+}
@@ -0,0 +1,23 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLogFactorial(t *testing.T) {
+	var want float64
+	for n := 0; n < 2*logFactorialTableSize; n++ {
+		if n > 1 {
+			want += math.Log(float64(n))
+		}
+		got := logFactorial(n)
+		if math.Abs(got-want) > 1e-12*math.Max(1, want) {
+			t.Errorf("logFactorial mismatch for n = %d. Expected %v, Found %v", n, want, got)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// FoldedNormal represents the folded normal distribution, the distribution
+// of |X| where X is normally distributed
+// (https://en.wikipedia.org/wiki/Folded_normal_distribution).
+// Valid range for x is [0,+∞). When Mu is zero the folded normal is the
+// half-normal distribution.
+type FoldedNormal struct {
+	Mu     float64 // Mean of the underlying normal distribution
+	Sigma  float64 // Standard deviation of the underlying normal distribution
+	Source Source
+}
+
+// NewFoldedNormal returns the folded normal distribution of a normal
+// distribution with mean mu and standard deviation sigma that samples from
+// src. NewFoldedNormal returns an error if mu is not finite or if sigma is not
+// positive and finite.
+func NewFoldedNormal(mu, sigma float64, src Source) (FoldedNormal, error) {
+	f := FoldedNormal{Mu: mu, Sigma: sigma, Source: src}
+	if err := f.Validate(); err != nil {
+		return FoldedNormal{}, err
+	}
+	return f, nil
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (f FoldedNormal) CDF(x float64) float64 {
+	x = snapNonNegative(x)
+	if x < 0 {
+		return 0
+	}
+	s := f.Sigma * math.Sqrt2
+	return 0.5 * (math.Erf((x+f.Mu)/s) + math.Erf((x-f.Mu)/s))
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (f FoldedNormal) LogProb(x float64) float64 {
+	x = snapNonNegative(x)
+	if x < 0 || math.IsInf(x, 1) {
+		return math.Inf(-1)
+	}
+	// The density is the sum of the normal densities at x and -x. Factor out
+	// the larger of the two to avoid underflow.
+	z := (x - math.Abs(f.Mu)) / f.Sigma
+	return negLogRoot2Pi - math.Log(f.Sigma) - 0.5*z*z + math.Log1p(math.Exp(-2*x*math.Abs(f.Mu)/(f.Sigma*f.Sigma)))
+}
+
+// MarshalParameters implements the ParameterMarshaler interface
+func (f FoldedNormal) MarshalParameters(p []Parameter) {
+	if len(p) != f.NumParameters() {
+		panic("foldednormal: improper parameter length")
+	}
+	p[0].Name = "Mu"
+	p[0].Value = f.Mu
+	p[1].Name = "Sigma"
+	p[1].Value = f.Sigma
+	return
+}
+
+// Mean returns the mean of the probability distribution.
+func (f FoldedNormal) Mean() float64 {
+	r := f.Mu / f.Sigma
+	return f.Sigma*math.Sqrt(2/math.Pi)*math.Exp(-0.5*r*r) + f.Mu*math.Erf(r/math.Sqrt2)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (FoldedNormal) NumParameters() int {
+	return 2
+}
+
+// Prob computes the value of the probability density function at x.
+func (f FoldedNormal) Prob(x float64) float64 {
+	return math.Exp(f.LogProb(x))
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (f FoldedNormal) Rand() float64 {
+	return math.Abs(f.Mu + f.Sigma*randNormFloat64(f.Source))
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (f FoldedNormal) StdDev() float64 {
+	return math.Sqrt(f.Variance())
+}
+
+// String implements the fmt.Stringer interface.
+func (f FoldedNormal) String() string {
+	return formatParams(&f)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (f FoldedNormal) Survival(x float64) float64 {
+	x = snapNonNegative(x)
+	if x < 0 {
+		return 1
+	}
+	s := f.Sigma * math.Sqrt2
+	return 0.5 * (math.Erfc((x+f.Mu)/s) + math.Erfc((x-f.Mu)/s))
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface
+func (f *FoldedNormal) UnmarshalParameters(p []Parameter) {
+	if len(p) != f.NumParameters() {
+		panic("foldednormal: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Mu" {
+		panic("foldednormal: " + panicNameMismatch)
+	}
+	if p[1].Name != "Sigma" {
+		panic("foldednormal: " + panicNameMismatch)
+	}
+	f.Mu = p[0].Value
+	f.Sigma = p[1].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Mu is finite and Sigma is
+// positive and finite.
+func (f FoldedNormal) Validate() error {
+	return firstError(
+		checkFinite("foldednormal", "Mu", f.Mu),
+		checkPositive("foldednormal", "Sigma", f.Sigma),
+	)
+}
+
+// Variance returns the variance of the probability distribution.
+func (f FoldedNormal) Variance() float64 {
+	m := f.Mean()
+	return f.Mu*f.Mu + f.Sigma*f.Sigma - m*m
+}
@@ -0,0 +1,72 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFoldedNormalHalfNormal(t *testing.T) {
+	for _, sigma := range []float64{0.5, 1, 3} {
+		f := FoldedNormal{Mu: 0, Sigma: sigma}
+		h := HalfNormal{Sigma: sigma}
+		for _, x := range []float64{0, 0.1, 0.5, 1, 2, 5, 10} {
+			if !absEq(f.Prob(x), h.Prob(x)) {
+				t.Errorf("Prob mismatch at %v for σ = %v. Expected %v, Found %v", x, sigma, h.Prob(x), f.Prob(x))
+			}
+			if !absEq(f.LogProb(x), h.LogProb(x)) {
+				t.Errorf("LogProb mismatch at %v for σ = %v. Expected %v, Found %v", x, sigma, h.LogProb(x), f.LogProb(x))
+			}
+			if !absEq(f.CDF(x), h.CDF(x)) {
+				t.Errorf("CDF mismatch at %v for σ = %v. Expected %v, Found %v", x, sigma, h.CDF(x), f.CDF(x))
+			}
+			if !absEq(f.Survival(x), h.Survival(x)) {
+				t.Errorf("Survival mismatch at %v for σ = %v. Expected %v, Found %v", x, sigma, h.Survival(x), f.Survival(x))
+			}
+		}
+		if math.Abs(f.Mean()-h.Mean()) > 1e-14 {
+			t.Errorf("Mean mismatch for σ = %v. Expected %v, Found %v", sigma, h.Mean(), f.Mean())
+		}
+		if math.Abs(f.Variance()-h.Variance()) > 1e-13 {
+			t.Errorf("Variance mismatch for σ = %v. Expected %v, Found %v", sigma, h.Variance(), f.Variance())
+		}
+	}
+}
+
+func TestFoldedNormalMoments(t *testing.T) {
+	for _, f := range []FoldedNormal{
+		{Mu: 1, Sigma: 1},
+		{Mu: -2, Sigma: 0.5},
+		{Mu: 3, Sigma: 2},
+		{Mu: 40, Sigma: 1},
+	} {
+		hi := math.Abs(f.Mu) + 12*f.Sigma
+		if total := integrate(f.Prob, 0, hi, 20000); math.Abs(total-1) > 1e-10 {
+			t.Errorf("Density of %#v does not integrate to 1. Found %v", f, total)
+		}
+		mean := integrate(func(x float64) float64 { return x * f.Prob(x) }, 0, hi, 20000)
+		variance := integrate(func(x float64) float64 { return (x - mean) * (x - mean) * f.Prob(x) }, 0, hi, 20000)
+		if math.Abs(f.Mean()-mean) > 1e-8 {
+			t.Errorf("Mean mismatch for %#v. Expected %v, Found %v", f, mean, f.Mean())
+		}
+		if math.Abs(f.Variance()-variance) > 1e-8 {
+			t.Errorf("Variance mismatch for %#v. Expected %v, Found %v", f, variance, f.Variance())
+		}
+		for _, x := range []float64{0.5, 1, 2.5} {
+			if cdf := integrate(f.Prob, 0, x, 2000); math.Abs(cdf-f.CDF(x)) > 1e-10 {
+				t.Errorf("CDF mismatch at %v for %#v. Expected %v, Found %v", x, f, cdf, f.CDF(x))
+			}
+		}
+
+		f.Source = rand.New(rand.NewSource(1))
+		x := make([]float64, 100000)
+		for i := range x {
+			x[i] = f.Rand()
+		}
+		checkMeanVariance(t, x, f.Mean(), f.Variance(), "FoldedNormal")
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// Gamma represents the gamma distribution with shape Alpha and rate Beta
+// (https://en.wikipedia.org/wiki/Gamma_distribution).
+// Valid range for x is [0,+∞).
+type Gamma struct {
+	Alpha  float64 // Shape parameter
+	Beta   float64 // Rate parameter, the reciprocal of the scale
+	Source Source
+
+	// Algorithm is the method used by Rand. The zero value is
+	// MarsagliaTsang.
+	Algorithm GammaAlgorithm
+}
+
+// GammaAlgorithm specifies the method used by Gamma.Rand to generate samples.
+// The methods produce different streams from the same Source, so the choice
+// matters when reproducing the samples of another system.
+type GammaAlgorithm int
+
+const (
+	// MarsagliaTsang is the squeeze and rejection method of Marsaglia and
+	// Tsang (2000), with the shape boosted by one and corrected by a power of
+	// a uniform for α < 1. It uses a normal and a uniform per trial, accepts
+	// more than 95% of trials for any shape, and is the default.
+	MarsagliaTsang GammaAlgorithm = iota
+	// AhrensDieter sums exponentials for the integer part of the shape and
+	// uses the rejection algorithm GS of Ahrens and Dieter (1974) for the
+	// fractional part. It needs only uniforms and exponentials, but its cost
+	// grows linearly with α.
+	AhrensDieter
+)
+
+// NewGamma returns a gamma distribution with shape alpha and rate beta that
+// samples from src. NewGamma returns an error if alpha or beta is not
+// positive and finite.
+func NewGamma(alpha, beta float64, src Source) (Gamma, error) {
+	g := Gamma{Alpha: alpha, Beta: beta, Source: src}
+	if err := g.Validate(); err != nil {
+		return Gamma{}, err
+	}
+	return g, nil
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (g Gamma) CDF(x float64) float64 {
+	x = snapNonNegative(x)
+	if x <= 0 {
+		return 0
+	}
+	return gammaIncReg(g.Alpha, g.Beta*x)
+}
+
+// CGF returns the cumulant generating function K(s) = -α log(1 - s/β) and
+// its first and second derivatives with respect to s.
+func (g Gamma) CGF(s float64) (k, dk, d2k float64) {
+	r := g.Beta - s
+	return -g.Alpha * math.Log1p(-s/g.Beta), g.Alpha / r, g.Alpha / (r * r)
+}
+
+// CGFDomain returns the open interval (-∞, β) on which the cumulant
+// generating function is finite.
+func (g Gamma) CGFDomain() (lo, hi float64) {
+	return math.Inf(-1), g.Beta
+}
+
+// Cumulants returns the first n cumulants κ_1, …, κ_n of the distribution,
+//  κ_j = α (j-1)! / β^j.
+func (g Gamma) Cumulants(n int) []float64 {
+	if n < 0 {
+		panic("dist: negative cumulant count")
+	}
+	k := make([]float64, n)
+	c := g.Alpha / g.Beta
+	for j := range k {
+		k[j] = c
+		c *= float64(j+1) / g.Beta
+	}
+	return k
+}
+
+// DLogProbDParam returns the derivative of the log of the probability with
+// respect to the parameters of the distribution. The deriv slice must have length
+// equal to the number of parameters of the distribution.
+//
+// The order is ∂LogProb / ∂α and then ∂LogProb / ∂β.
+//
+// Special cases are:
+//  The derivative at 0 is NaN.
+func (g Gamma) DLogProbDParam(x float64, deriv []float64) {
+	if len(deriv) != g.NumParameters() {
+		panic("gamma: slice length mismatch")
+	}
+	if x > 0 {
+		deriv[0] = math.Log(g.Beta*x) - digamma(g.Alpha)
+		deriv[1] = g.Alpha/g.Beta - x
+		return
+	}
+	if x < 0 {
+		deriv[0] = 0
+		deriv[1] = 0
+		return
+	}
+	deriv[0] = math.NaN()
+	deriv[1] = math.NaN()
+}
+
+// DLogProbDX returns the derivative of the log of the probability with
+// respect to the input x.
+//
+// Special cases are:
+//  DLogProbDX(0) = NaN
+func (g Gamma) DLogProbDX(x float64) float64 {
+	if x > 0 {
+		return (g.Alpha-1)/x - g.Beta
+	}
+	if x < 0 {
+		return 0
+	}
+	return math.NaN()
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution.
+func (g Gamma) ExKurtosis() float64 {
+	return 6 / g.Alpha
+}
+
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with n intervals.
+func (g Gamma) IntegrateProb(lo, hi float64, n int) float64 {
+	return integrateProb(g, lo, hi, n)
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (g Gamma) LogProb(x float64) float64 {
+	x = snapNonNegative(x)
+	if x < 0 {
+		return math.Inf(-1)
+	}
+	if math.IsInf(x, 1) {
+		return math.Inf(-1)
+	}
+	if x == 0 {
+		switch {
+		case g.Alpha < 1:
+			return math.Inf(1)
+		case g.Alpha == 1:
+			return math.Log(g.Beta)
+		}
+		return math.Inf(-1)
+	}
+	lg, _ := math.Lgamma(g.Alpha)
+	return g.Alpha*math.Log(g.Beta) - lg + (g.Alpha-1)*math.Log(x) - g.Beta*x
+}
+
+// MGF returns the moment generating function E[exp(sX)] = (1 - s/β)^-α,
+// which is +Inf for s ≥ β.
+func (g Gamma) MGF(s float64) float64 {
+	if s >= g.Beta {
+		return math.Inf(1)
+	}
+	return math.Exp(-g.Alpha * math.Log1p(-s/g.Beta))
+}
+
+// MarshalParameters implements the ParameterMarshaler interface
+func (g Gamma) MarshalParameters(p []Parameter) {
+	if len(p) != g.NumParameters() {
+		panic("gamma: improper parameter length")
+	}
+	p[0].Name = "Alpha"
+	p[0].Value = g.Alpha
+	p[1].Name = "Beta"
+	p[1].Value = g.Beta
+	return
+}
+
+// Mean returns the mean of the probability distribution.
+func (g Gamma) Mean() float64 {
+	return g.Alpha / g.Beta
+}
+
+// Mode returns the mode of the probability distribution.
+func (g Gamma) Mode() float64 {
+	if g.Alpha < 1 {
+		return 0
+	}
+	return (g.Alpha - 1) / g.Beta
+}
+
+// Moment returns the k-th raw moment E[X^k] of the distribution, or the k-th
+// central moment E[(X-μ)^k] if central is true. The raw moments are
+// α(α+1)…(α+k-1)/β^k, and the central moments are computed from them. Moment
+// panics if k is negative.
+func (g Gamma) Moment(k int, central bool) float64 {
+	checkMomentOrder(k)
+	raw := func(j int) float64 {
+		m := 1.0
+		for i := 0; i < j; i++ {
+			m *= (g.Alpha + float64(i)) / g.Beta
+		}
+		return m
+	}
+	if central {
+		return centralFromRaw(k, raw)
+	}
+	return raw(k)
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (g Gamma) NormalizationCheck() float64 {
+	return normalization(g)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Gamma) NumParameters() int {
+	return 2
+}
+
+// Prob computes the value of the probability density function at x.
+func (g Gamma) Prob(x float64) float64 {
+	return math.Exp(g.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+// Quantile(0) is 0 and Quantile(1) is +Inf, the bounds of the support.
+func (g Gamma) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	return gammaIncRegInv(g.Alpha, p) / g.Beta
+}
+
+// Rand returns a random sample drawn from the distribution using the method
+// given by Algorithm. Rand panics if Algorithm is not a known algorithm.
+func (g Gamma) Rand() float64 {
+	switch g.Algorithm {
+	case MarsagliaTsang:
+		return randGamma(g.Alpha, g.Source) / g.Beta
+	case AhrensDieter:
+		return randGammaAhrensDieter(g.Alpha, g.Source) / g.Beta
+	default:
+		panic("gamma: unknown algorithm")
+	}
+}
+
+// SaddlepointProb returns the saddlepoint approximation to the density at x.
+// See CGFer for details. The ratio of the approximation to the exact density
+// does not depend on x and tends to 1 as α increases.
+func (g Gamma) SaddlepointProb(x float64) float64 {
+	return saddlepointProb(g, x)
+}
+
+// Skewness returns the skewness of the distribution.
+func (g Gamma) Skewness() float64 {
+	return 2 / math.Sqrt(g.Alpha)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (g Gamma) StdDev() float64 {
+	return math.Sqrt(g.Alpha) / g.Beta
+}
+
+// String implements the fmt.Stringer interface.
+func (g Gamma) String() string {
+	return formatParams(&g)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (g Gamma) Survival(x float64) float64 {
+	x = snapNonNegative(x)
+	if x <= 0 {
+		return 1
+	}
+	return gammaIncRegComp(g.Alpha, g.Beta*x)
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface
+func (g *Gamma) UnmarshalParameters(p []Parameter) {
+	if len(p) != g.NumParameters() {
+		panic("gamma: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Alpha" {
+		panic("gamma: " + panicNameMismatch)
+	}
+	if p[1].Name != "Beta" {
+		panic("gamma: " + panicNameMismatch)
+	}
+	g.Alpha = p[0].Value
+	g.Beta = p[1].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Alpha and Beta are positive
+// and finite.
+func (g Gamma) Validate() error {
+	return firstError(
+		checkPositive("gamma", "Alpha", g.Alpha),
+		checkPositive("gamma", "Beta", g.Beta),
+	)
+}
+
+// Variance returns the variance of the probability distribution.
+func (g Gamma) Variance() float64 {
+	return g.Alpha / (g.Beta * g.Beta)
+}
@@ -0,0 +1,81 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/stat"
+)
+
+func TestGammaIncReg(t *testing.T) {
+	// For integer a, Q(a, x) is the Poisson probability of fewer than a
+	// events with mean x, which gives the expected values.
+	for _, test := range []struct {
+		a, x, q float64
+	}{
+		{10, 100, 1.1253473960842733e-31},
+		{15, 10, 0.9165415270653372},
+		{30, 25, 0.8178960840225449},
+		{12, 30, 6.387702539927337e-05},
+	} {
+		if got := gammaIncRegComp(test.a, test.x); !equalRel(got, test.q, 1e-13) {
+			t.Errorf("gammaIncRegComp mismatch for a = %v, x = %v. Expected %v, Found %v", test.a, test.x, test.q, got)
+		}
+		if got, want := gammaIncReg(test.a, test.x), 1-test.q; !equalRel(got, want, 1e-13) {
+			t.Errorf("gammaIncReg mismatch for a = %v, x = %v. Expected %v, Found %v", test.a, test.x, want, got)
+		}
+	}
+}
+
+func TestGamma(t *testing.T) {
+	for _, g := range []Gamma{
+		{Alpha: 0.5, Beta: 1},
+		{Alpha: 1, Beta: 2},
+		{Alpha: 3.5, Beta: 0.5},
+		{Alpha: 40, Beta: 10},
+	} {
+		gg := GeneralizedGamma{A: 1 / g.Beta, D: g.Alpha, P: 1}
+		for _, x := range []float64{0.01, 0.5, 1, 3, 10} {
+			if !equalRel(g.Prob(x), gg.Prob(x), 1e-12) {
+				t.Errorf("Prob mismatch for %#v at %v. Expected %v, Found %v", g, x, gg.Prob(x), g.Prob(x))
+			}
+			if !equalRel(g.CDF(x), gg.CDF(x), 1e-12) {
+				t.Errorf("CDF mismatch for %#v at %v. Expected %v, Found %v", g, x, gg.CDF(x), g.CDF(x))
+			}
+		}
+		for _, p := range []float64{0.001, 0.1, 0.5, 0.9, 0.999} {
+			if c := g.CDF(g.Quantile(p)); math.Abs(c-p) > 1e-8 {
+				t.Errorf("CDF(Quantile) mismatch for %#v at %v. Expected %v, Found %v", g, p, p, c)
+			}
+		}
+		g.Source = rand.New(rand.NewSource(1))
+		x := make([]float64, 100000)
+		for i := range x {
+			x[i] = g.Rand()
+		}
+		checkMeanVariance(t, x, g.Mean(), g.Variance(), "Gamma")
+	}
+}
+
+func TestGammaAlgorithm(t *testing.T) {
+	for _, alg := range []GammaAlgorithm{MarsagliaTsang, AhrensDieter} {
+		for _, alpha := range []float64{0.3, 1, 2.7, 12} {
+			g := Gamma{Alpha: alpha, Beta: 2, Source: rand.New(rand.NewSource(1)), Algorithm: alg}
+			x := make([]float64, 5000)
+			for i := range x {
+				x[i] = g.Rand()
+			}
+			if _, p := stat.UniformityTest(stat.PIT(x, g.CDF)); p < 0.001 {
+				t.Errorf("Samples of %#v fail the Kolmogorov-Smirnov test. Found p = %v", g, p)
+			}
+		}
+	}
+	if !panics(func() { (Gamma{Alpha: 1, Beta: 1, Algorithm: -1}).Rand() }) {
+		t.Errorf("Rand did not panic with an unknown algorithm")
+	}
+}
@@ -1,5 +1,11 @@
 package dist
 
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+)
+
 // Parameter represents a parameter of a probability distribution
 type Parameter struct {
 	Name  string
@@ -18,8 +24,130 @@ type Parameter struct {
 // panic if the length of the slice is not equal to the number of parameters.
 // UnmarshalParameters will panic if the names of the parameters do not match.
 // UnmarshalParameters tests names in the same order as they were created in
-// MarshalParameters.
+// MarshalParameters. A parameter marshaled as "λ" is also accepted under the
+// names "lambda" and "Lambda", since the Unicode name may not survive
+// external encodings.
 type ParameterMarshaler interface {
 	MarshalParameters([]Parameter)
 	UnmarshalParameters([]Parameter)
 }
+
+// isLambda returns whether name is an accepted name for a parameter
+// marshaled as "λ".
+func isLambda(name string) bool {
+	switch name {
+	case "λ", "lambda", "Lambda":
+		return true
+	}
+	return false
+}
+
+// formatParams returns the type name of d followed by its marshaled
+// parameters, for example
+//  Weibull{K: 1.5, λ: 2}
+// d must also have a NumParameters method.
+func formatParams(d interface {
+	ParameterMarshaler
+	NumParameters() int
+}) string {
+	p := make([]Parameter, d.NumParameters())
+	d.MarshalParameters(p)
+	var buf bytes.Buffer
+	buf.WriteString(reflect.Indirect(reflect.ValueOf(d)).Type().Name())
+	buf.WriteByte('{')
+	for i, v := range p {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(v.Name)
+		buf.WriteString(": ")
+		buf.WriteString(strconv.FormatFloat(v.Value, 'g', -1, 64))
+	}
+	buf.WriteByte('}')
+	return buf.String()
+}
+
+// The distributions in this package handle non-finite arguments uniformly.
+// Prob, LogProb, CDF and Survival return NaN at NaN, and at ±Inf they return
+// their limits, so Prob is 0, LogProb is -Inf, CDF is 0 at -Inf and 1 at +Inf,
+// and Survival is 1 at -Inf and 0 at +Inf. Quantile panics if its argument
+// is NaN or outside [0, 1], since a NaN percentile is almost always the
+// result of an earlier error.
+
+// LogProber is a type that can compute the log of the probability density.
+type LogProber interface {
+	LogProb(x float64) float64
+}
+
+// Prober is a type that can compute the probability density.
+type Prober interface {
+	Prob(x float64) float64
+}
+
+// Quantiler is a type that can compute the inverse of the cumulative
+// distribution function. Quantile panics if p is NaN or outside [0, 1].
+type Quantiler interface {
+	Quantile(p float64) float64
+}
+
+// Rander is a type that can generate random samples.
+type Rander interface {
+	Rand() float64
+}
+
+// CDFer is a type that can compute the cumulative distribution function.
+type CDFer interface {
+	CDF(x float64) float64
+}
+
+// Survivaler is a type that can compute the survival function (complementary CDF).
+type Survivaler interface {
+	Survival(x float64) float64
+}
+
+// InverseSurvivaler is a type that can compute the inverse of the survival
+// function, the quantile with a given upper-tail probability. InverseSurvival
+// returns the x at which Survival(x) = q. It equals Quantile(1-q), but is
+// computed without forming 1-q, so small upper-tail probabilities keep their
+// precision.
+type InverseSurvivaler interface {
+	InverseSurvival(q float64) float64
+}
+
+// CGFer is a type with a closed-form cumulant generating function
+//  K(s) = log E[exp(sX)].
+// CGF returns K(s) and its first and second derivatives for s in the open
+// interval returned by CGFDomain, which contains zero.
+//
+// Types implementing CGFer may provide SaddlepointProb, the saddlepoint
+// approximation to the density
+//  f(x) ≈ exp(K(ŝ) - ŝx) / sqrt(2π K''(ŝ)), where K'(ŝ) = x.
+// The approximation is accurate far into the tails, so it is useful for sums
+// of independent variables, whose cumulant generating functions add.
+type CGFer interface {
+	CGF(s float64) (k, dk, d2k float64)
+	CGFDomain() (lo, hi float64)
+}
+
+// Validator is a type that can check its parameters are in their valid range.
+// Validate is typically called after UnmarshalParameters.
+type Validator interface {
+	Validate() error
+}
+
+// ProbBetween returns the probability that a sample drawn from d lies in
+// the interval (a, b], that is CDF(b) - CDF(a). If d also implements Survivaler
+// and the interval is in the upper tail of the distribution, the probability
+// is computed as Survival(a) - Survival(b) to avoid cancellation.
+//
+// ProbBetween panics if a > b.
+func ProbBetween(d CDFer, a, b float64) float64 {
+	if a > b {
+		panic("dist: lower bound greater than upper bound")
+	}
+	cdfA := d.CDF(a)
+	if s, ok := d.(Survivaler); ok && cdfA > 0.5 {
+		return s.Survival(a) - s.Survival(b)
+	}
+	return d.CDF(b) - cdfA
+}
@@ -187,3 +187,263 @@ func parametersEqual(p1, p2 []Parameter, tol float64) bool {
 	}
 	return true
 }
+
+// integrate computes the integral of f over [a, b] using the composite
+// Simpson rule with n intervals.
+func integrate(f func(float64) float64, a, b float64, n int) float64 {
+	if n%2 != 0 {
+		n++
+	}
+	h := (b - a) / float64(n)
+	sum := f(a) + f(b)
+	for i := 1; i < n; i++ {
+		x := a + float64(i)*h
+		if i%2 == 0 {
+			sum += 2 * f(x)
+		} else {
+			sum += 4 * f(x)
+		}
+	}
+	return sum * h / 3
+}
+
+func TestProbBetween(t *testing.T) {
+	for _, test := range []struct {
+		dist interface {
+			CDFer
+			Prob(float64) float64
+		}
+		a, b float64
+	}{
+		{Normal{Mu: 0, Sigma: 1}, -1, 1},
+		{Normal{Mu: 2, Sigma: 5}, 3, 20},
+		{Exponential{Rate: 2}, 0, 0.5},
+		{Exponential{Rate: 2}, 3, 4},
+		{Laplace{Mu: 1, Scale: 2}, 1.5, 8},
+		{Uniform{Min: -1, Max: 3}, 0, 2},
+	} {
+		got := ProbBetween(test.dist, test.a, test.b)
+		want := integrate(test.dist.Prob, test.a, test.b, 1000)
+		if math.Abs(got-want) > 1e-10 {
+			t.Errorf("ProbBetween mismatch for %#v on [%v, %v]. Expected %v, Found %v", test.dist, test.a, test.b, want, got)
+		}
+	}
+}
+
+func TestQuantileBounds(t *testing.T) {
+	inf := math.Inf(1)
+	for _, test := range []struct {
+		dist   interface{ Quantile(float64) float64 }
+		lo, hi float64
+	}{
+		{Exponential{Rate: 2}, 0, inf},
+		{GeneralizedGamma{A: 2, D: 3, P: 0.5}, 0, inf},
+		{HalfNormal{Sigma: 2}, 0, inf},
+		{InverseGamma{Alpha: 2, Beta: 3}, 0, inf},
+		{Kumaraswamy{A: 2, B: 5}, 0, 1},
+		{Laplace{Mu: 1, Scale: 2}, -inf, inf},
+		{Normal{Mu: 1, Sigma: 2}, -inf, inf},
+		{TruncatedNormal{Mu: 0, Sigma: 1, Lower: -1, Upper: 3}, -1, 3},
+		{TruncatedNormal{Mu: 0, Sigma: 1, Lower: 2, Upper: inf}, 2, inf},
+		{Uniform{Min: 0.1, Max: 0.3}, 0.1, 0.3},
+		{Weibull{K: 1, Lambda: 2}, 0, inf},
+		{Weibull{K: 0.5, Lambda: 2}, 0, inf},
+	} {
+		if x := test.dist.Quantile(0); x != test.lo || math.Signbit(x) != math.Signbit(test.lo) {
+			t.Errorf("Quantile(0) mismatch for %#v. Expected %v, Found %v", test.dist, test.lo, x)
+		}
+		if x := test.dist.Quantile(1); x != test.hi {
+			t.Errorf("Quantile(1) mismatch for %#v. Expected %v, Found %v", test.dist, test.hi, x)
+		}
+		for _, p := range []float64{-0.1, 1.1} {
+			if !panics(func() { test.dist.Quantile(p) }) {
+				t.Errorf("Quantile(%v) did not panic for %#v", p, test.dist)
+			}
+		}
+	}
+}
+
+func TestNonFinite(t *testing.T) {
+	nan := math.NaN()
+	inf := math.Inf(1)
+	for _, d := range []interface{}{
+		Binomial{N: 10, P: 0.3},
+		Burr{C: 2, K: 3, Lambda: 1.5},
+		Categorical{Weights: []float64{1, 2, 3}},
+		Erlang{K: 3, Lambda: 2},
+		Exponential{Rate: 2},
+		FoldedNormal{Mu: 1, Sigma: 2},
+		FoldedNormal{Mu: 0, Sigma: 1},
+		Gamma{Alpha: 2, Beta: 3},
+		GeneralizedExtremeValue{Mu: 1, Sigma: 2, Xi: 0.2},
+		GeneralizedExtremeValue{Mu: 1, Sigma: 2, Xi: 0},
+		GeneralizedExtremeValue{Mu: 1, Sigma: 2, Xi: -0.2},
+		GeneralizedGamma{A: 1, D: 2, P: 1.5},
+		GeneralizedPareto{Mu: 1, Sigma: 2, Xi: 0.2},
+		GeneralizedPareto{Mu: 1, Sigma: 2, Xi: -0.2},
+		HalfNormal{Sigma: 2},
+		InverseGamma{Alpha: 2, Beta: 3},
+		Kumaraswamy{A: 2, B: 5},
+		Laplace{Mu: 1, Scale: 2},
+		LogLogistic{Alpha: 1, Beta: 3},
+		Normal{Mu: 1, Sigma: 2},
+		PearsonIII{Mu: 1, Sigma: 2, Gamma: 0.5},
+		PearsonIII{Mu: 1, Sigma: 2, Gamma: -0.5},
+		Poisson{Lambda: 3},
+		Rician{Nu: 1, Sigma: 2},
+		TruncatedNormal{Mu: 0, Sigma: 1, Lower: -1, Upper: 2},
+		Tweedie{Mu: 2, Phi: 1, Power: 1.5},
+		Uniform{Min: -1, Max: 3},
+		Weibull{K: 1.5, Lambda: 2},
+	} {
+		for _, test := range []struct {
+			x                      float64
+			prob, logProb, cdf, sf float64
+		}{
+			{nan, nan, nan, nan, nan},
+			{-inf, 0, -inf, 0, 1},
+			{inf, 0, -inf, 1, 0},
+		} {
+			check := func(name string, got, want float64) {
+				if math.IsNaN(want) != math.IsNaN(got) || (!math.IsNaN(want) && got != want) {
+					t.Errorf("%s(%v) mismatch for %v. Expected %v, Found %v", name, test.x, d, want, got)
+				}
+			}
+			if v, ok := d.(Prober); ok {
+				check("Prob", v.Prob(test.x), test.prob)
+			}
+			if v, ok := d.(LogProber); ok {
+				check("LogProb", v.LogProb(test.x), test.logProb)
+			}
+			if v, ok := d.(CDFer); ok {
+				check("CDF", v.CDF(test.x), test.cdf)
+			}
+			if v, ok := d.(Survivaler); ok {
+				check("Survival", v.Survival(test.x), test.sf)
+			}
+			if v, ok := d.(Quantiler); ok {
+				if !panics(func() { v.Quantile(test.x) }) {
+					t.Errorf("Quantile(%v) did not panic for %v", test.x, d)
+				}
+			}
+		}
+	}
+}
+
+func panics(f func()) (b bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			b = true
+		}
+	}()
+	f()
+	return
+}
+
+func TestSliceMethods(t *testing.T) {
+	xs := []float64{math.Inf(-1), -3, -0.5, 0, 0.1, 0.5, 1, 1.5, 2, 4, 20, math.Inf(1)}
+	for _, dist := range []interface {
+		CDF(float64) float64
+		Prob(float64) float64
+		CDFSlice([]float64) []float64
+		ProbSlice([]float64) []float64
+	}{
+		Exponential{Rate: 1.5},
+		Laplace{Mu: 1, Scale: 0.5},
+		Normal{Mu: 1, Sigma: 2},
+		TruncatedNormal{Mu: 0, Sigma: 1, Lower: -1, Upper: 3},
+		TruncatedNormal{Mu: 0, Sigma: 1, Lower: 0.5, Upper: math.Inf(1)},
+		Uniform{Min: -1, Max: 3},
+		Weibull{K: 1.5, Lambda: 2},
+		Weibull{K: 0.5, Lambda: 0.5},
+	} {
+		cdfs := dist.CDFSlice(xs)
+		probs := dist.ProbSlice(xs)
+		for i, x := range xs {
+			if want := dist.CDF(x); !equalRel(cdfs[i], want, 1e-14) {
+				t.Errorf("CDFSlice mismatch for %#v at %v. Expected %v, Found %v", dist, x, want, cdfs[i])
+			}
+			if want := dist.Prob(x); !equalRel(probs[i], want, 1e-14) {
+				t.Errorf("ProbSlice mismatch for %#v at %v. Expected %v, Found %v", dist, x, want, probs[i])
+			}
+		}
+	}
+}
+
+// equalRel returns whether a and b are equal to within the relative
+// tolerance tol.
+func equalRel(a, b, tol float64) bool {
+	if a == b {
+		return true
+	}
+	return math.Abs(a-b) <= tol*math.Max(math.Abs(a), math.Abs(b))
+}
+
+func TestString(t *testing.T) {
+	for _, test := range []struct {
+		d    fmt.Stringer
+		want string
+	}{
+		{Weibull{K: 1.5, Lambda: 2}, "Weibull{K: 1.5, λ: 2}"},
+		{Normal{Mu: -1, Sigma: 0.25}, "Normal{Mu: -1, Sigma: 0.25}"},
+		{&Exponential{Rate: 3}, "Exponential{Rate: 3}"},
+		{Uniform{Min: 0, Max: math.Inf(1)}, "Uniform{Min: 0, Max: +Inf}"},
+	} {
+		if got := test.d.String(); got != test.want {
+			t.Errorf("String mismatch. Expected %q, Found %q", test.want, got)
+		}
+		if got := fmt.Sprint(test.d); got != test.want {
+			t.Errorf("Sprint mismatch. Expected %q, Found %q", test.want, got)
+		}
+	}
+}
+
+func TestInverseSurvival(t *testing.T) {
+	for _, test := range []struct {
+		d interface {
+			InverseSurvivaler
+			Survivaler
+			Quantiler
+		}
+		minQ float64
+	}{
+		{Burr{C: 2, K: 3, Lambda: 1}, 1e-300},
+		{Exponential{Rate: 2}, 1e-300},
+		{GeneralizedExtremeValue{Mu: 1, Sigma: 2, Xi: 0.2}, 1e-300},
+		{GeneralizedExtremeValue{Mu: 1, Sigma: 2, Xi: 0}, 1e-300},
+		{GeneralizedExtremeValue{Mu: 1, Sigma: 2, Xi: -0.2}, 1e-12},
+		{GeneralizedPareto{Mu: 1, Sigma: 2, Xi: 0.2}, 1e-300},
+		{GeneralizedPareto{Mu: 1, Sigma: 2, Xi: 0}, 1e-300},
+		{GeneralizedPareto{Mu: 1, Sigma: 2, Xi: -0.2}, 1e-12},
+		{HalfNormal{Sigma: 2}, 1e-300},
+		{Laplace{Mu: 1, Scale: 2}, 1e-300},
+		{LogLogistic{Alpha: 2, Beta: 3}, 1e-300},
+		{Normal{Mu: 1, Sigma: 2}, 1e-300},
+		// Near a finite upper bound of the support the points can
+		// only resolve upper tails down to the spacing of
+		// floating-point numbers, as for the negative shapes above.
+		{Uniform{Min: -1, Max: 3}, 0.01},
+		{Weibull{K: 2, Lambda: 3}, 1e-300},
+	} {
+		for _, q := range []float64{1e-300, 1e-100, 1e-12, 1e-6, 0.01, 0.3, 0.5, 0.7} {
+			if q < test.minQ {
+				continue
+			}
+			x := test.d.InverseSurvival(q)
+			// Survival is exp(-y) or similar for many of the
+			// distributions, with a condition number of about
+			// y ≤ 700.
+			if got := test.d.Survival(x); !equalRel(got, q, 1e-11) {
+				t.Errorf("Survival(InverseSurvival(%v)) mismatch for %v. Expected %v, Found %v", q, test.d, q, got)
+			}
+		}
+		for _, p := range []float64{0.1, 0.5, 0.9} {
+			if got, want := test.d.InverseSurvival(1-p), test.d.Quantile(p); !equalRel(got, want, 1e-12) {
+				t.Errorf("InverseSurvival mismatch for %v at %v. Expected %v, Found %v", test.d, 1-p, want, got)
+			}
+		}
+		if !panics(func() { test.d.InverseSurvival(math.NaN()) }) {
+			t.Errorf("InverseSurvival did not panic with NaN for %v", test.d)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// GeneralizedExtremeValue represents the generalized extreme value (GEV)
+// distribution (https://en.wikipedia.org/wiki/Generalized_extreme_value_distribution),
+// the limiting distribution of the maxima of blocks of samples. The
+// cumulative distribution function is
+//  CDF(x) = exp(-(1 + ξz)^(-1/ξ)), z = (x-μ)/σ
+// and reduces to the Gumbel distribution exp(-exp(-z)) when ξ = 0. Valid
+// range for x is [μ-σ/ξ,+∞) if ξ > 0, (-∞,+∞) if ξ = 0 and (-∞, μ-σ/ξ] if
+// ξ < 0.
+type GeneralizedExtremeValue struct {
+	Mu     float64 // Location parameter
+	Sigma  float64 // Scale parameter
+	Xi     float64 // Shape parameter
+	Source Source
+}
+
+// NewGeneralizedExtremeValue returns a generalized extreme value distribution
+// with location mu, scale sigma and shape xi that samples from src.
+// NewGeneralizedExtremeValue returns an error if mu or xi is not finite, or if
+// sigma is not positive and finite.
+func NewGeneralizedExtremeValue(mu, sigma, xi float64, src Source) (GeneralizedExtremeValue, error) {
+	g := GeneralizedExtremeValue{Mu: mu, Sigma: sigma, Xi: xi, Source: src}
+	if err := g.Validate(); err != nil {
+		return GeneralizedExtremeValue{}, err
+	}
+	return g, nil
+}
+
+// logT returns log t(x), where t(x) = (1 + ξz)^(-1/ξ), or exp(-z) if ξ = 0,
+// and CDF(x) = exp(-t(x)). ok is false if 1 + ξz <= 0, outside the
+// interior of the support.
+func (g GeneralizedExtremeValue) logT(x float64) (lt float64, ok bool) {
+	z := (x - g.Mu) / g.Sigma
+	if g.Xi == 0 {
+		return -z, true
+	}
+	u := g.Xi * z
+	if !(u > -1) {
+		return 0, false
+	}
+	return -math.Log1p(u) / g.Xi, true
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (g GeneralizedExtremeValue) CDF(x float64) float64 {
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	lt, ok := g.logT(x)
+	if !ok {
+		if g.Xi > 0 {
+			return 0
+		}
+		return 1
+	}
+	return math.Exp(-math.Exp(lt))
+}
+
+// FitPWM sets the parameters of the distribution from the first three
+// probability-weighted moments of the samples (Hosking, Wallis and Wood,
+// 1985). The moments are estimated without bias, without a plotting position;
+// see probWeightedMoments. The shape is found from the approximation
+//  k = 7.8590c + 2.9554c², c = (2b₁ - b₀)/(3b₂ - b₀) - log 2/log 3
+// with ξ = -k, which is accurate for -0.5 < ξ < 0.5. Probability-weighted
+// moment estimates are typically less variable than maximum likelihood
+// estimates for small samples. FitPWM panics if there are fewer than three
+// samples.
+func (g *GeneralizedExtremeValue) FitPWM(samples []float64) {
+	b := probWeightedMoments(samples, 3)
+	l2 := 2*b[1] - b[0]
+	c := l2/(3*b[2]-b[0]) - math.Ln2/math.Log(3)
+	k := c * (7.8590 + 2.9554*c)
+	if k == 0 {
+		g.Sigma = l2 / math.Ln2
+		g.Mu = b[0] - eulerGamma*g.Sigma
+		g.Xi = 0
+		return
+	}
+	gk := math.Gamma(1 + k)
+	g.Sigma = l2 * k / (gk * -math.Expm1(-k*math.Ln2))
+	g.Mu = b[0] + g.Sigma*(gk-1)/k
+	g.Xi = -k
+}
+
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with n intervals.
+func (g GeneralizedExtremeValue) IntegrateProb(lo, hi float64, n int) float64 {
+	return integrateProb(g, lo, hi, n)
+}
+
+// InverseSurvival returns the inverse of the survival function.
+func (g GeneralizedExtremeValue) InverseSurvival(q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	l := math.Log(-math.Log1p(-q))
+	if g.Xi == 0 {
+		return g.Mu - g.Sigma*l
+	}
+	return g.Mu + g.Sigma*math.Expm1(-g.Xi*l)/g.Xi
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (g GeneralizedExtremeValue) LogProb(x float64) float64 {
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	lt, ok := g.logT(x)
+	if !ok {
+		// At the upper bound of the support when ξ < 0 the density is
+		// zero for ξ > -1, 1/σ for ξ = -1 and infinite for ξ < -1.
+		if g.Xi < 0 && x == g.Mu-g.Sigma/g.Xi {
+			switch {
+			case g.Xi == -1:
+				return -math.Log(g.Sigma)
+			case g.Xi < -1:
+				return math.Inf(1)
+			}
+		}
+		return math.Inf(-1)
+	}
+	if math.IsInf(lt, 1) {
+		// The lower tail, where exp(-t) vanishes faster than t^(ξ+1) grows.
+		return math.Inf(-1)
+	}
+	return -math.Log(g.Sigma) + (g.Xi+1)*lt - math.Exp(lt)
+}
+
+// MarshalParameters implements the ParameterMarshaler interface
+func (g GeneralizedExtremeValue) MarshalParameters(p []Parameter) {
+	if len(p) != g.NumParameters() {
+		panic("generalizedextremevalue: improper parameter length")
+	}
+	p[0].Name = "Mu"
+	p[0].Value = g.Mu
+	p[1].Name = "Sigma"
+	p[1].Value = g.Sigma
+	p[2].Name = "Xi"
+	p[2].Value = g.Xi
+	return
+}
+
+// Mean returns the mean of the probability distribution. The mean is +Inf
+// if ξ >= 1.
+func (g GeneralizedExtremeValue) Mean() float64 {
+	switch {
+	case g.Xi >= 1:
+		return math.Inf(1)
+	case g.Xi == 0:
+		return g.Mu + g.Sigma*eulerGamma
+	}
+	return g.Mu + g.Sigma*(math.Gamma(1-g.Xi)-1)/g.Xi
+}
+
+// Median returns the median of the probability distribution.
+func (g GeneralizedExtremeValue) Median() float64 {
+	return g.Quantile(0.5)
+}
+
+// Moment returns the k-th raw moment E[X^k] of the distribution, or the k-th
+// central moment E[(X-μ)^k] if central is true. The moments are computed by
+// numerical integration of the density, and are inaccurate if the moment
+// does not exist. Moment panics if k is negative.
+func (g GeneralizedExtremeValue) Moment(k int, central bool) float64 {
+	return numericalMoment(g, k, central)
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (g GeneralizedExtremeValue) NormalizationCheck() float64 {
+	return normalization(g)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (GeneralizedExtremeValue) NumParameters() int {
+	return 3
+}
+
+// Prob computes the value of the probability density function at x.
+func (g GeneralizedExtremeValue) Prob(x float64) float64 {
+	return math.Exp(g.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+func (g GeneralizedExtremeValue) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	// log(-log p) is the negated quantile of the standard Gumbel
+	// distribution.
+	l := math.Log(-math.Log(p))
+	if g.Xi == 0 {
+		return g.Mu - g.Sigma*l
+	}
+	return g.Mu + g.Sigma*math.Expm1(-g.Xi*l)/g.Xi
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (g GeneralizedExtremeValue) Rand() float64 {
+	return g.Quantile(randOpen(g.Source))
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (g GeneralizedExtremeValue) StdDev() float64 {
+	return math.Sqrt(g.Variance())
+}
+
+// String implements the fmt.Stringer interface.
+func (g GeneralizedExtremeValue) String() string {
+	return formatParams(&g)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (g GeneralizedExtremeValue) Survival(x float64) float64 {
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	lt, ok := g.logT(x)
+	if !ok {
+		if g.Xi > 0 {
+			return 1
+		}
+		return 0
+	}
+	return -math.Expm1(-math.Exp(lt))
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface
+func (g *GeneralizedExtremeValue) UnmarshalParameters(p []Parameter) {
+	if len(p) != g.NumParameters() {
+		panic("generalizedextremevalue: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Mu" {
+		panic("generalizedextremevalue: " + panicNameMismatch)
+	}
+	if p[1].Name != "Sigma" {
+		panic("generalizedextremevalue: " + panicNameMismatch)
+	}
+	if p[2].Name != "Xi" {
+		panic("generalizedextremevalue: " + panicNameMismatch)
+	}
+	g.Mu = p[0].Value
+	g.Sigma = p[1].Value
+	g.Xi = p[2].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Mu and Xi are finite and
+// Sigma is positive and finite.
+func (g GeneralizedExtremeValue) Validate() error {
+	return firstError(
+		checkFinite("generalizedextremevalue", "Mu", g.Mu),
+		checkPositive("generalizedextremevalue", "Sigma", g.Sigma),
+		checkFinite("generalizedextremevalue", "Xi", g.Xi),
+	)
+}
+
+// Variance returns the variance of the probability distribution. The variance
+// is +Inf if 1/2 <= ξ < 1 and NaN if ξ >= 1, where the mean is also infinite.
+func (g GeneralizedExtremeValue) Variance() float64 {
+	switch {
+	case g.Xi >= 1:
+		return math.NaN()
+	case g.Xi >= 0.5:
+		return math.Inf(1)
+	case g.Xi == 0:
+		return g.Sigma * g.Sigma * math.Pi * math.Pi / 6
+	}
+	g1 := math.Gamma(1 - g.Xi)
+	g2 := math.Gamma(1 - 2*g.Xi)
+	return g.Sigma * g.Sigma * (g2 - g1*g1) / (g.Xi * g.Xi)
+}
@@ -0,0 +1,112 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGeneralizedExtremeValue(t *testing.T) {
+	for i, g := range []GeneralizedExtremeValue{
+		{Mu: 0, Sigma: 1, Xi: 0},
+		{Mu: 1, Sigma: 2, Xi: 0.2},
+		{Mu: -1, Sigma: 0.5, Xi: -0.3},
+	} {
+		lo, hi := g.Quantile(1e-6), g.Quantile(1-1e-9)
+		xs := []float64{lo - 1, lo, g.Quantile(0.1), g.Median(), g.Quantile(0.9), hi, hi + 1}
+		if err := CheckProbLog(g, xs, 1e-13); err != nil {
+			t.Errorf("Case %d: %v", i, err)
+		}
+		if err := CheckCDF(g, lo-1, hi+1, 1000); err != nil {
+			t.Errorf("Case %d: %v", i, err)
+		}
+		if err := CheckMarshalRoundTrip(&g); err != nil {
+			t.Errorf("Case %d: %v", i, err)
+		}
+		for _, p := range []float64{1e-6, 0.01, 0.3, 0.5, 0.8, 0.999} {
+			if got := g.CDF(g.Quantile(p)); !equalRel(got, p, 1e-12) {
+				t.Errorf("Case %d: CDF(Quantile(%v)) mismatch. Found %v", i, p, got)
+			}
+			x := g.Quantile(p)
+			if got := g.CDF(x) + g.Survival(x); !equalRel(got, 1, 1e-14) {
+				t.Errorf("Case %d: CDF + Survival mismatch at %v. Found %v", i, x, got)
+			}
+		}
+
+		// Integrate the density and its first two moments between extreme
+		// quantiles.
+		const n = 20000
+		h := (hi - lo) / n
+		var mass, mean, second float64
+		for j := 0; j <= n; j++ {
+			w := 2.0
+			switch {
+			case j == 0 || j == n:
+				w = 1
+			case j%2 == 1:
+				w = 4
+			}
+			x := lo + float64(j)*h
+			p := w * g.Prob(x) * h / 3
+			mass += p
+			mean += x * p
+			second += x * x * p
+		}
+		if !equalRel(mass, 1, 1e-5) {
+			t.Errorf("Case %d: integral of Prob mismatch. Expected 1, Found %v", i, mass)
+		}
+		if math.Abs(mean-g.Mean()) > 1e-3 {
+			t.Errorf("Case %d: Mean mismatch. Expected %v, Found %v", i, mean, g.Mean())
+		}
+		if v := second - mean*mean; math.Abs(v-g.Variance()) > 1e-2*g.Variance() {
+			t.Errorf("Case %d: Variance mismatch. Expected %v, Found %v", i, v, g.Variance())
+		}
+	}
+
+	// A negative shape bounds the support above.
+	g := GeneralizedExtremeValue{Mu: 0, Sigma: 1, Xi: -0.5}
+	if got := g.Quantile(1); got != 2 {
+		t.Errorf("Quantile(1) mismatch. Expected 2, Found %v", got)
+	}
+	if g.CDF(2.5) != 1 || g.Prob(2.5) != 0 {
+		t.Errorf("Support mismatch above the bound. Found CDF %v, Prob %v", g.CDF(2.5), g.Prob(2.5))
+	}
+	// A positive shape bounds the support below.
+	g = GeneralizedExtremeValue{Mu: 0, Sigma: 1, Xi: 0.5}
+	if got := g.Quantile(0); got != -2 {
+		t.Errorf("Quantile(0) mismatch. Expected -2, Found %v", got)
+	}
+	if g.CDF(-2.5) != 0 || g.Prob(-2.5) != 0 {
+		t.Errorf("Support mismatch below the bound. Found CDF %v, Prob %v", g.CDF(-2.5), g.Prob(-2.5))
+	}
+}
+
+func TestGeneralizedExtremeValueFitPWM(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, want := range []GeneralizedExtremeValue{
+		{Mu: 0, Sigma: 1, Xi: 0},
+		{Mu: 10, Sigma: 3, Xi: 0.2},
+		{Mu: -1, Sigma: 0.5, Xi: -0.3},
+	} {
+		want.Source = src
+		samples := make([]float64, 20000)
+		for j := range samples {
+			samples[j] = want.Rand()
+		}
+		var got GeneralizedExtremeValue
+		got.FitPWM(samples)
+		if math.Abs(got.Mu-want.Mu) > 0.03*want.Sigma {
+			t.Errorf("Case %d: Mu mismatch. Expected %v, Found %v", i, want.Mu, got.Mu)
+		}
+		if math.Abs(got.Sigma-want.Sigma) > 0.03*want.Sigma {
+			t.Errorf("Case %d: Sigma mismatch. Expected %v, Found %v", i, want.Sigma, got.Sigma)
+		}
+		if math.Abs(got.Xi-want.Xi) > 0.03 {
+			t.Errorf("Case %d: Xi mismatch. Expected %v, Found %v", i, want.Xi, got.Xi)
+		}
+	}
+}
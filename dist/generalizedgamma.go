@@ -0,0 +1,242 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// GeneralizedGamma represents the generalized gamma distribution of Stacy
+// (https://en.wikipedia.org/wiki/Generalized_gamma_distribution), with density
+//  p/a^d x^(d-1) exp(-(x/a)^p) / Γ(d/p)
+// Valid range for x is [0,+∞).
+//
+// Special cases are:
+//  D == P is the Weibull distribution with K = P and λ = A.
+//  P == 1 is the gamma distribution with shape D and scale A.
+//  D == P == 1 is the exponential distribution with rate 1/A.
+type GeneralizedGamma struct {
+	// A is the scale parameter. Valid range is (0,+∞).
+	A float64
+	// D is the first shape parameter. Valid range is (0,+∞).
+	D float64
+	// P is the second shape parameter. Valid range is (0,+∞).
+	P float64
+	// Source of random numbers
+	Source Source
+}
+
+// NewGeneralizedGamma returns a generalized gamma distribution with scale a
+// and shapes d and p that samples from src. NewGeneralizedGamma returns an
+// error if any parameter is not positive and finite.
+func NewGeneralizedGamma(a, d, p float64, src Source) (GeneralizedGamma, error) {
+	g := GeneralizedGamma{A: a, D: d, P: p, Source: src}
+	if err := g.Validate(); err != nil {
+		return GeneralizedGamma{}, err
+	}
+	return g, nil
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (g GeneralizedGamma) CDF(x float64) float64 {
+	x = snapNonNegative(x)
+	if x <= 0 {
+		return 0
+	}
+	return gammaIncReg(g.D/g.P, math.Pow(x/g.A, g.P))
+}
+
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with n intervals.
+func (g GeneralizedGamma) IntegrateProb(lo, hi float64, n int) float64 {
+	return integrateProb(g, lo, hi, n)
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (g GeneralizedGamma) LogProb(x float64) float64 {
+	x = snapNonNegative(x)
+	if x < 0 {
+		return math.Inf(-1)
+	}
+	if math.IsInf(x, 1) {
+		return math.Inf(-1)
+	}
+	lg, _ := math.Lgamma(g.D / g.P)
+	if x == 0 {
+		switch {
+		case g.D < 1:
+			return math.Inf(1)
+		case g.D > 1:
+			return math.Inf(-1)
+		}
+		return math.Log(g.P) - math.Log(g.A) - lg
+	}
+	return math.Log(g.P) - g.D*math.Log(g.A) + (g.D-1)*math.Log(x) - math.Pow(x/g.A, g.P) - lg
+}
+
+// MarshalParameters implements the ParameterMarshaler interface.
+func (g GeneralizedGamma) MarshalParameters(p []Parameter) {
+	if len(p) != g.NumParameters() {
+		panic("generalizedgamma: improper parameter length")
+	}
+	p[0].Name = "A"
+	p[0].Value = g.A
+	p[1].Name = "D"
+	p[1].Value = g.D
+	p[2].Name = "P"
+	p[2].Value = g.P
+	return
+}
+
+// Mean returns the mean of the probability distribution.
+func (g GeneralizedGamma) Mean() float64 {
+	return g.A * g.gammaRatio(1)
+}
+
+// gammaRatio returns Γ((D+i)/P) / Γ(D/P).
+func (g GeneralizedGamma) gammaRatio(i float64) float64 {
+	num, _ := math.Lgamma((g.D + i) / g.P)
+	den, _ := math.Lgamma(g.D / g.P)
+	return math.Exp(num - den)
+}
+
+// Mode returns the mode of the probability distribution.
+func (g GeneralizedGamma) Mode() float64 {
+	if g.D <= 1 {
+		return 0
+	}
+	return g.A * math.Pow((g.D-1)/g.P, 1/g.P)
+}
+
+// Moment returns the k-th raw moment E[X^k] of the distribution, or the k-th
+// central moment E[(X-μ)^k] if central is true. The raw moments are
+// A^k Γ((D+k)/P) / Γ(D/P), and the central moments are computed from them.
+// Moment panics if k is negative.
+func (g GeneralizedGamma) Moment(k int, central bool) float64 {
+	checkMomentOrder(k)
+	raw := func(j int) float64 {
+		return math.Pow(g.A, float64(j)) * g.gammaRatio(float64(j))
+	}
+	if central {
+		return centralFromRaw(k, raw)
+	}
+	return raw(k)
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (g GeneralizedGamma) NormalizationCheck() float64 {
+	return normalization(g)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (GeneralizedGamma) NumParameters() int {
+	return 3
+}
+
+// Prob computes the value of the probability density function at x.
+func (g GeneralizedGamma) Prob(x float64) float64 {
+	return math.Exp(g.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+// Quantile(0) is 0 and Quantile(1) is +Inf, the bounds of the support.
+func (g GeneralizedGamma) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	return g.A * math.Pow(gammaIncRegInv(g.D/g.P, p), 1/g.P)
+}
+
+// QuantileWithTol is like Quantile but with a configurable convergence
+// criterion for the underlying root finder. Iteration stops when the relative
+// change in the solution is less than tol, or after maxIter iterations.
+// Quantile uses tol = 1e-8 and maxIter = 12. A smaller tol gives a more
+// accurate inverse at the cost of more iterations.
+func (g GeneralizedGamma) QuantileWithTol(p, tol float64, maxIter int) float64 {
+	if !(p >= 0 && p <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	if !(tol > 0) || maxIter < 1 {
+		panic("generalizedgamma: invalid tolerance")
+	}
+	x, _ := gammaIncRegInvTol(g.D/g.P, p, tol, maxIter)
+	return g.A * math.Pow(x, 1/g.P)
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (g GeneralizedGamma) Rand() float64 {
+	return g.A * math.Pow(randGamma(g.D/g.P, g.Source), 1/g.P)
+}
+
+// RandAntithetic returns n samples drawn from the distribution in antithetic
+// pairs, as described for randAntithetic.
+func (g GeneralizedGamma) RandAntithetic(n int) []float64 {
+	return randAntithetic(g, n, g.Source)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (g GeneralizedGamma) StdDev() float64 {
+	return math.Sqrt(g.Variance())
+}
+
+// StratifiedSample returns n stratified samples from the distribution, as
+// described for stratifiedSample, drawing uniforms from src or, if src is nil,
+// from the Source of the distribution.
+func (g GeneralizedGamma) StratifiedSample(n int, src Source) []float64 {
+	if src == nil {
+		src = g.Source
+	}
+	return stratifiedSample(g, n, src)
+}
+
+// String implements the fmt.Stringer interface.
+func (g GeneralizedGamma) String() string {
+	return formatParams(&g)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (g GeneralizedGamma) Survival(x float64) float64 {
+	x = snapNonNegative(x)
+	if x <= 0 {
+		return 1
+	}
+	return gammaIncRegComp(g.D/g.P, math.Pow(x/g.A, g.P))
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface.
+func (g *GeneralizedGamma) UnmarshalParameters(p []Parameter) {
+	if len(p) != g.NumParameters() {
+		panic("generalizedgamma: incorrect number of parameters to set")
+	}
+	if p[0].Name != "A" {
+		panic("generalizedgamma: " + panicNameMismatch)
+	}
+	if p[1].Name != "D" {
+		panic("generalizedgamma: " + panicNameMismatch)
+	}
+	if p[2].Name != "P" {
+		panic("generalizedgamma: " + panicNameMismatch)
+	}
+	g.A = p[0].Value
+	g.D = p[1].Value
+	g.P = p[2].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if A, D and P are positive and
+// finite.
+func (g GeneralizedGamma) Validate() error {
+	return firstError(
+		checkPositive("generalizedgamma", "A", g.A),
+		checkPositive("generalizedgamma", "D", g.D),
+		checkPositive("generalizedgamma", "P", g.P),
+	)
+}
+
+// Variance returns the variance of the probability distribution.
+func (g GeneralizedGamma) Variance() float64 {
+	m := g.gammaRatio(1)
+	return g.A * g.A * (g.gammaRatio(2) - m*m)
+}
@@ -0,0 +1,131 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGeneralizedGammaWeibull(t *testing.T) {
+	for _, w := range []Weibull{
+		{K: 0.5, Lambda: 1},
+		{K: 1, Lambda: 1},
+		{K: 2, Lambda: 1},
+		{K: 5, Lambda: 1},
+		{K: 0.5, Lambda: 1.5},
+		{K: 1.7, Lambda: 0.3},
+	} {
+		g := GeneralizedGamma{A: w.Lambda, D: w.K, P: w.K}
+		for _, x := range []float64{0.01, 0.1, 0.5, 1, 2, 5, 20} {
+			if math.Abs(g.LogProb(x)-w.LogProb(x)) > 1e-12*math.Max(1, math.Abs(w.LogProb(x))) {
+				t.Errorf("LogProb mismatch at %v for %#v. Expected %v, Found %v", x, w, w.LogProb(x), g.LogProb(x))
+			}
+			if !absEq(g.Prob(x), w.Prob(x)) {
+				t.Errorf("Prob mismatch at %v for %#v. Expected %v, Found %v", x, w, w.Prob(x), g.Prob(x))
+			}
+			if !absEq(g.CDF(x), w.CDF(x)) {
+				t.Errorf("CDF mismatch at %v for %#v. Expected %v, Found %v", x, w, w.CDF(x), g.CDF(x))
+			}
+			if !absEq(g.Survival(x), w.Survival(x)) {
+				t.Errorf("Survival mismatch at %v for %#v. Expected %v, Found %v", x, w, w.Survival(x), g.Survival(x))
+			}
+		}
+		for _, p := range []float64{0.001, 0.1, 0.5, 0.9, 0.999} {
+			if math.Abs(g.Quantile(p)-w.Quantile(p)) > 1e-10*w.Quantile(p) {
+				t.Errorf("Quantile mismatch at %v for %#v. Expected %v, Found %v", p, w, w.Quantile(p), g.Quantile(p))
+			}
+		}
+		if math.Abs(g.Mean()-w.Mean()) > 1e-12*w.Mean() {
+			t.Errorf("Mean mismatch for %#v. Expected %v, Found %v", w, w.Mean(), g.Mean())
+		}
+		if math.Abs(g.Variance()-w.Variance()) > 1e-12*w.Variance() {
+			t.Errorf("Variance mismatch for %#v. Expected %v, Found %v", w, w.Variance(), g.Variance())
+		}
+	}
+}
+
+func TestGeneralizedGamma(t *testing.T) {
+	for _, g := range []GeneralizedGamma{
+		{A: 1, D: 1, P: 1},
+		{A: 2, D: 3, P: 1},
+		{A: 0.5, D: 0.7, P: 1},
+		{A: 1.5, D: 2, P: 3},
+		{A: 3, D: 4, P: 0.5},
+	} {
+		for _, p := range []float64{0.001, 0.1, 0.5, 0.9, 0.999} {
+			x := g.Quantile(p)
+			if cdf := g.CDF(x); math.Abs(cdf-p) > 1e-10 {
+				t.Errorf("CDF(Quantile(%v)) mismatch for %#v. Found %v", p, g, cdf)
+			}
+			if cdf := integrate(g.Prob, 0, x, 10000); g.D >= 1 && math.Abs(cdf-p) > 1e-6 {
+				t.Errorf("Integrated density mismatch at Quantile(%v) for %#v. Found %v", p, g, cdf)
+			}
+		}
+
+		g.Source = rand.New(rand.NewSource(1))
+		x := make([]float64, 100000)
+		for i := range x {
+			x[i] = g.Rand()
+		}
+		checkMeanVariance(t, x, g.Mean(), g.Variance(), "GeneralizedGamma")
+	}
+
+	// With D == P == 1 the generalized gamma is the exponential distribution.
+	g := GeneralizedGamma{A: 0.25, D: 1, P: 1}
+	e := Exponential{Rate: 4}
+	for _, x := range []float64{0, 0.1, 1, 3} {
+		if !absEq(g.Prob(x), e.Prob(x)) {
+			t.Errorf("Prob mismatch with exponential at %v. Expected %v, Found %v", x, e.Prob(x), g.Prob(x))
+		}
+		if !absEq(g.CDF(x), e.CDF(x)) {
+			t.Errorf("CDF mismatch with exponential at %v. Expected %v, Found %v", x, e.CDF(x), g.CDF(x))
+		}
+	}
+}
+
+func TestGeneralizedGammaQuantileWithTol(t *testing.T) {
+	var moreIter bool
+	for _, a := range []float64{0.3, 1, 2.5, 10, 50} {
+		for _, p := range []float64{1e-6, 0.01, 0.3, 0.5, 0.9, 0.999} {
+			xLoose, nLoose := gammaIncRegInvTol(a, p, 1e-2, 100)
+			xTight, nTight := gammaIncRegInvTol(a, p, 1e-14, 100)
+			errLoose := math.Abs(gammaIncReg(a, xLoose) - p)
+			errTight := math.Abs(gammaIncReg(a, xTight) - p)
+			if errTight > errLoose+1e-15 {
+				t.Errorf("a = %v, p = %v: tighter tolerance less accurate. Loose %v, Tight %v", a, p, errLoose, errTight)
+			}
+			if errTight > 1e-12*p {
+				t.Errorf("a = %v, p = %v: inverse not accurate with tight tolerance. Error %v", a, p, errTight)
+			}
+			if nTight < nLoose {
+				t.Errorf("a = %v, p = %v: tighter tolerance used fewer iterations. Loose %v, Tight %v", a, p, nLoose, nTight)
+			}
+			if nTight > nLoose {
+				moreIter = true
+			}
+		}
+	}
+	if !moreIter {
+		t.Errorf("tighter tolerance never required more iterations")
+	}
+
+	g := GeneralizedGamma{A: 2, D: 3, P: 1.5}
+	for _, p := range []float64{0, 0.1, 0.5, 0.9, 1} {
+		if q, qt := g.Quantile(p), g.QuantileWithTol(p, 1e-8, 12); q != qt {
+			t.Errorf("Quantile mismatch with default tolerance at p = %v. Expected %v, Found %v", p, q, qt)
+		}
+		if p > 0 && p < 1 {
+			x := g.QuantileWithTol(p, 1e-14, 100)
+			if c := g.CDF(x); math.Abs(c-p) > 1e-12 {
+				t.Errorf("CDF(QuantileWithTol) mismatch. Expected %v, Found %v", p, c)
+			}
+		}
+	}
+	if !panics(func() { g.QuantileWithTol(0.5, 0, 10) }) {
+		t.Errorf("expected panic for zero tolerance")
+	}
+}
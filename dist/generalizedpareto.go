@@ -0,0 +1,414 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// GeneralizedPareto represents the generalized Pareto distribution
+// (https://en.wikipedia.org/wiki/Generalized_Pareto_distribution), the
+// limiting distribution of exceedances over a high threshold. The cumulative
+// distribution function is
+//  CDF(x) = 1 - (1 + ξz)^(-1/ξ), z = (x-μ)/σ
+// and reduces to the exponential distribution 1 - exp(-z) when ξ = 0.
+// Valid range for x is [μ,+∞) if ξ >= 0 and [μ, μ-σ/ξ] if ξ < 0.
+type GeneralizedPareto struct {
+	Mu     float64 // Location parameter, the lower bound of the support
+	Sigma  float64 // Scale parameter
+	Xi     float64 // Shape parameter
+	Source Source
+}
+
+// NewGeneralizedPareto returns a generalized Pareto distribution with
+// location mu, scale sigma and shape xi that samples from src.
+// NewGeneralizedPareto returns an error if mu or xi is not finite, or if
+// sigma is not positive and finite.
+func NewGeneralizedPareto(mu, sigma, xi float64, src Source) (GeneralizedPareto, error) {
+	g := GeneralizedPareto{Mu: mu, Sigma: sigma, Xi: xi, Source: src}
+	if err := g.Validate(); err != nil {
+		return GeneralizedPareto{}, err
+	}
+	return g, nil
+}
+
+// upper returns the upper bound of the support.
+func (g GeneralizedPareto) upper() float64 {
+	if g.Xi >= 0 {
+		return math.Inf(1)
+	}
+	return g.Mu - g.Sigma/g.Xi
+}
+
+// logSurvival returns the log of the survival function for x in the support.
+func (g GeneralizedPareto) logSurvival(x float64) float64 {
+	z := (x - g.Mu) / g.Sigma
+	if g.Xi == 0 {
+		return -z
+	}
+	return -math.Log1p(g.Xi*z) / g.Xi
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (g GeneralizedPareto) CDF(x float64) float64 {
+	switch {
+	case x <= g.Mu:
+		return 0
+	case x >= g.upper():
+		return 1
+	}
+	return -math.Expm1(g.logSurvival(x))
+}
+
+// Entropy returns the entropy of the distribution.
+func (g GeneralizedPareto) Entropy() float64 {
+	return math.Log(g.Sigma) + g.Xi + 1
+}
+
+// gpFitMaxXi is the largest shape parameter considered by
+// GeneralizedPareto.Fit.
+const gpFitMaxXi = 5
+
+// Fit sets the scale and shape parameters of the distribution to their
+// maximum likelihood estimates from the samples with relative weights,
+// keeping Mu fixed as the known threshold. Every sample must be at least Mu.
+// If weights is nil, then all the weights are 1.
+// If weights is not nil, then the len(weights) must equal len(samples).
+//
+// The likelihood is maximized over θ = ξ/σ, for which the estimates of ξ and
+// σ have closed forms (Grimshaw, 1993). The search is restricted to ξ in
+// [-1, gpFitMaxXi], since the likelihood is unbounded for ξ < -1. If every
+// sample equals Mu, Sigma and Xi are set to zero.
+func (g *GeneralizedPareto) Fit(samples, weights []float64) {
+	if weights != nil && len(samples) != len(weights) {
+		panic("dist: length of samples and weights must match")
+	}
+	if len(samples) == 0 {
+		panic("dist: must have at least one sample")
+	}
+	y := make([]float64, len(samples))
+	var max float64
+	for i, x := range samples {
+		if x < g.Mu {
+			panic("dist: sample below the location of the generalized Pareto distribution")
+		}
+		y[i] = x - g.Mu
+		max = math.Max(max, y[i])
+	}
+	if max == 0 {
+		g.Sigma = 0
+		g.Xi = 0
+		return
+	}
+	weight := func(i int) float64 {
+		if weights == nil {
+			return 1
+		}
+		return weights[i]
+	}
+
+	var sumWeights, mean float64
+	for i, v := range y {
+		sumWeights += weight(i)
+		mean += weight(i) * v
+	}
+	mean /= sumWeights
+	// xi returns the maximum likelihood estimate of ξ for a given θ.
+	xi := func(theta float64) float64 {
+		var s float64
+		for i, v := range y {
+			s += weight(i) * math.Log1p(theta*v)
+		}
+		return s / sumWeights
+	}
+	// profile returns the log-likelihood per unit weight maximized over σ
+	// and ξ with θ fixed.
+	profile := func(theta float64) float64 {
+		if theta == 0 {
+			return -math.Log(mean) - 1
+		}
+		x := xi(theta)
+		return -math.Log(x/theta) - 1 - x
+	}
+
+	// Bracket θ so that ξ(θ), which increases with θ, lies in
+	// [-1, gpFitMaxXi].
+	lo, hi := -1/max, 0.0
+	for i := 0; i < 200; i++ {
+		mid := (lo + hi) / 2
+		if xi(mid) < -1 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	thetaLo := hi
+	thetaHi := 1 / max
+	for i := 0; i < 1000 && xi(thetaHi) < gpFitMaxXi; i++ {
+		thetaHi *= 2
+	}
+
+	// Evaluate on a grid, uniform for negative θ and geometric for positive
+	// θ, then refine around the best grid point by golden-section search.
+	const n = 100
+	grid := make([]float64, 0, 2*n+1)
+	for i := 0; i < n; i++ {
+		grid = append(grid, thetaLo*float64(n-i)/n)
+	}
+	grid = append(grid, 0)
+	for i := 0; i < n; i++ {
+		grid = append(grid, thetaHi*math.Pow(1e-8, float64(n-1-i)/(n-1)))
+	}
+	best := 0
+	bestLL := math.Inf(-1)
+	for i, theta := range grid {
+		if ll := profile(theta); ll > bestLL {
+			best, bestLL = i, ll
+		}
+	}
+	a := grid[best]
+	if best > 0 {
+		a = grid[best-1]
+	}
+	b := grid[best]
+	if best < len(grid)-1 {
+		b = grid[best+1]
+	}
+	const invPhi = 0.6180339887498949
+	c := b - invPhi*(b-a)
+	d := a + invPhi*(b-a)
+	fc, fd := profile(c), profile(d)
+	for i := 0; i < 100 && b-a > 1e-12*math.Max(math.Abs(a), math.Abs(b)); i++ {
+		if fc > fd {
+			b, d, fd = d, c, fc
+			c = b - invPhi*(b-a)
+			fc = profile(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + invPhi*(b-a)
+			fd = profile(d)
+		}
+	}
+	theta := grid[best]
+	if ll := math.Max(fc, fd); ll > bestLL {
+		theta = c
+		if fd > fc {
+			theta = d
+		}
+	}
+	if theta == 0 {
+		g.Sigma = mean
+		g.Xi = 0
+		return
+	}
+	g.Xi = xi(theta)
+	g.Sigma = g.Xi / theta
+}
+
+// FitPWM sets the scale and shape parameters of the distribution from the
+// first two probability-weighted moments of the exceedances over Mu, keeping
+// Mu fixed as the known threshold (Hosking and Wallis, 1987). With
+//  a₀ = E[X-μ], a₁ = E[(X-μ)(1-F(X))]
+// the estimates are
+//  σ = 2a₀a₁/(a₀ - 2a₁), ξ = 2 - a₀/(a₀ - 2a₁)
+// The moments are estimated without bias, without a plotting position; see
+// probWeightedMoments. The estimates are consistent only for ξ < 1, but for
+// small samples they are typically less variable than those of Fit. Every
+// sample must be at least Mu. FitPWM panics if there are fewer than two
+// samples.
+func (g *GeneralizedPareto) FitPWM(samples []float64) {
+	y := make([]float64, len(samples))
+	for i, x := range samples {
+		if x < g.Mu {
+			panic("dist: sample below the location of the generalized Pareto distribution")
+		}
+		y[i] = x - g.Mu
+	}
+	b := probWeightedMoments(y, 2)
+	a0 := b[0]
+	a1 := b[0] - b[1]
+	d := a0 - 2*a1
+	g.Sigma = 2 * a0 * a1 / d
+	g.Xi = 2 - a0/d
+}
+
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with n intervals.
+func (g GeneralizedPareto) IntegrateProb(lo, hi float64, n int) float64 {
+	return integrateProb(g, lo, hi, n)
+}
+
+// InverseSurvival returns the inverse of the survival function.
+func (g GeneralizedPareto) InverseSurvival(q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	e := -math.Log(q)
+	if g.Xi == 0 {
+		return g.Mu + g.Sigma*e
+	}
+	if q == 0 {
+		return g.upper()
+	}
+	return g.Mu + g.Sigma*math.Expm1(g.Xi*e)/g.Xi
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (g GeneralizedPareto) LogProb(x float64) float64 {
+	up := g.upper()
+	if x < g.Mu || x > up {
+		return math.Inf(-1)
+	}
+	z := (x - g.Mu) / g.Sigma
+	switch {
+	case g.Xi == 0:
+		return -math.Log(g.Sigma) - z
+	case g.Xi == -1:
+		return -math.Log(g.Sigma)
+	case x == up:
+		if g.Xi < -1 {
+			return math.Inf(1)
+		}
+		return math.Inf(-1)
+	}
+	return -math.Log(g.Sigma) - (1/g.Xi+1)*math.Log1p(g.Xi*z)
+}
+
+// MarshalParameters implements the ParameterMarshaler interface
+func (g GeneralizedPareto) MarshalParameters(p []Parameter) {
+	if len(p) != g.NumParameters() {
+		panic("generalizedpareto: improper parameter length")
+	}
+	p[0].Name = "Mu"
+	p[0].Value = g.Mu
+	p[1].Name = "Sigma"
+	p[1].Value = g.Sigma
+	p[2].Name = "Xi"
+	p[2].Value = g.Xi
+	return
+}
+
+// Mean returns the mean of the probability distribution. The mean is +Inf
+// if ξ >= 1.
+func (g GeneralizedPareto) Mean() float64 {
+	if g.Xi >= 1 {
+		return math.Inf(1)
+	}
+	return g.Mu + g.Sigma/(1-g.Xi)
+}
+
+// Median returns the median of the probability distribution.
+func (g GeneralizedPareto) Median() float64 {
+	return g.Quantile(0.5)
+}
+
+// Moment returns the k-th raw moment E[X^k] of the distribution, or the k-th
+// central moment E[(X-μ)^k] if central is true. The moments are computed by
+// numerical integration of the density, and are inaccurate if the moment
+// does not exist. Moment panics if k is negative.
+func (g GeneralizedPareto) Moment(k int, central bool) float64 {
+	return numericalMoment(g, k, central)
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (g GeneralizedPareto) NormalizationCheck() float64 {
+	return normalization(g)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (GeneralizedPareto) NumParameters() int {
+	return 3
+}
+
+// Prob computes the value of the probability density function at x.
+func (g GeneralizedPareto) Prob(x float64) float64 {
+	return math.Exp(g.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+// Quantile(0) is μ and Quantile(1) is the upper bound of the support.
+func (g GeneralizedPareto) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	// -log(1-p) is the quantile of the standard exponential distribution.
+	e := -math.Log1p(-p)
+	if g.Xi == 0 {
+		return g.Mu + g.Sigma*e
+	}
+	if p == 1 {
+		return g.upper()
+	}
+	return g.Mu + g.Sigma*math.Expm1(g.Xi*e)/g.Xi
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (g GeneralizedPareto) Rand() float64 {
+	return g.Quantile(randFloat64(g.Source))
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (g GeneralizedPareto) StdDev() float64 {
+	return math.Sqrt(g.Variance())
+}
+
+// String implements the fmt.Stringer interface.
+func (g GeneralizedPareto) String() string {
+	return formatParams(&g)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (g GeneralizedPareto) Survival(x float64) float64 {
+	switch {
+	case x <= g.Mu:
+		return 1
+	case x >= g.upper():
+		return 0
+	}
+	return math.Exp(g.logSurvival(x))
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface
+func (g *GeneralizedPareto) UnmarshalParameters(p []Parameter) {
+	if len(p) != g.NumParameters() {
+		panic("generalizedpareto: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Mu" {
+		panic("generalizedpareto: " + panicNameMismatch)
+	}
+	if p[1].Name != "Sigma" {
+		panic("generalizedpareto: " + panicNameMismatch)
+	}
+	if p[2].Name != "Xi" {
+		panic("generalizedpareto: " + panicNameMismatch)
+	}
+	g.Mu = p[0].Value
+	g.Sigma = p[1].Value
+	g.Xi = p[2].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Mu and Xi are finite and
+// Sigma is positive and finite.
+func (g GeneralizedPareto) Validate() error {
+	return firstError(
+		checkFinite("generalizedpareto", "Mu", g.Mu),
+		checkPositive("generalizedpareto", "Sigma", g.Sigma),
+		checkFinite("generalizedpareto", "Xi", g.Xi),
+	)
+}
+
+// Variance returns the variance of the probability distribution. The variance
+// is +Inf if 1/2 <= ξ < 1 and NaN if ξ >= 1, where the mean is also infinite.
+func (g GeneralizedPareto) Variance() float64 {
+	if g.Xi >= 1 {
+		return math.NaN()
+	}
+	if g.Xi >= 0.5 {
+		return math.Inf(1)
+	}
+	d := 1 - g.Xi
+	return g.Sigma * g.Sigma / (d * d * (1 - 2*g.Xi))
+}
@@ -0,0 +1,209 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGeneralizedParetoExponential(t *testing.T) {
+	g := GeneralizedPareto{Mu: 1, Sigma: 2, Xi: 0}
+	e := Exponential{Rate: 0.5}
+	for _, x := range []float64{0.5, 1, 1.5, 3, 10, 40} {
+		if got, want := g.Prob(x), e.Prob(x-1); !equalRel(got, want, 1e-14) {
+			t.Errorf("Prob mismatch at %v. Expected %v, Found %v", x, want, got)
+		}
+		if got, want := g.CDF(x), e.CDF(x-1); !equalRel(got, want, 1e-14) {
+			t.Errorf("CDF mismatch at %v. Expected %v, Found %v", x, want, got)
+		}
+	}
+	for _, p := range []float64{0, 0.1, 0.5, 0.9, 0.999} {
+		if got, want := g.Quantile(p), 1+e.Quantile(p); !equalRel(got, want, 1e-14) {
+			t.Errorf("Quantile mismatch at %v. Expected %v, Found %v", p, want, got)
+		}
+	}
+	if got, want := g.Mean(), 1+e.Mean(); !equalRel(got, want, 1e-14) {
+		t.Errorf("Mean mismatch. Expected %v, Found %v", want, got)
+	}
+	if got, want := g.Variance(), e.Variance(); !equalRel(got, want, 1e-14) {
+		t.Errorf("Variance mismatch. Expected %v, Found %v", want, got)
+	}
+
+	// Small shapes approach the exponential.
+	h := GeneralizedPareto{Mu: 1, Sigma: 2, Xi: 1e-9}
+	for _, x := range []float64{1.5, 3, 10} {
+		if got, want := h.CDF(x), g.CDF(x); !equalRel(got, want, 1e-7) {
+			t.Errorf("CDF mismatch for small Xi at %v. Expected %v, Found %v", x, want, got)
+		}
+	}
+}
+
+func TestGeneralizedParetoBounded(t *testing.T) {
+	g := GeneralizedPareto{Mu: 1, Sigma: 2, Xi: -0.5, Source: rand.New(rand.NewSource(1))}
+	up := 5.0
+	if got := g.Quantile(1); got != up {
+		t.Errorf("Quantile(1) mismatch. Expected %v, Found %v", up, got)
+	}
+	for _, x := range []float64{up, up + 1, 100} {
+		if got := g.CDF(x); got != 1 {
+			t.Errorf("CDF mismatch at %v. Expected 1, Found %v", x, got)
+		}
+		if got := g.Survival(x); got != 0 {
+			t.Errorf("Survival mismatch at %v. Expected 0, Found %v", x, got)
+		}
+		if x > up && g.Prob(x) != 0 {
+			t.Errorf("Prob mismatch at %v. Expected 0, Found %v", x, g.Prob(x))
+		}
+	}
+	// With ξ = -1/2 the density is linear, falling from 1/σ at μ to 0 at
+	// the upper bound.
+	for _, x := range []float64{1, 2, 3, 4.5} {
+		want := (up - x) / (up - 1) / 2
+		if got := g.Prob(x); !equalRel(got, want, 1e-14) {
+			t.Errorf("Prob mismatch at %v. Expected %v, Found %v", x, want, got)
+		}
+	}
+	for i := 0; i < 10000; i++ {
+		if x := g.Rand(); x < 1 || x > up {
+			t.Fatalf("Rand out of support. Found %v", x)
+		}
+	}
+	if u := (GeneralizedPareto{Mu: 0, Sigma: 3, Xi: -1}); !equalRel(u.Prob(2), 1.0/3, 1e-15) || !equalRel(u.Prob(3), 1.0/3, 1e-15) {
+		t.Errorf("Xi = -1 is not uniform. Found Prob(2) = %v, Prob(3) = %v", u.Prob(2), u.Prob(3))
+	}
+}
+
+func TestGeneralizedPareto(t *testing.T) {
+	for i, g := range []GeneralizedPareto{
+		{Mu: 0, Sigma: 1, Xi: 0.3},
+		{Mu: -2, Sigma: 0.5, Xi: -0.25},
+		{Mu: 3, Sigma: 2, Xi: 0},
+	} {
+		xs := []float64{g.Mu - 1, g.Mu, g.Quantile(0.1), g.Quantile(0.5), g.Quantile(0.99)}
+		if err := CheckProbLog(g, xs, 1e-14); err != nil {
+			t.Errorf("Case %d: %v", i, err)
+		}
+		if err := CheckCDF(g, g.Mu-1, g.Quantile(0.999), 1000); err != nil {
+			t.Errorf("Case %d: %v", i, err)
+		}
+		if err := CheckMarshalRoundTrip(&g); err != nil {
+			t.Errorf("Case %d: %v", i, err)
+		}
+		for _, p := range []float64{0.01, 0.3, 0.5, 0.8, 0.999} {
+			if got := g.CDF(g.Quantile(p)); !equalRel(got, p, 1e-13) {
+				t.Errorf("Case %d: CDF(Quantile(%v)) mismatch. Found %v", i, p, got)
+			}
+		}
+		// The density integrates to the CDF.
+		x0, x1 := g.Quantile(0.05), g.Quantile(0.9)
+		const n = 2000
+		h := (x1 - x0) / n
+		var sum float64
+		for j := 0; j <= n; j++ {
+			w := 2.0
+			switch {
+			case j == 0 || j == n:
+				w = 1
+			case j%2 == 1:
+				w = 4
+			}
+			sum += w * g.Prob(x0+float64(j)*h)
+		}
+		if got := sum * h / 3; !equalRel(got, 0.85, 1e-10) {
+			t.Errorf("Case %d: integral of Prob mismatch. Expected 0.85, Found %v", i, got)
+		}
+	}
+}
+
+func TestGeneralizedParetoFit(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, want := range []GeneralizedPareto{
+		{Mu: 0, Sigma: 1, Xi: 0.3},
+		{Mu: 2, Sigma: 3, Xi: -0.3},
+		{Mu: 0, Sigma: 2, Xi: 0},
+	} {
+		want.Source = src
+		samples := make([]float64, 20000)
+		for j := range samples {
+			samples[j] = want.Rand()
+		}
+		got := GeneralizedPareto{Mu: want.Mu}
+		got.Fit(samples, nil)
+		if math.Abs(got.Sigma-want.Sigma) > 0.05*want.Sigma {
+			t.Errorf("Case %d: Sigma mismatch. Expected %v, Found %v", i, want.Sigma, got.Sigma)
+		}
+		if math.Abs(got.Xi-want.Xi) > 0.03 {
+			t.Errorf("Case %d: Xi mismatch. Expected %v, Found %v", i, want.Xi, got.Xi)
+		}
+	}
+
+	// Doubling the weight of every sample does not change the estimate.
+	g := GeneralizedPareto{Mu: 0, Sigma: 1, Xi: 0.2, Source: src}
+	samples := make([]float64, 500)
+	weights := make([]float64, len(samples))
+	for j := range samples {
+		samples[j] = g.Rand()
+		weights[j] = 2
+	}
+	a := GeneralizedPareto{}
+	a.Fit(samples, nil)
+	b := GeneralizedPareto{}
+	b.Fit(samples, weights)
+	if !equalRel(a.Sigma, b.Sigma, 1e-10) || !equalRel(a.Xi, b.Xi, 1e-10) {
+		t.Errorf("Weighted fit mismatch. Expected %v, Found %v", a, b)
+	}
+	if !panics(func() { (&GeneralizedPareto{Mu: 1}).Fit([]float64{0.5, 2}, nil) }) {
+		t.Errorf("expected panic for sample below Mu")
+	}
+}
+
+func TestGeneralizedParetoFitPWM(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, want := range []GeneralizedPareto{
+		{Mu: 0, Sigma: 1, Xi: 0.3},
+		{Mu: 2, Sigma: 3, Xi: -0.3},
+		{Mu: 0, Sigma: 2, Xi: 0},
+	} {
+		want.Source = src
+		samples := make([]float64, 20000)
+		for j := range samples {
+			samples[j] = want.Rand()
+		}
+		got := GeneralizedPareto{Mu: want.Mu}
+		got.FitPWM(samples)
+		if math.Abs(got.Sigma-want.Sigma) > 0.05*want.Sigma {
+			t.Errorf("Case %d: Sigma mismatch. Expected %v, Found %v", i, want.Sigma, got.Sigma)
+		}
+		if math.Abs(got.Xi-want.Xi) > 0.03 {
+			t.Errorf("Case %d: Xi mismatch. Expected %v, Found %v", i, want.Xi, got.Xi)
+		}
+	}
+
+	// For small samples the probability-weighted moment estimate of the
+	// shape has a smaller mean squared error than the maximum likelihood
+	// estimate.
+	want := GeneralizedPareto{Mu: 0, Sigma: 1, Xi: 0.1, Source: src}
+	const (
+		reps = 500
+		n    = 15
+	)
+	var msePWM, mseMLE float64
+	samples := make([]float64, n)
+	for r := 0; r < reps; r++ {
+		for j := range samples {
+			samples[j] = want.Rand()
+		}
+		var pwm, mle GeneralizedPareto
+		pwm.FitPWM(samples)
+		mle.Fit(samples, nil)
+		msePWM += (pwm.Xi - want.Xi) * (pwm.Xi - want.Xi)
+		mseMLE += (mle.Xi - want.Xi) * (mle.Xi - want.Xi)
+	}
+	if msePWM >= mseMLE {
+		t.Errorf("PWM shape estimate not more stable than MLE at n = %d. Found MSE %v and %v", n, msePWM/reps, mseMLE/reps)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// HalfNormal represents the half-normal distribution, the distribution of |X|
+// where X is normally distributed with mean zero
+// (https://en.wikipedia.org/wiki/Half-normal_distribution).
+// Valid range for x is [0,+∞).
+type HalfNormal struct {
+	// Sigma is the standard deviation of the underlying normal distribution.
+	// Valid range is (0,+∞).
+	Sigma  float64
+	Source Source
+}
+
+// NewHalfNormal returns a half-normal distribution with scale sigma that
+// samples from src. NewHalfNormal returns an error if sigma is not positive
+// and finite.
+func NewHalfNormal(sigma float64, src Source) (HalfNormal, error) {
+	h := HalfNormal{Sigma: sigma, Source: src}
+	if err := h.Validate(); err != nil {
+		return HalfNormal{}, err
+	}
+	return h, nil
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (h HalfNormal) CDF(x float64) float64 {
+	x = snapNonNegative(x)
+	if x < 0 {
+		return 0
+	}
+	return math.Erf(x / (h.Sigma * math.Sqrt2))
+}
+
+// Entropy returns the differential entropy of the distribution.
+func (h HalfNormal) Entropy() float64 {
+	return 0.5*math.Log(math.Pi*h.Sigma*h.Sigma/2) + 0.5
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution.
+func (HalfNormal) ExKurtosis() float64 {
+	return 8 * (math.Pi - 3) / ((math.Pi - 2) * (math.Pi - 2))
+}
+
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with n intervals.
+func (h HalfNormal) IntegrateProb(lo, hi float64, n int) float64 {
+	return integrateProb(h, lo, hi, n)
+}
+
+// InverseSurvival returns the inverse of the survival function.
+func (h HalfNormal) InverseSurvival(q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	// math.Erfcinv computes Erfinv(1-q), which loses the precision of
+	// small q.
+	return -h.Sigma * zQuantile(q/2)
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (h HalfNormal) LogProb(x float64) float64 {
+	x = snapNonNegative(x)
+	if x < 0 {
+		return math.Inf(-1)
+	}
+	return math.Ln2 + negLogRoot2Pi - math.Log(h.Sigma) - x*x/(2*h.Sigma*h.Sigma)
+}
+
+// MarshalParameters implements the ParameterMarshaler interface
+func (h HalfNormal) MarshalParameters(p []Parameter) {
+	if len(p) != h.NumParameters() {
+		panic("halfnormal: improper parameter length")
+	}
+	p[0].Name = "Sigma"
+	p[0].Value = h.Sigma
+	return
+}
+
+// Mean returns the mean of the probability distribution.
+func (h HalfNormal) Mean() float64 {
+	return h.Sigma * math.Sqrt(2/math.Pi)
+}
+
+// Median returns the median of the probability distribution.
+func (h HalfNormal) Median() float64 {
+	return h.Quantile(0.5)
+}
+
+// Mode returns the mode of the probability distribution.
+func (HalfNormal) Mode() float64 {
+	return 0
+}
+
+// Moment returns the k-th raw moment E[X^k] of the distribution, or the k-th
+// central moment E[(X-μ)^k] if central is true. The raw moments are
+// σ^k 2^(k/2) Γ((k+1)/2)/√π, and the central moments are computed from them.
+// Moment panics if k is negative.
+func (h HalfNormal) Moment(k int, central bool) float64 {
+	checkMomentOrder(k)
+	raw := func(j int) float64 {
+		s := float64(j)
+		lg, _ := math.Lgamma((s + 1) / 2)
+		return math.Pow(h.Sigma, s) * math.Exp(s/2*math.Ln2+lg) / math.SqrtPi
+	}
+	if central {
+		return centralFromRaw(k, raw)
+	}
+	return raw(k)
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (h HalfNormal) NormalizationCheck() float64 {
+	return normalization(h)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (HalfNormal) NumParameters() int {
+	return 1
+}
+
+// Prob computes the value of the probability density function at x.
+func (h HalfNormal) Prob(x float64) float64 {
+	return math.Exp(h.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+// Quantile(0) is 0 and Quantile(1) is +Inf, the bounds of the support.
+func (h HalfNormal) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	return h.Sigma * math.Sqrt2 * math.Erfinv(p)
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (h HalfNormal) Rand() float64 {
+	return math.Abs(h.Sigma * randNormFloat64(h.Source))
+}
+
+// RandAntithetic returns n samples drawn from the distribution in antithetic
+// pairs, as described for randAntithetic.
+func (h HalfNormal) RandAntithetic(n int) []float64 {
+	return randAntithetic(h, n, h.Source)
+}
+
+// Skewness returns the skewness of the distribution.
+func (HalfNormal) Skewness() float64 {
+	return math.Sqrt2 * (4 - math.Pi) / math.Pow(math.Pi-2, 1.5)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (h HalfNormal) StdDev() float64 {
+	return math.Sqrt(h.Variance())
+}
+
+// StratifiedSample returns n stratified samples from the distribution, as
+// described for stratifiedSample, drawing uniforms from src or, if src is nil,
+// from the Source of the distribution.
+func (h HalfNormal) StratifiedSample(n int, src Source) []float64 {
+	if src == nil {
+		src = h.Source
+	}
+	return stratifiedSample(h, n, src)
+}
+
+// String implements the fmt.Stringer interface.
+func (h HalfNormal) String() string {
+	return formatParams(&h)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (h HalfNormal) Survival(x float64) float64 {
+	x = snapNonNegative(x)
+	if x < 0 {
+		return 1
+	}
+	return math.Erfc(x / (h.Sigma * math.Sqrt2))
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface
+func (h *HalfNormal) UnmarshalParameters(p []Parameter) {
+	if len(p) != h.NumParameters() {
+		panic("halfnormal: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Sigma" {
+		panic("halfnormal: " + panicNameMismatch)
+	}
+	h.Sigma = p[0].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Sigma is positive and finite.
+func (h HalfNormal) Validate() error {
+	return checkPositive("halfnormal", "Sigma", h.Sigma)
+}
+
+// Variance returns the variance of the probability distribution.
+func (h HalfNormal) Variance() float64 {
+	return h.Sigma * h.Sigma * (1 - 2/math.Pi)
+}
@@ -0,0 +1,51 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestHalfNormal(t *testing.T) {
+	for _, sigma := range []float64{0.5, 1, 3} {
+		h := HalfNormal{Sigma: sigma}
+		// The half-normal is the normal truncated at zero.
+		tn := TruncatedNormal{Mu: 0, Sigma: sigma, Lower: 0, Upper: math.Inf(1)}
+		for _, x := range []float64{0, 0.1, 0.5, 1, 2, 5, 10} {
+			if !absEq(h.Prob(x), tn.Prob(x)) {
+				t.Errorf("Prob mismatch at %v for σ = %v. Expected %v, Found %v", x, sigma, tn.Prob(x), h.Prob(x))
+			}
+			if !absEq(h.LogProb(x), tn.LogProb(x)) {
+				t.Errorf("LogProb mismatch at %v for σ = %v. Expected %v, Found %v", x, sigma, tn.LogProb(x), h.LogProb(x))
+			}
+			if !absEq(h.CDF(x), tn.CDF(x)) {
+				t.Errorf("CDF mismatch at %v for σ = %v. Expected %v, Found %v", x, sigma, tn.CDF(x), h.CDF(x))
+			}
+			if !absEq(h.Survival(x), tn.Survival(x)) {
+				t.Errorf("Survival mismatch at %v for σ = %v. Expected %v, Found %v", x, sigma, tn.Survival(x), h.Survival(x))
+			}
+		}
+		for _, p := range []float64{0, 0.01, 0.3, 0.5, 0.9, 0.999} {
+			if math.Abs(h.Quantile(p)-tn.Quantile(p)) > 1e-8 {
+				t.Errorf("Quantile mismatch at %v for σ = %v. Expected %v, Found %v", p, sigma, tn.Quantile(p), h.Quantile(p))
+			}
+		}
+		if math.Abs(h.Mean()-tn.Mean()) > 1e-14 {
+			t.Errorf("Mean mismatch for σ = %v. Expected %v, Found %v", sigma, tn.Mean(), h.Mean())
+		}
+		if math.Abs(h.Variance()-tn.Variance()) > 1e-13 {
+			t.Errorf("Variance mismatch for σ = %v. Expected %v, Found %v", sigma, tn.Variance(), h.Variance())
+		}
+
+		h.Source = rand.New(rand.NewSource(1))
+		x := make([]float64, 100000)
+		for i := range x {
+			x[i] = h.Rand()
+		}
+		checkMeanVariance(t, x, h.Mean(), h.Variance(), "HalfNormal")
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// The regularized incomplete beta function follows the presentation in
+// Numerical Recipes, 3rd edition, section 6.4.
+
+const incBetaMaxIter = 1000
+
+// betaIncReg returns the regularized incomplete beta function
+//  I_x(a, b) = 1/B(a, b) ∫_0^x t^(a-1) (1-t)^(b-1) dt
+// for a, b > 0 and 0 ≤ x ≤ 1.
+func betaIncReg(a, b, x float64) float64 {
+	switch {
+	case x <= 0:
+		return 0
+	case x >= 1:
+		return 1
+	}
+	// The continued fraction converges quickly for x < (a+1)/(a+b+2), and
+	// the symmetry I_x(a, b) = 1 - I_{1-x}(b, a) covers the rest.
+	if x > (a+1)/(a+b+2) {
+		return 1 - betaIncReg(b, a, 1-x)
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	prefix := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log1p(-x))
+	return prefix * betaIncFrac(a, b, x) / a
+}
+
+// betaIncFrac evaluates the continued fraction for I_x(a, b) using the
+// modified Lentz method.
+func betaIncFrac(a, b, x float64) float64 {
+	qab := a + b
+	qap := a + 1
+	qam := a - 1
+	c := 1.0
+	d := 1 - qab*x/qap
+	if math.Abs(d) < incGammaTiny {
+		d = incGammaTiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= incBetaMaxIter; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+		// Even step of the recurrence.
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < incGammaTiny {
+			d = incGammaTiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < incGammaTiny {
+			c = incGammaTiny
+		}
+		d = 1 / d
+		h *= d * c
+		// Odd step of the recurrence.
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < incGammaTiny {
+			d = incGammaTiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < incGammaTiny {
+			c = incGammaTiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < incGammaEps {
+			break
+		}
+	}
+	return h
+}
@@ -0,0 +1,127 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// normalizationIntervals is the number of intervals used by the
+// NormalizationCheck methods.
+const normalizationIntervals = 2000
+
+// integrateProb returns the integral of the density of d over [lo, hi], either
+// of which may be infinite, computed by integrateDensity with n intervals. It
+// implements the IntegrateProb methods.
+func integrateProb(d interface {
+	Prober
+	Quantiler
+}, lo, hi float64, n int) float64 {
+	return integrateDensity(d, nil, lo, hi, n)
+}
+
+// integrateDensity returns the integral of g(x) times the density of d over
+// [lo, hi], or of the density alone if g is nil. The whole real line is split
+// at the median of d into two half lines of n/2 intervals each, so that a
+// peak or kink of the density at the median falls on a bound. A half line is
+// mapped to t ∈ [0, 1) by
+//  x = lo + s t/(1-t)  or  x = hi - s t/(1-t),
+// where s is the interquartile range of d, so that the nodes follow the scale
+// of the distribution. The integral over the finite interval [a, b] of t,
+// either [0, 1) or [lo, hi] itself, is computed by the tanh-sinh rule
+//  t = (a+b)/2 + (b-a)/2 tanh(π/2 sinh v)
+// with the trapezoidal rule over n intervals of v ∈ [-6, 6]. The nodes
+// cluster double exponentially at a and b without reaching them, so
+// integrable singularities at a bound, such as that of a Gamma density with
+// shape below 1 at 0, and slowly decaying tails are integrated accurately.
+// The distance of each node to the ends of [a, b] is computed directly, and
+// nodes where the integrand is not finite, which are at a bound to within the
+// precision of x, are skipped. integrateDensity panics if lo > hi or n is not
+// positive.
+func integrateDensity(d interface {
+	Prober
+	Quantiler
+}, g func(float64) float64, lo, hi float64, n int) float64 {
+	if lo > hi {
+		panic("dist: lower bound greater than upper bound")
+	}
+	if n <= 0 {
+		panic("dist: non-positive interval count")
+	}
+	if lo == hi {
+		return 0
+	}
+	if math.IsInf(lo, -1) && math.IsInf(hi, 1) {
+		m := d.Quantile(0.5)
+		half := (n + 1) / 2
+		return integrateDensity(d, g, lo, m, half) + integrateDensity(d, g, m, hi, half)
+	}
+	s := d.Quantile(0.75) - d.Quantile(0.25)
+	if !(s > 0) || math.IsInf(s, 1) {
+		s = 1
+	}
+	// x returns the point and the derivative dx/dt given the distances dl
+	// and du of t from a and b.
+	var width float64
+	var x func(dl, du float64) (float64, float64)
+	switch {
+	case math.IsInf(hi, 1):
+		width = 1
+		x = func(dl, du float64) (float64, float64) {
+			return lo + s*dl/du, s / (du * du)
+		}
+	case math.IsInf(lo, -1):
+		width = 1
+		x = func(dl, du float64) (float64, float64) {
+			return hi - s*dl/du, s / (du * du)
+		}
+	default:
+		width = hi - lo
+		x = func(dl, du float64) (float64, float64) {
+			if dl < du {
+				return lo + dl, 1
+			}
+			return hi - du, 1
+		}
+	}
+	const vmax = 6
+	h := 2 * vmax / float64(n)
+	var sum float64
+	for i := 0; i <= n; i++ {
+		v := -vmax + float64(i)*h
+		y := math.Pi / 2 * math.Sinh(v)
+		dl := width / (1 + math.Exp(2*y))
+		du := width / (1 + math.Exp(-2*y))
+		// dt/dv = (b-a)/2 sech²(y) π/2 cosh(v), where sech²(y) = 4 dl du/(b-a)².
+		dt := math.Pi * math.Cosh(v) * dl / width * du
+		if dt == 0 {
+			continue
+		}
+		xv, dx := x(dl, du)
+		f := d.Prob(xv) * dx * dt
+		if g != nil {
+			f *= g(xv)
+		}
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			continue
+		}
+		if i == 0 || i == n {
+			f /= 2
+		}
+		sum += f
+	}
+	return sum * h
+}
+
+// normalization returns the integral of the density of d over its support,
+// [Quantile(0), Quantile(1)], computed by integrateProb with
+// normalizationIntervals intervals. It implements the NormalizationCheck
+// methods. The result differs from 1 by the error of the integration, which
+// is small even for densities that are singular at a bound of the support,
+// so a larger difference points to an error in Prob or Quantile.
+func normalization(d interface {
+	Prober
+	Quantiler
+}) float64 {
+	return integrateProb(d, d.Quantile(0), d.Quantile(1), normalizationIntervals)
+}
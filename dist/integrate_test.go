@@ -0,0 +1,87 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalizationCheck(t *testing.T) {
+	for _, test := range []struct {
+		d interface {
+			NormalizationCheck() float64
+		}
+		tol float64
+	}{
+		{Normal{Mu: 1, Sigma: 2}, 1e-12},
+		{Normal{Mu: -1e3, Sigma: 1e-3}, 1e-10},
+		{Weibull{K: 2, Lambda: 3}, 1e-12},
+		{Weibull{K: 5, Lambda: 1e3}, 1e-12},
+		// The densities are singular at 0.
+		{Weibull{K: 0.8, Lambda: 1}, 1e-12},
+		{Weibull{K: 0.5, Lambda: 1}, 1e-12},
+		{Gamma{Alpha: 0.5, Beta: 1}, 1e-12},
+		{Gamma{Alpha: 0.1, Beta: 2}, 1e-12},
+		{Burr{C: 0.5, K: 1, Lambda: 1}, 1e-12},
+		{LogLogistic{Alpha: 1, Beta: 0.5}, 1e-12},
+		// The density is also singular at 1, where the nodes closer than
+		// the float64 spacing of x are lost.
+		{Kumaraswamy{A: 0.5, B: 0.5}, 1e-7},
+		{Burr{C: 2, K: 3, Lambda: 1}, 1e-12},
+		{Erlang{K: 3, Lambda: 2}, 1e-12},
+		{Exponential{Rate: 2}, 1e-12},
+		{Gamma{Alpha: 2, Beta: 3}, 1e-12},
+		{GeneralizedExtremeValue{Mu: 1, Sigma: 2, Xi: 0.2}, 1e-12},
+		{GeneralizedExtremeValue{Mu: 1, Sigma: 2, Xi: -0.2}, 1e-12},
+		{GeneralizedGamma{A: 1, D: 2, P: 1.5}, 1e-12},
+		{GeneralizedPareto{Mu: 1, Sigma: 2, Xi: 0.2}, 1e-12},
+		{HalfNormal{Sigma: 2}, 1e-12},
+		{InverseGamma{Alpha: 3, Beta: 2}, 1e-12},
+		{Kumaraswamy{A: 2, B: 5}, 1e-12},
+		{Laplace{Mu: 1, Scale: 2}, 1e-12},
+		{LogLogistic{Alpha: 2, Beta: 3}, 1e-12},
+		{TruncatedNormal{Mu: 0, Sigma: 1, Lower: -1, Upper: 2}, 1e-12},
+		{Uniform{Min: -1, Max: 3}, 1e-14},
+	} {
+		if got := test.d.NormalizationCheck(); math.Abs(got-1) > test.tol {
+			t.Errorf("NormalizationCheck mismatch for %v. Expected 1, Found %v", test.d, got)
+		}
+	}
+}
+
+func TestIntegrateProb(t *testing.T) {
+	inf := math.Inf(1)
+	n := Normal{Mu: 1, Sigma: 2}
+	w := Weibull{K: 2, Lambda: 3}
+	for _, test := range []struct {
+		d interface {
+			CDFer
+			IntegrateProb(lo, hi float64, n int) float64
+		}
+		lo, hi float64
+	}{
+		{n, -1, 4},
+		{n, -inf, 0},
+		{n, 3, inf},
+		{w, 0, 2},
+		{w, 1, 5},
+		{w, 4, inf},
+	} {
+		want := ProbBetween(test.d, test.lo, test.hi)
+		if got := test.d.IntegrateProb(test.lo, test.hi, 1000); math.Abs(got-want) > 1e-10 {
+			t.Errorf("IntegrateProb mismatch for %v on [%v, %v]. Expected %v, Found %v", test.d, test.lo, test.hi, want, got)
+		}
+	}
+	if got := n.IntegrateProb(2, 2, 10); got != 0 {
+		t.Errorf("IntegrateProb mismatch for an empty interval. Expected 0, Found %v", got)
+	}
+	if !panics(func() { n.IntegrateProb(2, 1, 10) }) {
+		t.Errorf("IntegrateProb did not panic with reversed bounds")
+	}
+	if !panics(func() { n.IntegrateProb(1, 2, 0) }) {
+		t.Errorf("IntegrateProb did not panic with no intervals")
+	}
+}
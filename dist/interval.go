@@ -0,0 +1,105 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// IntervalDist is a univariate distribution whose parameters can be fitted
+// to interval-censored observations.
+//
+// UnmarshalParameters typically has a pointer receiver, so an IntervalDist
+// is usually a pointer to a distribution.
+type IntervalDist interface {
+	ParameterMarshaler
+	NumParameters() int
+	LogProb(x float64) float64
+	CDF(x float64) float64
+}
+
+// FitIntervalMLE sets the parameters of d to maximize the likelihood of
+// interval-censored observations, and returns the maximum weighted
+// log-likelihood. Observation i is only known to lie in [lower[i], upper[i]]
+// and contributes
+//  log(CDF(upper[i]) - CDF(lower[i]))
+// to the log-likelihood, or LogProb(lower[i]) if lower[i] == upper[i], so
+// exact observations can be mixed with censored ones. An upper bound of +Inf
+// is a right-censored observation, and a lower bound at or below the support
+// is a left-censored one. If d also has a Survival method it is used for
+// intervals in the upper tail of the distribution, where the difference of
+// the CDF loses precision.
+//
+// The maximization is a damped Newton method with finite-difference
+// derivatives, starting from the current parameters of d, which should be a
+// reasonable initial estimate. If the log-likelihood at the starting
+// parameters is -Inf because some interval has zero probability, the
+// parameters are not changed. If weights is nil, the weights are assumed to
+// be 1. FitIntervalMLE panics if the lengths of lower, upper and a non-nil
+// weights differ, or if lower[i] > upper[i] for some i.
+func FitIntervalMLE(d IntervalDist, lower, upper, weights []float64) float64 {
+	if len(lower) != len(upper) || (weights != nil && len(weights) != len(lower)) {
+		panic("dist: slice length mismatch")
+	}
+	for i, l := range lower {
+		if !(l <= upper[i]) {
+			panic("dist: interval lower bound above upper bound")
+		}
+	}
+	return maximize(d, func() float64 {
+		return intervalLogLikelihood(d, lower, upper, weights)
+	}, nil, nil)
+}
+
+// FitLeftCensored sets the parameters of d to maximize the likelihood of
+// observations with a detection limit, and returns the maximum weighted
+// log-likelihood. An observation with belowLimit[i] true is a non-detect,
+// only known to be below limit, and contributes log CDF(limit) to the
+// log-likelihood; values[i] is then ignored. The others contribute
+// LogProb(values[i]). This is FitIntervalMLE with the non-detects as
+// intervals (-∞, limit], and it avoids the bias of substituting a value such
+// as limit/2 for the non-detects, which distorts both the location and the
+// spread of the fit.
+//
+// The maximization starts from the current parameters of d, which should be
+// a reasonable initial estimate. If weights is nil, the weights are assumed
+// to be 1. FitLeftCensored panics if the lengths of values, belowLimit and a
+// non-nil weights differ.
+func FitLeftCensored(d IntervalDist, values []float64, belowLimit []bool, limit float64, weights []float64) float64 {
+	if len(values) != len(belowLimit) {
+		panic("dist: slice length mismatch")
+	}
+	lower := make([]float64, len(values))
+	upper := make([]float64, len(values))
+	for i, x := range values {
+		lower[i], upper[i] = x, x
+		if belowLimit[i] {
+			lower[i], upper[i] = math.Inf(-1), limit
+		}
+	}
+	return FitIntervalMLE(d, lower, upper, weights)
+}
+
+// intervalLogLikelihood returns the total weighted log-likelihood of the
+// interval-censored observations described in FitIntervalMLE.
+func intervalLogLikelihood(d IntervalDist, lower, upper, weights []float64) float64 {
+	surv, hasSurvival := d.(Survivaler)
+	var ll float64
+	for i, l := range lower {
+		u := upper[i]
+		var v float64
+		switch {
+		case l == u:
+			v = d.LogProb(l)
+		case hasSurvival && d.CDF(l) > 0.5:
+			v = math.Log(surv.Survival(l) - surv.Survival(u))
+		default:
+			v = math.Log(d.CDF(u) - d.CDF(l))
+		}
+		if weights != nil {
+			v *= weights[i]
+		}
+		ll += v
+	}
+	return ll
+}
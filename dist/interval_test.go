@@ -0,0 +1,77 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFitIntervalMLENormal(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	want := Normal{Mu: 3, Sigma: 2, Source: src}
+	const n = 5000
+	lower := make([]float64, n)
+	upper := make([]float64, n)
+	for i := range lower {
+		// Inspections at integer times.
+		x := want.Rand()
+		lower[i] = math.Floor(x)
+		upper[i] = lower[i] + 1
+	}
+	got := Normal{Mu: 2, Sigma: 1.5}
+	FitIntervalMLE(&got, lower, upper, nil)
+	if math.Abs(got.Mu-want.Mu) > 0.1 {
+		t.Errorf("Mu mismatch. Expected %v, Found %v", want.Mu, got.Mu)
+	}
+	if math.Abs(got.Sigma-want.Sigma) > 0.1 {
+		t.Errorf("Sigma mismatch. Expected %v, Found %v", want.Sigma, got.Sigma)
+	}
+
+	// Exact observations have the closed-form estimate.
+	samples := []float64{1, 4, 2, 8, 5}
+	exact := Normal{Mu: 0, Sigma: 1}
+	FitIntervalMLE(&exact, samples, samples, nil)
+	mle := Normal{Mu: 4, Sigma: math.Sqrt(6)}
+	if math.Abs(exact.Mu-mle.Mu) > 1e-6 || math.Abs(exact.Sigma-mle.Sigma) > 1e-6 {
+		t.Errorf("Exact observation fit mismatch. Expected %v, Found %v", mle, exact)
+	}
+
+	if !panics(func() { FitIntervalMLE(&exact, []float64{2}, []float64{1}, nil) }) {
+		t.Errorf("expected panic for inverted interval")
+	}
+}
+
+func TestFitLeftCensored(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	truth := Normal{Mu: 3, Sigma: 2, Source: src}
+	const (
+		n     = 5000
+		limit = 2.0
+	)
+	values := make([]float64, n)
+	below := make([]bool, n)
+	substituted := make([]float64, n)
+	for i := range values {
+		values[i] = truth.Rand()
+		substituted[i] = values[i]
+		if values[i] < limit {
+			below[i] = true
+			values[i] = 0
+			substituted[i] = limit / 2
+		}
+	}
+	got := Normal{Mu: 2, Sigma: 1}
+	FitLeftCensored(&got, values, below, limit, nil)
+	if math.Abs(got.Mu-truth.Mu) > 0.1 || math.Abs(got.Sigma-truth.Sigma) > 0.1 {
+		t.Errorf("FitLeftCensored mismatch. Expected %v, Found %v", truth, got)
+	}
+	naive := Normal{Mu: 2, Sigma: 1}
+	maximizeLikelihood(&naive, substituted, nil, nil)
+	if math.Abs(naive.Mu-truth.Mu) < 3*math.Abs(got.Mu-truth.Mu) || math.Abs(naive.Sigma-truth.Sigma) < 3*math.Abs(got.Sigma-truth.Sigma) {
+		t.Errorf("Substitution not worse than the censored fit. Censored %v, Substituted %v", got, naive)
+	}
+}
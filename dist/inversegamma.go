@@ -0,0 +1,198 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// InverseGamma represents the inverse gamma distribution with shape Alpha and
+// scale Beta (https://en.wikipedia.org/wiki/Inverse-gamma_distribution). If X
+// has a gamma distribution with shape Alpha and rate Beta, 1/X has an inverse
+// gamma distribution with shape Alpha and scale Beta. It is the conjugate
+// prior for the variance of a normal distribution.
+// Valid range for x is [0,+∞).
+type InverseGamma struct {
+	Alpha  float64 // Shape parameter
+	Beta   float64 // Scale parameter
+	Source Source
+}
+
+// NewInverseGamma returns an inverse gamma distribution with shape alpha and
+// scale beta that samples from src. NewInverseGamma returns an error if alpha
+// or beta is not positive and finite.
+func NewInverseGamma(alpha, beta float64, src Source) (InverseGamma, error) {
+	g := InverseGamma{Alpha: alpha, Beta: beta, Source: src}
+	if err := g.Validate(); err != nil {
+		return InverseGamma{}, err
+	}
+	return g, nil
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (g InverseGamma) CDF(x float64) float64 {
+	x = snapNonNegative(x)
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 0
+	}
+	return gammaIncRegComp(g.Alpha, g.Beta/x)
+}
+
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with n intervals.
+func (g InverseGamma) IntegrateProb(lo, hi float64, n int) float64 {
+	return integrateProb(g, lo, hi, n)
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (g InverseGamma) LogProb(x float64) float64 {
+	x = snapNonNegative(x)
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= 0 || math.IsInf(x, 1) {
+		return math.Inf(-1)
+	}
+	lg, _ := math.Lgamma(g.Alpha)
+	return g.Alpha*math.Log(g.Beta) - lg - (g.Alpha+1)*math.Log(x) - g.Beta/x
+}
+
+// MarshalParameters implements the ParameterMarshaler interface
+func (g InverseGamma) MarshalParameters(p []Parameter) {
+	if len(p) != g.NumParameters() {
+		panic("inversegamma: improper parameter length")
+	}
+	p[0].Name = "Alpha"
+	p[0].Value = g.Alpha
+	p[1].Name = "Beta"
+	p[1].Value = g.Beta
+}
+
+// Mean returns the mean of the probability distribution. The mean is +Inf if
+// Alpha ≤ 1.
+func (g InverseGamma) Mean() float64 {
+	if g.Alpha <= 1 {
+		return math.Inf(1)
+	}
+	return g.Beta / (g.Alpha - 1)
+}
+
+// Mode returns the mode of the probability distribution.
+func (g InverseGamma) Mode() float64 {
+	return g.Beta / (g.Alpha + 1)
+}
+
+// Moment returns the k-th raw moment E[X^k] of the distribution, or the k-th
+// central moment E[(X-μ)^k] if central is true. The raw moments are
+// β^k Γ(α-k)/Γ(α) for k < α and +Inf otherwise, and the central moments are
+// computed from them. Moment panics if k is negative.
+func (g InverseGamma) Moment(k int, central bool) float64 {
+	checkMomentOrder(k)
+	if float64(k) >= g.Alpha {
+		return math.Inf(1)
+	}
+	raw := func(j int) float64 {
+		m := 1.0
+		for i := 1; i <= j; i++ {
+			m *= g.Beta / (g.Alpha - float64(i))
+		}
+		return m
+	}
+	if central {
+		return centralFromRaw(k, raw)
+	}
+	return raw(k)
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (g InverseGamma) NormalizationCheck() float64 {
+	return normalization(g)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (InverseGamma) NumParameters() int {
+	return 2
+}
+
+// Prob computes the value of the probability density function at x.
+func (g InverseGamma) Prob(x float64) float64 {
+	return math.Exp(g.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+// Quantile(0) is 0 and Quantile(1) is +Inf, the bounds of the support. The
+// quantile is computed from the gamma quantile at 1-p, so it has reduced
+// relative precision for p close to 0.
+func (g InverseGamma) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	return g.Beta / gammaIncRegInv(g.Alpha, 1-p)
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (g InverseGamma) Rand() float64 {
+	return g.Beta / randGamma(g.Alpha, g.Source)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (g InverseGamma) StdDev() float64 {
+	return math.Sqrt(g.Variance())
+}
+
+// String implements the fmt.Stringer interface.
+func (g InverseGamma) String() string {
+	return formatParams(&g)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (g InverseGamma) Survival(x float64) float64 {
+	x = snapNonNegative(x)
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 1
+	}
+	return gammaIncReg(g.Alpha, g.Beta/x)
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface
+func (g *InverseGamma) UnmarshalParameters(p []Parameter) {
+	if len(p) != g.NumParameters() {
+		panic("inversegamma: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Alpha" {
+		panic("inversegamma: " + panicNameMismatch)
+	}
+	if p[1].Name != "Beta" {
+		panic("inversegamma: " + panicNameMismatch)
+	}
+	g.Alpha = p[0].Value
+	g.Beta = p[1].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Alpha and Beta are positive
+// and finite.
+func (g InverseGamma) Validate() error {
+	return firstError(
+		checkPositive("inversegamma", "Alpha", g.Alpha),
+		checkPositive("inversegamma", "Beta", g.Beta),
+	)
+}
+
+// Variance returns the variance of the probability distribution. The variance
+// is +Inf if Alpha ≤ 2.
+func (g InverseGamma) Variance() float64 {
+	if g.Alpha <= 2 {
+		return math.Inf(1)
+	}
+	am1 := g.Alpha - 1
+	return g.Beta * g.Beta / (am1 * am1 * (g.Alpha - 2))
+}
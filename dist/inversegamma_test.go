@@ -0,0 +1,53 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestInverseGamma(t *testing.T) {
+	for _, ig := range []InverseGamma{
+		{Alpha: 0.5, Beta: 1},
+		{Alpha: 3, Beta: 2},
+		{Alpha: 20, Beta: 0.1},
+	} {
+		// 1/X has a gamma distribution with rate Beta.
+		g := Gamma{Alpha: ig.Alpha, Beta: ig.Beta}
+		for _, p := range []float64{1e-10, 0.001, 0.1, 0.5, 0.9, 0.999, 1 - 1e-10} {
+			x := ig.Quantile(p)
+			// Quantile inverts the gamma CDF at 1-p, which rounds off
+			// the low digits of small p.
+			if got := ig.CDF(x); !equalRel(got, p, 1e-6) {
+				t.Errorf("CDF mismatch for %v at %v. Expected %v, Found %v", ig, x, p, got)
+			}
+			if got, want := ig.Survival(x), g.CDF(1/x); !equalRel(got, want, 1e-12) {
+				t.Errorf("Survival mismatch for %v at %v. Expected %v, Found %v", ig, x, want, got)
+			}
+			// Change of variables y = 1/x, with Jacobian 1/x².
+			if got, want := ig.LogProb(x), g.LogProb(1/x)-2*math.Log(x); !equalRel(got, want, 1e-12) {
+				t.Errorf("LogProb mismatch for %v at %v. Expected %v, Found %v", ig, x, want, got)
+			}
+		}
+	}
+	ig := InverseGamma{Alpha: 1.5, Beta: 2}
+	if !math.IsInf(ig.Variance(), 1) {
+		t.Errorf("Variance mismatch for %v. Expected +Inf, Found %v", ig, ig.Variance())
+	}
+	if !equalRel(ig.Mean(), 4, 1e-15) {
+		t.Errorf("Mean mismatch for %v. Expected 4, Found %v", ig, ig.Mean())
+	}
+}
+
+func TestInverseGammaRand(t *testing.T) {
+	ig := InverseGamma{Alpha: 6, Beta: 3, Source: rand.New(rand.NewSource(1))}
+	x := make([]float64, 100000)
+	for i := range x {
+		x[i] = ig.Rand()
+	}
+	checkMeanVariance(t, x, ig.Mean(), ig.Variance(), "InverseGamma")
+}
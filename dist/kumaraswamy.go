@@ -0,0 +1,252 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// Kumaraswamy represents the Kumaraswamy distribution with shapes A and B
+// (https://en.wikipedia.org/wiki/Kumaraswamy_distribution), which has the
+// density
+//  f(x) = A B x^(A-1) (1 - x^A)^(B-1)
+// on [0, 1]. It resembles the beta distribution with the same shapes, and
+// is used in its place for proportions and probabilities because its CDF and
+// quantile are in closed form.
+// Valid range for x is [0,1].
+type Kumaraswamy struct {
+	A      float64 // First shape parameter
+	B      float64 // Second shape parameter
+	Source Source
+}
+
+// NewKumaraswamy returns a Kumaraswamy distribution with shapes a and b that
+// samples from src. NewKumaraswamy returns an error if a or b is not positive
+// and finite.
+func NewKumaraswamy(a, b float64, src Source) (Kumaraswamy, error) {
+	k := Kumaraswamy{A: a, B: b, Source: src}
+	if err := k.Validate(); err != nil {
+		return Kumaraswamy{}, err
+	}
+	return k, nil
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (k Kumaraswamy) CDF(x float64) float64 {
+	x = snapToSupport(x, 0, 1)
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	return -math.Expm1(k.B * math.Log1p(-math.Pow(x, k.A)))
+}
+
+// DLogProbDParam returns the derivative of the log of the probability with
+// respect to the parameters of the distribution. The deriv slice must have length
+// equal to the number of parameters of the distribution.
+//
+// The order is ∂LogProb / ∂A and then ∂LogProb / ∂B.
+//
+// Special cases are:
+//  The derivative at 0 and 1 is NaN.
+func (k Kumaraswamy) DLogProbDParam(x float64, deriv []float64) {
+	if len(deriv) != k.NumParameters() {
+		panic("kumaraswamy: slice length mismatch")
+	}
+	if x > 0 && x < 1 {
+		lx := math.Log(x)
+		xa := math.Pow(x, k.A)
+		deriv[0] = 1/k.A + lx - (k.B-1)*xa*lx/(1-xa)
+		deriv[1] = 1/k.B + math.Log1p(-xa)
+		return
+	}
+	if x < 0 || x > 1 {
+		deriv[0] = 0
+		deriv[1] = 0
+		return
+	}
+	deriv[0] = math.NaN()
+	deriv[1] = math.NaN()
+}
+
+// DLogProbDX returns the derivative of the log of the probability with
+// respect to the input x.
+//
+// Special cases are:
+//  DLogProbDX(0) = NaN
+//  DLogProbDX(1) = NaN
+func (k Kumaraswamy) DLogProbDX(x float64) float64 {
+	if x > 0 && x < 1 {
+		xa := math.Pow(x, k.A)
+		return ((k.A - 1) - (k.B-1)*k.A*xa/(1-xa)) / x
+	}
+	if x < 0 || x > 1 {
+		return 0
+	}
+	return math.NaN()
+}
+
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with n intervals.
+func (k Kumaraswamy) IntegrateProb(lo, hi float64, n int) float64 {
+	return integrateProb(k, lo, hi, n)
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (k Kumaraswamy) LogProb(x float64) float64 {
+	x = snapToSupport(x, 0, 1)
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 || x > 1 {
+		return math.Inf(-1)
+	}
+	lp := math.Log(k.A * k.B)
+	// The factors x^(A-1) and (1-x^A)^(B-1) are 1 at the boundaries when
+	// the exponent is 0, rather than 0*log(0).
+	if k.A != 1 {
+		lp += (k.A - 1) * math.Log(x)
+	}
+	if k.B != 1 {
+		lp += (k.B - 1) * math.Log1p(-math.Pow(x, k.A))
+	}
+	return lp
+}
+
+// MarshalParameters implements the ParameterMarshaler interface
+func (k Kumaraswamy) MarshalParameters(p []Parameter) {
+	if len(p) != k.NumParameters() {
+		panic("kumaraswamy: improper parameter length")
+	}
+	p[0].Name = "A"
+	p[0].Value = k.A
+	p[1].Name = "B"
+	p[1].Value = k.B
+}
+
+// Mean returns the mean of the probability distribution.
+func (k Kumaraswamy) Mean() float64 {
+	return k.rawMoment(1)
+}
+
+// Median returns the median of the probability distribution.
+func (k Kumaraswamy) Median() float64 {
+	return math.Pow(-math.Expm1(-math.Ln2/k.B), 1/k.A)
+}
+
+// Moment returns the n-th raw moment E[X^n] of the distribution, or the n-th
+// central moment E[(X-μ)^n] if central is true. The raw moments are
+// B Γ(1+n/A) Γ(B) / Γ(1+B+n/A), and the central moments are computed from
+// them. Moment panics if n is negative.
+func (k Kumaraswamy) Moment(n int, central bool) float64 {
+	checkMomentOrder(n)
+	raw := func(j int) float64 {
+		return k.rawMoment(float64(j))
+	}
+	if central {
+		return centralFromRaw(n, raw)
+	}
+	return raw(n)
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (k Kumaraswamy) NormalizationCheck() float64 {
+	return normalization(k)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Kumaraswamy) NumParameters() int {
+	return 2
+}
+
+// Prob computes the value of the probability density function at x.
+func (k Kumaraswamy) Prob(x float64) float64 {
+	return math.Exp(k.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution,
+//  x = (1 - (1-p)^(1/B))^(1/A).
+// Quantile(0) is 0 and Quantile(1) is 1, the bounds of the support.
+func (k Kumaraswamy) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	return math.Pow(-math.Expm1(math.Log1p(-p)/k.B), 1/k.A)
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (k Kumaraswamy) Rand() float64 {
+	return k.Quantile(randFloat64(k.Source))
+}
+
+// rawMoment returns E[X^r] = B Γ(1+r/A) Γ(B) / Γ(1+B+r/A).
+func (k Kumaraswamy) rawMoment(r float64) float64 {
+	s := r / k.A
+	lg1, _ := math.Lgamma(1 + s)
+	lg2, _ := math.Lgamma(k.B)
+	lg3, _ := math.Lgamma(1 + k.B + s)
+	return k.B * math.Exp(lg1+lg2-lg3)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (k Kumaraswamy) StdDev() float64 {
+	return math.Sqrt(k.Variance())
+}
+
+// String implements the fmt.Stringer interface.
+func (k Kumaraswamy) String() string {
+	return formatParams(&k)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (k Kumaraswamy) Survival(x float64) float64 {
+	x = snapToSupport(x, 0, 1)
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 1
+	}
+	if x >= 1 {
+		return 0
+	}
+	return math.Exp(k.B * math.Log1p(-math.Pow(x, k.A)))
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface
+func (k *Kumaraswamy) UnmarshalParameters(p []Parameter) {
+	if len(p) != k.NumParameters() {
+		panic("kumaraswamy: incorrect number of parameters to set")
+	}
+	if p[0].Name != "A" {
+		panic("kumaraswamy: " + panicNameMismatch)
+	}
+	if p[1].Name != "B" {
+		panic("kumaraswamy: " + panicNameMismatch)
+	}
+	k.A = p[0].Value
+	k.B = p[1].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if A and B are positive and
+// finite.
+func (k Kumaraswamy) Validate() error {
+	return firstError(
+		checkPositive("kumaraswamy", "A", k.A),
+		checkPositive("kumaraswamy", "B", k.B),
+	)
+}
+
+// Variance returns the variance of the probability distribution.
+func (k Kumaraswamy) Variance() float64 {
+	m := k.rawMoment(1)
+	return k.rawMoment(2) - m*m
+}
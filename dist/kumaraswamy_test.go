@@ -0,0 +1,61 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestKumaraswamy(t *testing.T) {
+	for _, k := range []Kumaraswamy{
+		{A: 0.5, B: 0.5},
+		{A: 1, B: 1},
+		{A: 2, B: 5},
+		{A: 5, B: 2},
+	} {
+		for _, p := range []float64{0.001, 0.1, 0.5, 0.9, 0.999} {
+			x := k.Quantile(p)
+			if got := k.CDF(x); !equalRel(got, p, 1e-12) {
+				t.Errorf("CDF mismatch for %v at %v. Expected %v, Found %v", k, x, p, got)
+			}
+			// x rounds off the low digits of 1-x^A close to 1.
+			if got := k.Survival(x); !equalRel(got, 1-p, 1e-10) {
+				t.Errorf("Survival mismatch for %v at %v. Expected %v, Found %v", k, x, 1-p, got)
+			}
+		}
+		if got := k.CDF(k.Median()); !equalRel(got, 0.5, 1e-14) {
+			t.Errorf("Median mismatch for %v. CDF(Median) = %v", k, got)
+		}
+		for _, x := range []float64{0.1, 0.5, 0.9} {
+			if err := CheckDerivatives(&k, x, 1e-6); err != nil {
+				t.Errorf("%v: %v", k, err)
+			}
+		}
+	}
+	// With A = 1 and B = 1 the distribution is uniform on [0, 1].
+	u := Kumaraswamy{A: 1, B: 1}
+	for _, x := range []float64{0, 0.3, 1} {
+		if got := u.Prob(x); got != 1 {
+			t.Errorf("Prob mismatch for %v at %v. Expected 1, Found %v", u, x, got)
+		}
+	}
+	// With B = 1 the CDF is x^A.
+	if got, want := (Kumaraswamy{A: 3, B: 1}).CDF(0.5), 0.125; !equalRel(got, want, 1e-15) {
+		t.Errorf("CDF mismatch with B = 1. Expected %v, Found %v", want, got)
+	}
+}
+
+func TestKumaraswamyRand(t *testing.T) {
+	k := Kumaraswamy{A: 2, B: 5, Source: rand.New(rand.NewSource(1))}
+	x := make([]float64, 100000)
+	for i := range x {
+		x[i] = k.Rand()
+		if x[i] < 0 || x[i] > 1 {
+			t.Fatalf("Sample outside [0, 1]: %v", x[i])
+		}
+	}
+	checkMeanVariance(t, x, k.Mean(), k.Variance(), "Kumaraswamy")
+}
@@ -6,10 +6,7 @@ package dist
 
 import (
 	"math"
-	"math/rand"
-	"sort"
 
-	"github.com/gonum/floats"
 	"github.com/gonum/stat"
 )
 
@@ -17,7 +14,18 @@ import (
 type Laplace struct {
 	Mu     float64 // Mean of the Laplace distribution
 	Scale  float64 // Scale of the Laplace distribution
-	Source *rand.Rand
+	Source Source
+}
+
+// NewLaplace returns a Laplace distribution with mean mu and the given scale
+// that samples from src. NewLaplace returns an error if mu is not finite or
+// if scale is not positive and finite.
+func NewLaplace(mu, scale float64, src Source) (Laplace, error) {
+	l := Laplace{Mu: mu, Scale: scale, Source: src}
+	if err := l.Validate(); err != nil {
+		return Laplace{}, err
+	}
+	return l, nil
 }
 
 // CDF computes the value of the cumulative density function at x.
@@ -28,6 +36,31 @@ func (l Laplace) CDF(x float64) float64 {
 	return 1 - 0.5*math.Exp(-(x-l.Mu)/l.Scale)
 }
 
+// CDFSlice returns the value of the cumulative density function at each of
+// the values in xs. See CDFSliceTo.
+func (l Laplace) CDFSlice(xs []float64) []float64 {
+	return l.CDFSliceTo(make([]float64, len(xs)), xs)
+}
+
+// CDFSliceTo stores in dst the value of the cumulative density function at
+// each of the values in xs, and returns dst. The reciprocal of the
+// scale is computed once for the whole slice.
+// CDFSliceTo panics if len(dst) != len(xs).
+func (l Laplace) CDFSliceTo(dst, xs []float64) []float64 {
+	if len(dst) != len(xs) {
+		panic("dist: slice length mismatch")
+	}
+	invScale := 1 / l.Scale
+	for i, x := range xs {
+		if x < l.Mu {
+			dst[i] = 0.5 * math.Exp((x-l.Mu)*invScale)
+			continue
+		}
+		dst[i] = 1 - 0.5*math.Exp(-(x-l.Mu)*invScale)
+	}
+	return dst
+}
+
 // DLogProbDX returns the derivative of the log of the probability with
 // respect to the input x. Returns 0 if x == l.Mu.
 func (l Laplace) DLogProbDX(x float64) float64 {
@@ -62,7 +95,7 @@ func (l Laplace) DLogProbDParam(x float64, deriv []float64) {
 		deriv[0] = math.NaN()
 	}
 
-	deriv[1] = math.Abs(diff)/(l.Scale*l.Scale) - 0.5/(l.Scale)
+	deriv[1] = math.Abs(diff)/(l.Scale*l.Scale) - 1/l.Scale
 	return
 }
 
@@ -84,7 +117,7 @@ func (l Laplace) ExKurtosis() float64 {
 // Note: Laplace distribution has no FitPrior because it has no sufficient
 // statistics.
 func (l *Laplace) Fit(samples, weights []float64) {
-	if len(samples) != len(weights) {
+	if weights != nil && len(samples) != len(weights) {
 		panic("dist: length of samples and weights must match")
 	}
 
@@ -97,37 +130,41 @@ func (l *Laplace) Fit(samples, weights []float64) {
 		return
 	}
 
-	var (
-		sortedSamples []float64
-		sortedWeights []float64
-	)
-	if sort.Float64sAreSorted(samples) {
-		sortedSamples = samples
-		sortedWeights = weights
-	} else {
-		// Need to copy variables so the input variables aren't effected by the sorting
-		sortedSamples = make([]float64, len(samples))
-		copy(sortedSamples, samples)
-		sortedWeights := make([]float64, len(samples))
-		copy(sortedWeights, weights)
-
-		stat.SortWeighted(sortedSamples, sortedWeights)
-	}
-
 	// The (weighted) median of the samples is the maximum likelihood estimate
 	// of the mean parameter
-	// TODO: Rethink quantile type when stat has more options
-	l.Mu = stat.Quantile(0.5, stat.Empirical, sortedSamples, sortedWeights)
-
-	sumWeights := floats.Sum(weights)
+	l.Mu = stat.WeightedMedian(samples, weights)
 
 	// The scale parameter is the average absolute distance
 	// between the sample and the mean
-	absError := stat.Moment(1, samples, l.Mu, weights)
-
+	var absError, sumWeights float64
+	for i, x := range samples {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		absError += w * math.Abs(x-l.Mu)
+		sumWeights += w
+	}
 	l.Scale = absError / sumWeights
 }
 
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with n intervals.
+func (l Laplace) IntegrateProb(lo, hi float64, n int) float64 {
+	return integrateProb(l, lo, hi, n)
+}
+
+// InverseSurvival returns the inverse of the survival function.
+func (l Laplace) InverseSurvival(q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	if q < 0.5 {
+		return l.Mu - l.Scale*math.Log(2*q)
+	}
+	return l.Mu + l.Scale*math.Log(2*(1-q))
+}
+
 // LogProb computes the natural logarithm of the value of the probability density
 // function at x.
 func (l Laplace) LogProb(x float64) float64 {
@@ -161,14 +198,43 @@ func (l Laplace) Mode() float64 {
 	return l.Mu
 }
 
+// Moment returns the k-th raw moment E[X^k] of the distribution, or the k-th
+// central moment E[(X-μ)^k] if central is true. The moments are computed
+// in closed form: the central moments are 0 for odd k and k! Scale^k for even
+// k. Moment panics if k is negative.
+func (l Laplace) Moment(k int, central bool) float64 {
+	checkMomentOrder(k)
+	c := func(j int) float64 {
+		if j%2 != 0 {
+			return 0
+		}
+		m := 1.0
+		for i := 1; i <= j; i++ {
+			m *= float64(i) * l.Scale
+		}
+		return m
+	}
+	if central {
+		return c(k)
+	}
+	return rawFromCentral(k, l.Mu, c)
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (l Laplace) NormalizationCheck() float64 {
+	return normalization(l)
+}
+
 // NumParameters returns the number of parameters in the distribution.
 func (l Laplace) NumParameters() int {
 	return 2
 }
 
 // Quantile returns the inverse of the cumulative probability distribution.
+// Quantile(0) is -Inf and Quantile(1) is +Inf, the bounds of the support.
 func (l Laplace) Quantile(p float64) float64 {
-	if p < 0 || p > 1 {
+	if !(p >= 0 && p <= 1) {
 		panic("dist: percentile out of bounds")
 	}
 	if p < 0.5 {
@@ -182,14 +248,31 @@ func (l Laplace) Prob(x float64) float64 {
 	return math.Exp(l.LogProb(x))
 }
 
+// ProbSlice returns the value of the probability density function at each
+// of the values in xs. See ProbSliceTo.
+func (l Laplace) ProbSlice(xs []float64) []float64 {
+	return l.ProbSliceTo(make([]float64, len(xs)), xs)
+}
+
+// ProbSliceTo stores in dst the value of the probability density function at
+// each of the values in xs, and returns dst. The normalization constant
+// and the reciprocal of the scale are computed once for the whole slice.
+// ProbSliceTo panics if len(dst) != len(xs).
+func (l Laplace) ProbSliceTo(dst, xs []float64) []float64 {
+	if len(dst) != len(xs) {
+		panic("dist: slice length mismatch")
+	}
+	logNorm := -math.Ln2 - math.Log(l.Scale)
+	invScale := 1 / l.Scale
+	for i, x := range xs {
+		dst[i] = math.Exp(logNorm - math.Abs(x-l.Mu)*invScale)
+	}
+	return dst
+}
+
 // Rand returns a random sample drawn from the distribution.
 func (l Laplace) Rand() float64 {
-	var rnd float64
-	if l.Source == nil {
-		rnd = rand.Float64()
-	} else {
-		rnd = l.Source.Float64()
-	}
+	rnd := randFloat64(l.Source)
 	u := rnd - 0.5
 	if u < 0 {
 		return l.Mu + l.Scale*math.Log(1+2*u)
@@ -197,6 +280,12 @@ func (l Laplace) Rand() float64 {
 	return l.Mu - l.Scale*math.Log(1-2*u)
 }
 
+// RandAntithetic returns n samples drawn from the distribution in antithetic
+// pairs, as described for randAntithetic.
+func (l Laplace) RandAntithetic(n int) []float64 {
+	return randAntithetic(l, n, l.Source)
+}
+
 // Skewness returns the skewness of the distribution.
 func (Laplace) Skewness() float64 {
 	return 0
@@ -207,6 +296,21 @@ func (l Laplace) StdDev() float64 {
 	return math.Sqrt2 * l.Scale
 }
 
+// StratifiedSample returns n stratified samples from the distribution, as
+// described for stratifiedSample, drawing uniforms from src or, if src is nil,
+// from the Source of the distribution.
+func (l Laplace) StratifiedSample(n int, src Source) []float64 {
+	if src == nil {
+		src = l.Source
+	}
+	return stratifiedSample(l, n, src)
+}
+
+// String implements the fmt.Stringer interface.
+func (l Laplace) String() string {
+	return formatParams(&l)
+}
+
 // Survival returns the survival function (complementary CDF) at x.
 func (l Laplace) Survival(x float64) float64 {
 	if x < l.Mu {
@@ -230,6 +334,16 @@ func (l *Laplace) UnmarshalParameters(p []Parameter) {
 	l.Scale = p[1].Value
 }
 
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Mu is finite and Scale is
+// positive and finite.
+func (l Laplace) Validate() error {
+	return firstError(
+		checkFinite("laplace", "Mu", l.Mu),
+		checkPositive("laplace", "Scale", l.Scale),
+	)
+}
+
 // Variance returns the variance of the probability distribution.
 func (l Laplace) Variance() float64 {
 	return 2 * l.Scale * l.Scale
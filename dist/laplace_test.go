@@ -56,3 +56,22 @@ func TestLaplaceProb(t *testing.T) {
 	}
 	testDistributionProbs(t, Laplace{Mu: 0, Scale: 1}, "Laplace", pts)
 }
+
+func TestLaplaceFit(t *testing.T) {
+	for i, test := range []struct {
+		samples, weights []float64
+		mu, scale        float64
+	}{
+		{[]float64{3, 1, 2, 10}, nil, 2.5, 2.5},
+		{[]float64{3, 1, 2, 10}, []float64{1, 1, 4, 1}, 2, 10.0 / 7},
+	} {
+		var l Laplace
+		l.Fit(test.samples, test.weights)
+		if l.Mu != test.mu {
+			t.Errorf("Mu mismatch case %d. Expected %v, Found %v", i, test.mu, l.Mu)
+		}
+		if math.Abs(l.Scale-test.scale) > 1e-14 {
+			t.Errorf("Scale mismatch case %d. Expected %v, Found %v", i, test.scale, l.Scale)
+		}
+	}
+}
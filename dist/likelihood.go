@@ -0,0 +1,452 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// ParametricDist is a univariate distribution whose parameters can be
+// marshaled and whose log probability can be differentiated with respect to
+// those parameters.
+//
+// UnmarshalParameters typically has a pointer receiver, so a ParametricDist
+// is usually a pointer to a distribution.
+type ParametricDist interface {
+	ParameterMarshaler
+	NumParameters() int
+	LogProb(x float64) float64
+	DLogProbDParam(x float64, deriv []float64)
+}
+
+// derivStep is the relative step of central finite differences. The central
+// difference has truncation error O(h²) and rounding error O(ε/h), which are
+// balanced by h ∝ ε^(1/3).
+const derivStep = 6e-6
+
+// score stores in dst the derivative of the total weighted log-likelihood of
+// the samples with respect to the parameters of d.
+func score(d ParametricDist, samples, weights, dst []float64) {
+	for i := range dst {
+		dst[i] = 0
+	}
+	deriv := make([]float64, len(dst))
+	for i, x := range samples {
+		d.DLogProbDParam(x, deriv)
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		for j, v := range deriv {
+			dst[j] += w * v
+		}
+	}
+}
+
+// NumericalHessian returns the observed information of the samples, that is
+// the negative Hessian of the total weighted log-likelihood with respect to
+// the parameters of d, evaluated at the current parameters. The Hessian is
+// computed by central finite differences of the analytic derivative
+// DLogProbDParam.
+//
+// The parameters of d are modified during the computation and are restored
+// before NumericalHessian returns. If weights is nil, the weights are assumed
+// to be 1, otherwise NumericalHessian panics if len(samples) != len(weights).
+func NumericalHessian(d ParametricDist, samples, weights []float64) [][]float64 {
+	if weights != nil && len(samples) != len(weights) {
+		panic("dist: slice length mismatch")
+	}
+	n := d.NumParameters()
+	cols := make([]int, n)
+	for i := range cols {
+		cols[i] = i
+	}
+	hess := hessianColumns(d, samples, weights, cols)
+	// Enforce the symmetry of the exact Hessian.
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			v := (hess[i][j] + hess[j][i]) / 2
+			hess[i][j] = v
+			hess[j][i] = v
+		}
+	}
+	return hess
+}
+
+// hessianColumns returns the negative Hessian of the total weighted
+// log-likelihood with only the columns in cols computed. The other columns
+// are zero.
+func hessianColumns(d ParametricDist, samples, weights []float64, cols []int) [][]float64 {
+	n := d.NumParameters()
+	params := make([]Parameter, n)
+	d.MarshalParameters(params)
+	defer d.UnmarshalParameters(params)
+
+	work := make([]Parameter, n)
+	copy(work, params)
+
+	hess := make([][]float64, n)
+	for i := range hess {
+		hess[i] = make([]float64, n)
+	}
+	plus := make([]float64, n)
+	minus := make([]float64, n)
+	for _, j := range cols {
+		v := params[j].Value
+		h := derivStep * math.Max(1, math.Abs(v))
+		work[j].Value = v + h
+		d.UnmarshalParameters(work)
+		score(d, samples, weights, plus)
+		work[j].Value = v - h
+		d.UnmarshalParameters(work)
+		score(d, samples, weights, minus)
+		work[j].Value = v
+		for i := 0; i < n; i++ {
+			hess[i][j] = -(plus[i] - minus[i]) / (2 * h)
+		}
+	}
+	return hess
+}
+
+// logLikelihood returns the total weighted log-likelihood of the samples.
+// If weights is nil, the weights are assumed to be 1.
+func logLikelihood(d LogProber, samples, weights []float64) float64 {
+	var ll float64
+	for i, x := range samples {
+		if weights == nil {
+			ll += d.LogProb(x)
+			continue
+		}
+		ll += weights[i] * d.LogProb(x)
+	}
+	return ll
+}
+
+// LogLikelihoodOffset returns the total log-likelihood of the samples under d
+// as the offset, which depends only on the samples, and the log-likelihood ll
+// relative to it, so that the total is ll + offset. The offset is the
+// log-likelihood of the samples under the normal distribution with their mean
+// and maximum likelihood variance,
+//  offset = -n/2 (log(2π s²) + 1),
+// or 0 if there are fewer than two distinct samples. The raw log-likelihood
+// of a large dataset is a large negative number whose changes between
+// candidate parameters are small in relative terms; ll is instead of the order
+// of n times the divergence of d from the reference, and since the offset is
+// the same for any d, maximizing ll maximizes the log-likelihood. ll is
+// accumulated as the sum of LogProb(x_i) - offset/n, so that it does not lose
+// precision to the cancellation of two large totals.
+func LogLikelihoodOffset(d LogProber, samples []float64) (ll, offset float64) {
+	n := float64(len(samples))
+	if len(samples) > 1 {
+		var mean float64
+		for _, x := range samples {
+			mean += x
+		}
+		mean /= n
+		var ss float64
+		for _, x := range samples {
+			ss += (x - mean) * (x - mean)
+		}
+		if v := ss / n; v > 0 {
+			offset = -n / 2 * (math.Log(2*math.Pi*v) + 1)
+		}
+	}
+	each := 0.0
+	if n > 0 {
+		each = offset / n
+	}
+	for _, x := range samples {
+		ll += d.LogProb(x) - each
+	}
+	return ll, offset
+}
+
+// maximizeLikelihood sets the parameters of d that are not fixed to
+// maximize the weighted log-likelihood of the samples, and returns the
+// maximum log-likelihood. If fixed is nil, all of the parameters are free.
+// It is maximize with the analytic score and the Hessian from hessianColumns.
+func maximizeLikelihood(d ParametricDist, samples, weights []float64, fixed []bool) float64 {
+	return maximize(d, func() float64 {
+		return logLikelihood(d, samples, weights)
+	}, func(free []int, grad []float64, hess [][]float64) {
+		score(d, samples, weights, grad)
+		for i, row := range hessianColumns(d, samples, weights, free) {
+			copy(hess[i], row)
+		}
+	}, fixed)
+}
+
+// objectiveStep is the relative step of the finite differences used by
+// maximize when no analytic derivatives are given. The second differences of
+// the Hessian have rounding error O(ε/h²), which is balanced against the
+// truncation error O(h²) by h ∝ ε^(1/4).
+const objectiveStep = 1e-4
+
+// maximize sets the parameters of d that are not fixed to maximize f, which
+// evaluates an objective at the current parameters of d, and returns the
+// maximum. If fixed is nil, all of the parameters are free.
+//
+// If derivs is not nil, it stores in grad the gradient of f and in hess its
+// negative Hessian at the current parameters of d, setting at least the
+// entries of the free parameters, whose indices are in free, and leaves the
+// parameters of d unchanged. Otherwise the derivatives are computed by central
+// differences of f with relative step objectiveStep.
+//
+// The maximization is a damped Newton method starting from the current
+// parameters of d, with steps halved until f increases, so parameters at
+// which f is NaN are never accepted. Where f is not locally concave a scaled
+// gradient step is taken instead.
+func maximize(d interface {
+	ParameterMarshaler
+	NumParameters() int
+}, f func() float64, derivs func(free []int, grad []float64, hess [][]float64), fixed []bool) float64 {
+	const (
+		maxIter = 200
+		tol     = 1e-10
+	)
+	n := d.NumParameters()
+	var free []int
+	for i := 0; i < n; i++ {
+		if fixed == nil || !fixed[i] {
+			free = append(free, i)
+		}
+	}
+	val := f()
+	if len(free) == 0 {
+		return val
+	}
+	params := make([]Parameter, n)
+	d.MarshalParameters(params)
+	work := make([]Parameter, n)
+	// at returns f with the free parameters moved by delta from params.
+	at := func(delta []float64) float64 {
+		copy(work, params)
+		for r, i := range free {
+			work[i].Value += delta[r]
+		}
+		d.UnmarshalParameters(work)
+		return f()
+	}
+
+	m := len(free)
+	grad := make([]float64, n)
+	hess := make([][]float64, n)
+	for i := range hess {
+		hess[i] = make([]float64, n)
+	}
+	a := make([][]float64, m)
+	for i := range a {
+		a[i] = make([]float64, m)
+	}
+	b := make([]float64, m)
+	h := make([]float64, m)
+	delta := make([]float64, m)
+	for iter := 0; iter < maxIter; iter++ {
+		if derivs != nil {
+			d.UnmarshalParameters(params)
+			derivs(free, grad, hess)
+			for r, i := range free {
+				for c, j := range free {
+					a[r][c] = hess[i][j]
+				}
+				b[r] = grad[i]
+			}
+		} else {
+			for r, i := range free {
+				h[r] = objectiveStep * math.Max(1, math.Abs(params[i].Value))
+			}
+			for r := range free {
+				for k := range delta {
+					delta[k] = 0
+				}
+				delta[r] = h[r]
+				plus := at(delta)
+				delta[r] = -h[r]
+				minus := at(delta)
+				b[r] = (plus - minus) / (2 * h[r])
+				a[r][r] = -(plus - 2*val + minus) / (h[r] * h[r])
+				for c := 0; c < r; c++ {
+					delta[r], delta[c] = h[r], h[c]
+					pp := at(delta)
+					delta[c] = -h[c]
+					pm := at(delta)
+					delta[r] = -h[r]
+					mm := at(delta)
+					delta[c] = h[c]
+					mp := at(delta)
+					delta[c] = 0
+					a[r][c] = -(pp - pm - mp + mm) / (4 * h[r] * h[c])
+					a[c][r] = a[r][c]
+				}
+			}
+		}
+		step, ok := solve(a, b)
+		if !ok || dot(step, b) <= 0 {
+			step = make([]float64, m)
+			for r := range step {
+				step[r] = b[r] / math.Max(math.Abs(a[r][r]), 1e-8)
+			}
+		}
+
+		var valNew float64
+		improved := false
+		for t := 1.0; t > 1e-12; t /= 2 {
+			for r := range delta {
+				delta[r] = t * step[r]
+			}
+			valNew = at(delta)
+			if valNew >= val {
+				improved = true
+				break
+			}
+		}
+		if !improved {
+			d.UnmarshalParameters(params)
+			return val
+		}
+		converged := valNew-val <= tol*tol*math.Max(1, math.Abs(val))
+		for r, i := range free {
+			if math.Abs(delta[r]) > tol*math.Max(1, math.Abs(params[i].Value)) {
+				converged = false
+			}
+		}
+		copy(params, work)
+		val = valNew
+		if converged {
+			break
+		}
+	}
+	d.UnmarshalParameters(params)
+	return val
+}
+
+// solve returns the solution to the linear system a x = b computed by
+// Gaussian elimination with partial pivoting. The inputs are not modified.
+// solve returns false if a is singular.
+func solve(a [][]float64, b []float64) ([]float64, bool) {
+	n := len(b)
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, n+1)
+		copy(m[i], a[i])
+		m[i][n] = b[i]
+	}
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(m[r][col]) > math.Abs(m[pivot][col]) {
+				pivot = r
+			}
+		}
+		if m[pivot][col] == 0 || math.IsNaN(m[pivot][col]) {
+			return nil, false
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+		for r := col + 1; r < n; r++ {
+			f := m[r][col] / m[col][col]
+			for c := col; c <= n; c++ {
+				m[r][c] -= f * m[col][c]
+			}
+		}
+	}
+	x := make([]float64, n)
+	for r := n - 1; r >= 0; r-- {
+		s := m[r][n]
+		for c := r + 1; c < n; c++ {
+			s -= m[r][c] * x[c]
+		}
+		x[r] = s / m[r][r]
+	}
+	return x, true
+}
+
+func dot(a, b []float64) float64 {
+	var s float64
+	for i, v := range a {
+		s += v * b[i]
+	}
+	return s
+}
+
+// ProfileLikelihoodCI returns a confidence interval for the parameter of d
+// with index paramIndex, at the given confidence level, from the profile
+// likelihood of the samples. The profile log-likelihood maximizes over the
+// other parameters with the parameter of interest held fixed, and the interval
+// contains the values at which it is within χ²₁(level)/2 of the overall
+// maximum. Unlike the Wald interval, the profile interval need not be
+// symmetric about the estimate, and so is more accurate when the likelihood is
+// skewed, such as for small samples.
+//
+// The current parameters of d are used as the starting point of the
+// maximization, and on return d is set to the maximum likelihood estimate.
+// ProfileLikelihoodCI panics if paramIndex is out of range or if level is
+// not in (0, 1).
+func ProfileLikelihoodCI(d ParametricDist, samples []float64, paramIndex int, level float64) (lo, hi float64) {
+	n := d.NumParameters()
+	if paramIndex < 0 || paramIndex >= n {
+		panic("dist: parameter index out of range")
+	}
+	if !(level > 0 && level < 1) {
+		panic("dist: confidence level out of bounds")
+	}
+	llMax := maximizeLikelihood(d, samples, nil, nil)
+	mle := make([]Parameter, n)
+	d.MarshalParameters(mle)
+	defer d.UnmarshalParameters(mle)
+
+	z := zQuantile((1 + level) / 2)
+	target := llMax - z*z/2
+
+	// Use the Wald standard error as the scale of the search.
+	theta := mle[paramIndex].Value
+	unit := make([]float64, n)
+	unit[paramIndex] = 1
+	var se float64
+	if inv, ok := solve(NumericalHessian(d, samples, nil), unit); ok && inv[paramIndex] > 0 {
+		se = math.Sqrt(inv[paramIndex])
+	} else {
+		se = 0.1 * math.Max(1, math.Abs(theta))
+	}
+
+	fixed := make([]bool, n)
+	fixed[paramIndex] = true
+	work := make([]Parameter, n)
+	profile := func(v float64) float64 {
+		copy(work, mle)
+		work[paramIndex].Value = v
+		d.UnmarshalParameters(work)
+		return maximizeLikelihood(d, samples, nil, fixed) - target
+	}
+	return profileBound(profile, theta, -se), profileBound(profile, theta, se)
+}
+
+// profileBound returns the point where f crosses zero, searching from x0,
+// where f is positive, in the direction of step. Points where f is NaN, such
+// as outside the valid range of a parameter, are treated as negative.
+func profileBound(f func(float64) float64, x0, step float64) float64 {
+	inside := x0
+	outside := x0 + step
+	for i := 0; ; i++ {
+		if i == 60 {
+			return math.Inf(int(math.Copysign(1, step)))
+		}
+		if !(f(outside) > 0) {
+			break
+		}
+		inside = outside
+		step *= 2
+		outside = inside + step
+	}
+	for i := 0; i < 100; i++ {
+		if math.Abs(outside-inside) <= 1e-9*math.Max(1, math.Abs(inside)) {
+			break
+		}
+		mid := (inside + outside) / 2
+		if f(mid) > 0 {
+			inside = mid
+		} else {
+			outside = mid
+		}
+	}
+	return (inside + outside) / 2
+}
@@ -0,0 +1,224 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestNumericalHessianNormal(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	n := &Normal{Mu: 2, Sigma: 3}
+	samples := make([]float64, 10000)
+	weights := make([]float64, len(samples))
+	for i := range samples {
+		samples[i] = n.Mu + n.Sigma*src.NormFloat64()
+		weights[i] = src.Float64()
+	}
+
+	for _, w := range [][]float64{nil, weights} {
+		got := NumericalHessian(n, samples, w)
+		if n.Mu != 2 || n.Sigma != 3 {
+			t.Fatalf("Parameters not restored. Found %v", n)
+		}
+
+		// Analytic observed information of the normal distribution.
+		var want [2][2]float64
+		s2 := n.Sigma * n.Sigma
+		for i, x := range samples {
+			wt := 1.0
+			if w != nil {
+				wt = w[i]
+			}
+			d := x - n.Mu
+			want[0][0] += wt / s2
+			want[0][1] += wt * 2 * d / (s2 * n.Sigma)
+			want[1][1] += wt * (3*d*d/(s2*s2) - 1/s2)
+		}
+		want[1][0] = want[0][1]
+		for i := range want {
+			for j := range want[i] {
+				if math.Abs(got[i][j]-want[i][j]) > 1e-6*math.Max(1, math.Abs(want[i][j])) {
+					t.Errorf("Observed information mismatch at (%d, %d). Expected %v, Found %v", i, j, want[i][j], got[i][j])
+				}
+			}
+		}
+	}
+
+	// At the true parameters, the observed information is close to the Fisher
+	// information of the whole sample.
+	got := NumericalHessian(n, samples, nil)
+	fisher := [2][2]float64{
+		{float64(len(samples)) / (n.Sigma * n.Sigma), 0},
+		{0, 2 * float64(len(samples)) / (n.Sigma * n.Sigma)},
+	}
+	for i := range fisher {
+		for j := range fisher[i] {
+			if math.Abs(got[i][j]-fisher[i][j]) > 0.1*fisher[1][1] {
+				t.Errorf("Fisher information mismatch at (%d, %d). Expected %v, Found %v", i, j, fisher[i][j], got[i][j])
+			}
+		}
+	}
+}
+
+func TestNumericalHessianExponential(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	e := &Exponential{Rate: 4}
+	samples := make([]float64, 100)
+	for i := range samples {
+		samples[i] = src.ExpFloat64() / e.Rate
+	}
+	got := NumericalHessian(e, samples, nil)
+	// The observed information does not depend on the samples.
+	want := float64(len(samples)) / (e.Rate * e.Rate)
+	if math.Abs(got[0][0]-want) > 1e-6*want {
+		t.Errorf("Observed information mismatch. Expected %v, Found %v", want, got[0][0])
+	}
+}
+
+func TestNormalDLogProbDParam(t *testing.T) {
+	n := &Normal{Mu: 1.5, Sigma: 0.7}
+	deriv := make([]float64, 2)
+	for _, x := range []float64{-2, 0, 1.5, 3} {
+		n.DLogProbDParam(x, deriv)
+		h := 1e-6
+		wantMu := (Normal{Mu: n.Mu + h, Sigma: n.Sigma}.LogProb(x) - Normal{Mu: n.Mu - h, Sigma: n.Sigma}.LogProb(x)) / (2 * h)
+		wantSigma := (Normal{Mu: n.Mu, Sigma: n.Sigma + h}.LogProb(x) - Normal{Mu: n.Mu, Sigma: n.Sigma - h}.LogProb(x)) / (2 * h)
+		if math.Abs(deriv[0]-wantMu) > 1e-6 {
+			t.Errorf("∂LogProb/∂Mu mismatch at %v. Expected %v, Found %v", x, wantMu, deriv[0])
+		}
+		if math.Abs(deriv[1]-wantSigma) > 1e-6 {
+			t.Errorf("∂LogProb/∂Sigma mismatch at %v. Expected %v, Found %v", x, wantSigma, deriv[1])
+		}
+	}
+}
+
+func TestProfileLikelihoodCINormal(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	samples := make([]float64, 20)
+	for i := range samples {
+		samples[i] = 5 + 2*src.NormFloat64()
+	}
+	var mean, ss float64
+	for _, x := range samples {
+		mean += x
+	}
+	mean /= float64(len(samples))
+	for _, x := range samples {
+		ss += (x - mean) * (x - mean)
+	}
+	sigma := math.Sqrt(ss / float64(len(samples)))
+
+	// The profile log-likelihood of the mean is
+	//  -n/2 log(σ̂² + (μ-x̄)²) + const
+	// so the interval is known in closed form.
+	n := &Normal{Mu: 0, Sigma: 1}
+	lo, hi := ProfileLikelihoodCI(n, samples, 0, 0.95)
+	z := 1.959963984540054
+	half := sigma * math.Sqrt(math.Exp(z*z/float64(len(samples)))-1)
+	if math.Abs(lo-(mean-half)) > 1e-6 || math.Abs(hi-(mean+half)) > 1e-6 {
+		t.Errorf("Profile interval mismatch. Expected [%v, %v], Found [%v, %v]", mean-half, mean+half, lo, hi)
+	}
+	if math.Abs(n.Mu-mean) > 1e-8 || math.Abs(n.Sigma-sigma) > 1e-8 {
+		t.Errorf("Maximum likelihood estimate mismatch. Expected {%v %v}, Found {%v %v}", mean, sigma, n.Mu, n.Sigma)
+	}
+}
+
+func TestProfileLikelihoodCICoverage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping coverage simulation in short mode")
+	}
+	src := rand.New(rand.NewSource(1))
+	const reps = 400
+	for _, test := range []struct {
+		name     string
+		truth    float64
+		index    int
+		newDist  func() ParametricDist
+		generate func() float64
+		n        int
+	}{
+		{
+			name:     "Exponential rate",
+			truth:    2,
+			index:    0,
+			newDist:  func() ParametricDist { return &Exponential{Rate: 1} },
+			generate: func() float64 { return src.ExpFloat64() / 2 },
+			n:        8,
+		},
+		{
+			name:     "Weibull shape",
+			truth:    2,
+			index:    0,
+			newDist:  func() ParametricDist { return &Weibull{K: 1, Lambda: 3} },
+			generate: func() float64 { return 3 * math.Pow(src.ExpFloat64(), 0.5) },
+			n:        20,
+		},
+	} {
+		var covered, differ int
+		samples := make([]float64, test.n)
+		for r := 0; r < reps; r++ {
+			for i := range samples {
+				samples[i] = test.generate()
+			}
+			d := test.newDist()
+			lo, hi := ProfileLikelihoodCI(d, samples, test.index, 0.95)
+			if lo <= test.truth && test.truth <= hi {
+				covered++
+			}
+
+			// The Wald interval is symmetric about the estimate, but the
+			// likelihood for small samples is skewed.
+			params := make([]Parameter, d.NumParameters())
+			d.MarshalParameters(params)
+			est := params[test.index].Value
+			unit := make([]float64, len(params))
+			unit[test.index] = 1
+			inv, _ := solve(NumericalHessian(d, samples, nil), unit)
+			se := math.Sqrt(inv[test.index])
+			waldLo, waldHi := est-1.959963984540054*se, est+1.959963984540054*se
+			if hi-est > est-lo && math.Abs(lo-waldLo) > 0.01*se && math.Abs(hi-waldHi) > 0.01*se {
+				differ++
+			}
+		}
+		coverage := float64(covered) / reps
+		if coverage < 0.92 || coverage > 0.98 {
+			t.Errorf("%s: profile interval coverage %v is not close to 0.95", test.name, coverage)
+		}
+		if differ < reps*9/10 {
+			t.Errorf("%s: profile interval is not right-skewed relative to the Wald interval. Found %d of %d", test.name, differ, reps)
+		}
+	}
+}
+
+func TestLogLikelihoodOffset(t *testing.T) {
+	w := Weibull{K: 2, Lambda: 3, Source: rand.New(rand.NewSource(1))}
+	x := make([]float64, 100000)
+	for i := range x {
+		x[i] = w.Rand()
+	}
+	for _, d := range []LogProber{w, Weibull{K: 1.5, Lambda: 4}, Normal{Mu: 2.5, Sigma: 1.5}} {
+		ll, offset := LogLikelihoodOffset(d, x)
+		want := logLikelihood(d, x, nil)
+		if !equalRel(ll+offset, want, 1e-12) {
+			t.Errorf("LogLikelihoodOffset total mismatch for %v. Expected %v, Found %v", d, want, ll+offset)
+		}
+		// The relative log-likelihood is much smaller than the total.
+		if math.Abs(ll) > 0.1*math.Abs(want) {
+			t.Errorf("LogLikelihoodOffset not rescaled for %v. Total %v, Relative %v", d, want, ll)
+		}
+	}
+	// The offset depends only on the samples.
+	_, o1 := LogLikelihoodOffset(w, x)
+	_, o2 := LogLikelihoodOffset(Normal{Mu: 0, Sigma: 1}, x)
+	if o1 != o2 {
+		t.Errorf("LogLikelihoodOffset offset depends on the distribution. Found %v and %v", o1, o2)
+	}
+	if ll, offset := LogLikelihoodOffset(w, []float64{2}); offset != 0 || ll != w.LogProb(2) {
+		t.Errorf("LogLikelihoodOffset mismatch for a single sample. Expected %v and 0, Found %v and %v", w.LogProb(2), ll, offset)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// LocationScale represents the distribution of Loc + Scale*X for X
+// distributed as Base, so that any distribution can be shifted and scaled
+// without a separate parametrization. Scale must be positive.
+//
+// Each method other than Rand requires Base to implement the corresponding
+// method, for example CDFer for CDF, and panics otherwise.
+type LocationScale struct {
+	Base  Rander
+	Loc   float64
+	Scale float64
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (l LocationScale) CDF(x float64) float64 {
+	c, ok := l.Base.(CDFer)
+	if !ok {
+		panic("dist: location-scale base does not implement CDFer")
+	}
+	return c.CDF(l.standardize(x))
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (l LocationScale) LogProb(x float64) float64 {
+	lp, ok := l.Base.(LogProber)
+	if !ok {
+		panic("dist: location-scale base does not implement LogProber")
+	}
+	return lp.LogProb(l.standardize(x)) - math.Log(l.Scale)
+}
+
+// Prob computes the value of the probability density function at x.
+func (l LocationScale) Prob(x float64) float64 {
+	p, ok := l.Base.(Prober)
+	if !ok {
+		panic("dist: location-scale base does not implement Prober")
+	}
+	return p.Prob(l.standardize(x)) / l.Scale
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+func (l LocationScale) Quantile(p float64) float64 {
+	q, ok := l.Base.(Quantiler)
+	if !ok {
+		panic("dist: location-scale base does not implement Quantiler")
+	}
+	return l.Loc + l.Scale*q.Quantile(p)
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (l LocationScale) Rand() float64 {
+	return l.Loc + l.Scale*l.Base.Rand()
+}
+
+// standardize returns (x - Loc) / Scale.
+func (l LocationScale) standardize(x float64) float64 {
+	return (x - l.Loc) / l.Scale
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (l LocationScale) Survival(x float64) float64 {
+	s, ok := l.Base.(Survivaler)
+	if !ok {
+		panic("dist: location-scale base does not implement Survivaler")
+	}
+	return s.Survival(l.standardize(x))
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Loc is finite and Scale is
+// positive and finite. The parameters of Base are validated if it implements
+// Validator.
+func (l LocationScale) Validate() error {
+	err := firstError(
+		checkFinite("locationscale", "Loc", l.Loc),
+		checkPositive("locationscale", "Scale", l.Scale),
+	)
+	if err != nil {
+		return err
+	}
+	if v, ok := l.Base.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
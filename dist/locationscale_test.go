@@ -0,0 +1,60 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestLocationScaleNormal(t *testing.T) {
+	const mu, sigma = -2, 3
+	n := Normal{Mu: mu, Sigma: sigma}
+	l := LocationScale{Base: UnitNormal, Loc: mu, Scale: sigma}
+	if err := l.Validate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, x := range []float64{-20, -5, -2, 0, 1, 10} {
+		for _, m := range []struct {
+			name      string
+			got, want float64
+		}{
+			{"Prob", l.Prob(x), n.Prob(x)},
+			{"LogProb", l.LogProb(x), n.LogProb(x)},
+			{"CDF", l.CDF(x), n.CDF(x)},
+			{"Survival", l.Survival(x), n.Survival(x)},
+		} {
+			if !equalRel(m.got, m.want, 1e-13) {
+				t.Errorf("%s mismatch at %v. Expected %v, Found %v", m.name, x, m.want, m.got)
+			}
+		}
+	}
+	for _, p := range []float64{0.001, 0.1, 0.5, 0.9} {
+		if got, want := l.Quantile(p), n.Quantile(p); !equalRel(got, want, 1e-13) {
+			t.Errorf("Quantile mismatch at %v. Expected %v, Found %v", p, want, got)
+		}
+	}
+
+	l.Base = Normal{Mu: 0, Sigma: 1, Source: rand.New(rand.NewSource(1))}
+	x := make([]float64, 100000)
+	for i := range x {
+		x[i] = l.Rand()
+	}
+	checkMeanVariance(t, x, n.Mean(), n.Variance(), "Location-scale normal")
+
+	for _, bad := range []LocationScale{
+		{Base: UnitNormal, Loc: math.NaN(), Scale: 1},
+		{Base: UnitNormal, Loc: 0, Scale: 0},
+		{Base: Normal{Mu: 0, Sigma: -1}, Loc: 0, Scale: 1},
+	} {
+		if bad.Validate() == nil {
+			t.Errorf("Validate did not flag %+v", bad)
+		}
+	}
+	if !panics(func() { (LocationScale{Base: randOnly{}, Scale: 1}).CDF(0) }) {
+		t.Errorf("CDF did not panic for a base without a CDF")
+	}
+}
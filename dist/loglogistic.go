@@ -0,0 +1,257 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// LogLogistic represents the log-logistic distribution, also known as the
+// Fisk distribution, the distribution of a random variable whose logarithm
+// has a logistic distribution
+// (https://en.wikipedia.org/wiki/Log-logistic_distribution).
+// The cumulative distribution function is
+//  CDF(x) = 1 / (1 + (x/α)^(-β))
+// Valid range for x is (0,+∞). The log-logistic is the Burr distribution with
+// K = 1.
+type LogLogistic struct {
+	Alpha  float64 // Scale parameter, also the median
+	Beta   float64 // Shape parameter
+	Source Source
+}
+
+// NewLogLogistic returns a log-logistic distribution with scale alpha and
+// shape beta that samples from src. NewLogLogistic returns an error if alpha
+// or beta is not positive and finite.
+func NewLogLogistic(alpha, beta float64, src Source) (LogLogistic, error) {
+	l := LogLogistic{Alpha: alpha, Beta: beta, Source: src}
+	if err := l.Validate(); err != nil {
+		return LogLogistic{}, err
+	}
+	return l, nil
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (l LogLogistic) CDF(x float64) float64 {
+	x = snapNonNegative(x)
+	if x <= 0 {
+		return 0
+	}
+	return 1 / (1 + math.Exp(-l.Beta*math.Log(x/l.Alpha)))
+}
+
+// Hazard returns the hazard function Prob(x) / Survival(x) at x. For β > 1
+// the hazard increases from zero to a single maximum and then decreases.
+func (l LogLogistic) Hazard(x float64) float64 {
+	x = snapNonNegative(x)
+	if x < 0 {
+		return 0
+	}
+	z := x / l.Alpha
+	return l.Beta / l.Alpha * math.Pow(z, l.Beta-1) / (1 + math.Pow(z, l.Beta))
+}
+
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with n intervals.
+func (l LogLogistic) IntegrateProb(lo, hi float64, n int) float64 {
+	return integrateProb(l, lo, hi, n)
+}
+
+// InverseSurvival returns the inverse of the survival function.
+func (l LogLogistic) InverseSurvival(q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	return l.Alpha * math.Pow((1-q)/q, 1/l.Beta)
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (l LogLogistic) LogProb(x float64) float64 {
+	x = snapNonNegative(x)
+	if x < 0 {
+		return math.Inf(-1)
+	}
+	if math.IsInf(x, 1) {
+		return math.Inf(-1)
+	}
+	if x == 0 {
+		switch {
+		case l.Beta < 1:
+			return math.Inf(1)
+		case l.Beta == 1:
+			return -math.Log(l.Alpha)
+		}
+		return math.Inf(-1)
+	}
+	t := l.Beta * math.Log(x/l.Alpha)
+	// log(1 + e^t) computed without overflow for large t.
+	var softplus float64
+	if t > 0 {
+		softplus = t + math.Log1p(math.Exp(-t))
+	} else {
+		softplus = math.Log1p(math.Exp(t))
+	}
+	return math.Log(l.Beta/x) + t - 2*softplus
+}
+
+// MarshalParameters implements the ParameterMarshaler interface
+func (l LogLogistic) MarshalParameters(p []Parameter) {
+	if len(p) != l.NumParameters() {
+		panic("loglogistic: improper parameter length")
+	}
+	p[0].Name = "Alpha"
+	p[0].Value = l.Alpha
+	p[1].Name = "Beta"
+	p[1].Value = l.Beta
+	return
+}
+
+// Mean returns the mean of the probability distribution. The mean is +Inf
+// if β <= 1.
+func (l LogLogistic) Mean() float64 {
+	if l.Beta <= 1 {
+		return math.Inf(1)
+	}
+	b := math.Pi / l.Beta
+	return l.Alpha * b / math.Sin(b)
+}
+
+// Median returns the median of the probability distribution.
+func (l LogLogistic) Median() float64 {
+	return l.Alpha
+}
+
+// Mode returns the mode of the probability distribution.
+func (l LogLogistic) Mode() float64 {
+	if l.Beta <= 1 {
+		return 0
+	}
+	return l.Alpha * math.Pow((l.Beta-1)/(l.Beta+1), 1/l.Beta)
+}
+
+// Moment returns the k-th raw moment E[X^k] of the distribution, or the k-th
+// central moment E[(X-μ)^k] if central is true. The raw moments are
+// α^k (kπ/β) / sin(kπ/β) for k < β and +Inf otherwise, and the central
+// moments are computed from them. Moment panics if k is negative.
+func (l LogLogistic) Moment(k int, central bool) float64 {
+	checkMomentOrder(k)
+	if float64(k) >= l.Beta {
+		return math.Inf(1)
+	}
+	raw := func(j int) float64 {
+		if j == 0 {
+			return 1
+		}
+		b := float64(j) * math.Pi / l.Beta
+		return math.Pow(l.Alpha, float64(j)) * b / math.Sin(b)
+	}
+	if central {
+		return centralFromRaw(k, raw)
+	}
+	return raw(k)
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (l LogLogistic) NormalizationCheck() float64 {
+	return normalization(l)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (LogLogistic) NumParameters() int {
+	return 2
+}
+
+// Prob computes the value of the probability density function at x.
+func (l LogLogistic) Prob(x float64) float64 {
+	return math.Exp(l.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+// Quantile(0) is 0 and Quantile(1) is +Inf, the bounds of the support.
+func (l LogLogistic) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	return l.Alpha * math.Pow(p/(1-p), 1/l.Beta)
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (l LogLogistic) Rand() float64 {
+	return l.Quantile(randFloat64(l.Source))
+}
+
+// RandAntithetic returns n samples drawn from the distribution in antithetic
+// pairs, as described for randAntithetic.
+func (l LogLogistic) RandAntithetic(n int) []float64 {
+	return randAntithetic(l, n, l.Source)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (l LogLogistic) StdDev() float64 {
+	return math.Sqrt(l.Variance())
+}
+
+// StratifiedSample returns n stratified samples from the distribution, as
+// described for stratifiedSample, drawing uniforms from src or, if src is nil,
+// from the Source of the distribution.
+func (l LogLogistic) StratifiedSample(n int, src Source) []float64 {
+	if src == nil {
+		src = l.Source
+	}
+	return stratifiedSample(l, n, src)
+}
+
+// String implements the fmt.Stringer interface.
+func (l LogLogistic) String() string {
+	return formatParams(&l)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (l LogLogistic) Survival(x float64) float64 {
+	x = snapNonNegative(x)
+	if x <= 0 {
+		return 1
+	}
+	return 1 / (1 + math.Exp(l.Beta*math.Log(x/l.Alpha)))
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface
+func (l *LogLogistic) UnmarshalParameters(p []Parameter) {
+	if len(p) != l.NumParameters() {
+		panic("loglogistic: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Alpha" {
+		panic("loglogistic: " + panicNameMismatch)
+	}
+	if p[1].Name != "Beta" {
+		panic("loglogistic: " + panicNameMismatch)
+	}
+	l.Alpha = p[0].Value
+	l.Beta = p[1].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Alpha and Beta are positive
+// and finite.
+func (l LogLogistic) Validate() error {
+	return firstError(
+		checkPositive("loglogistic", "Alpha", l.Alpha),
+		checkPositive("loglogistic", "Beta", l.Beta),
+	)
+}
+
+// Variance returns the variance of the probability distribution. The variance
+// is +Inf if 1 < β <= 2 and NaN if β <= 1, where the mean is also infinite.
+func (l LogLogistic) Variance() float64 {
+	if l.Beta <= 1 {
+		return math.NaN()
+	}
+	if l.Beta <= 2 {
+		return math.Inf(1)
+	}
+	b := math.Pi / l.Beta
+	m := b / math.Sin(b)
+	return l.Alpha * l.Alpha * (2*b/math.Sin(2*b) - m*m)
+}
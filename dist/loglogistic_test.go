@@ -0,0 +1,111 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestLogLogisticBurr(t *testing.T) {
+	for _, l := range []LogLogistic{
+		{Alpha: 1, Beta: 0.5},
+		{Alpha: 2, Beta: 1},
+		{Alpha: 0.5, Beta: 4},
+	} {
+		b := Burr{C: l.Beta, K: 1, Lambda: l.Alpha}
+		for _, x := range []float64{0, 0.1, 0.5, 1, 2, 10, 1e6} {
+			if !equalRel(l.Prob(x), b.Prob(x), 1e-12) {
+				t.Errorf("Prob mismatch at %v for %#v. Expected %v, Found %v", x, l, b.Prob(x), l.Prob(x))
+			}
+			if !equalRel(l.CDF(x), b.CDF(x), 1e-12) {
+				t.Errorf("CDF mismatch at %v for %#v. Expected %v, Found %v", x, l, b.CDF(x), l.CDF(x))
+			}
+			if x > 0 {
+				if h := l.Prob(x) / l.Survival(x); !equalRel(l.Hazard(x), h, 1e-10) {
+					t.Errorf("Hazard mismatch at %v for %#v. Expected %v, Found %v", x, l, h, l.Hazard(x))
+				}
+			}
+		}
+	}
+}
+
+func TestLogLogisticQuantile(t *testing.T) {
+	for _, l := range []LogLogistic{
+		{Alpha: 1, Beta: 0.5},
+		{Alpha: 3, Beta: 2},
+		{Alpha: 0.1, Beta: 8},
+	} {
+		for _, p := range []float64{1e-10, 0.001, 0.1, 0.5, 0.9, 0.999, 1 - 1e-10} {
+			x := l.Quantile(p)
+			if cdf := l.CDF(x); !equalRel(cdf, p, 1e-10) {
+				t.Errorf("CDF(Quantile) mismatch for %#v at %v. Expected %v, Found %v", l, p, p, cdf)
+			}
+			if s := l.Survival(x); !equalRel(s, 1-p, 1e-6) {
+				t.Errorf("Survival(Quantile) mismatch for %#v at %v. Expected %v, Found %v", l, p, 1-p, s)
+			}
+		}
+		if m := l.Quantile(0.5); !equalRel(m, l.Median(), 1e-14) {
+			t.Errorf("Median mismatch for %#v. Expected %v, Found %v", l, l.Median(), m)
+		}
+	}
+}
+
+func TestLogLogisticHazard(t *testing.T) {
+	for _, beta := range []float64{1.5, 2, 5} {
+		l := LogLogistic{Alpha: 2, Beta: beta}
+		// The hazard peaks at x = α (β-1)^(1/β).
+		peak := l.Alpha * math.Pow(beta-1, 1/beta)
+		prev := 0.0
+		for x := peak / 50; x < 20*peak; x += peak / 50 {
+			h := l.Hazard(x)
+			if x <= peak && h < prev {
+				t.Errorf("Hazard for β = %v decreasing at %v before the peak at %v", beta, x, peak)
+			}
+			if x > peak+peak/50 && h > prev {
+				t.Errorf("Hazard for β = %v increasing at %v after the peak at %v", beta, x, peak)
+			}
+			prev = h
+		}
+	}
+	// For β <= 1 the hazard is monotone decreasing.
+	l := LogLogistic{Alpha: 1, Beta: 1}
+	for x := 0.1; x < 10; x += 0.1 {
+		if l.Hazard(x+0.1) > l.Hazard(x) {
+			t.Errorf("Hazard for β = 1 increasing at %v", x)
+		}
+	}
+}
+
+func TestLogLogisticMoments(t *testing.T) {
+	for _, l := range []LogLogistic{
+		{Alpha: 1, Beta: 5},
+		{Alpha: 2, Beta: 8},
+	} {
+		hi := l.Quantile(1 - 1e-13)
+		mean := integrate(func(x float64) float64 { return x * l.Prob(x) }, 0, hi, 200000)
+		variance := integrate(func(x float64) float64 { return (x - mean) * (x - mean) * l.Prob(x) }, 0, hi, 200000)
+		if !equalRel(l.Mean(), mean, 1e-4) {
+			t.Errorf("Mean mismatch for %#v. Expected %v, Found %v", l, mean, l.Mean())
+		}
+		if !equalRel(l.Variance(), variance, 1e-3) {
+			t.Errorf("Variance mismatch for %#v. Expected %v, Found %v", l, variance, l.Variance())
+		}
+
+		l.Source = rand.New(rand.NewSource(1))
+		x := make([]float64, 100000)
+		for i := range x {
+			x[i] = l.Rand()
+		}
+		checkMeanVariance(t, x, l.Mean(), l.Variance(), "LogLogistic")
+	}
+	if m := (LogLogistic{Alpha: 1, Beta: 1}).Mean(); !math.IsInf(m, 1) {
+		t.Errorf("Mean mismatch for β <= 1. Expected +Inf, Found %v", m)
+	}
+	if v := (LogLogistic{Alpha: 1, Beta: 2}).Variance(); !math.IsInf(v, 1) {
+		t.Errorf("Variance mismatch for β <= 2. Expected +Inf, Found %v", v)
+	}
+}
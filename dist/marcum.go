@@ -0,0 +1,64 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// marcumQ returns the first-order Marcum Q-function
+//  Q_1(a, b) = \int_b^∞ x exp(-(x^2+a^2)/2) I_0(ax) dx
+// for a, b >= 0.
+func marcumQ(a, b float64) float64 {
+	return marcumSum(a, b, true)
+}
+
+// marcumSum evaluates Q_1(a, b) if upper is true and 1 - Q_1(a, b) otherwise.
+// It uses the representation of Q_1 as a Poisson mixture of gamma tails,
+//  Q_1(a, b) = \sum_k e^{-a^2/2} (a^2/2)^k / k! Q(k+1, b^2/2),
+// where Q is the regularized upper incomplete gamma function. All terms are
+// positive, so both tails are computed with full relative accuracy.
+func marcumSum(a, b float64, upper bool) float64 {
+	if b <= 0 {
+		if upper {
+			return 1
+		}
+		return 0
+	}
+	if math.IsInf(b, 1) {
+		if upper {
+			return 0
+		}
+		return 1
+	}
+	m := 0.5 * a * a
+	y := 0.5 * b * b
+	if m == 0 {
+		if upper {
+			return math.Exp(-y)
+		}
+		return -math.Expm1(-y)
+	}
+	// The Poisson weights are negligible more than ten standard deviations
+	// from the mean.
+	spread := 10*math.Sqrt(m) + 20
+	kLo := int(math.Max(0, math.Floor(m-spread)))
+	kHi := int(math.Ceil(m + spread))
+	lm := math.Log(m)
+	var sum, wsum float64
+	for k := kLo; k <= kHi; k++ {
+		w := math.Exp(-m + float64(k)*lm - logFactorial(k))
+		if w == 0 {
+			continue
+		}
+		wsum += w
+		if upper {
+			sum += w * gammaIncRegComp(float64(k+1), y)
+		} else {
+			sum += w * gammaIncReg(float64(k+1), y)
+		}
+	}
+	// The weights sum to one in exact arithmetic. Normalizing removes the
+	// rounding error in the exponent, which grows with a.
+	return math.Min(sum/wsum, 1)
+}
@@ -0,0 +1,117 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// MetropolisHastings returns n samples from the density proportional to
+// exp(logTarget(x)) using a random-walk Metropolis-Hastings sampler with
+// normal proposals of standard deviation proposalStd. The chain starts at 0
+// and its first burnin states are discarded. A proposal is accepted with
+// probability
+//  min(1, exp(logTarget(x') - logTarget(x))),
+// so logTarget need only be known up to an additive constant, and may be
+// -Inf outside the support of the target. Proposals at which logTarget is
+// -Inf or NaN are always rejected. While logTarget is not finite at the
+// current state, as when 0 is outside the support or is a singularity of the
+// density, proposals outside the support are rejected; any proposal inside
+// it is accepted, since the acceptance probability above is then 1, so the
+// chain moves as soon as a proposal lands in the support. The support must
+// therefore contain points within a few multiples of proposalStd of 0.
+//
+// Successive samples are correlated, more strongly the further proposalStd
+// is from the scale of the target. MetropolisHastings panics if proposalStd
+// is not positive and finite or if n or burnin is negative.
+func MetropolisHastings(logTarget func(float64) float64, proposalStd float64, n, burnin int, src Source) []float64 {
+	if !(proposalStd > 0) || math.IsInf(proposalStd, 1) {
+		panic("dist: proposal standard deviation not positive and finite")
+	}
+	if n < 0 || burnin < 0 {
+		panic("dist: negative sample count")
+	}
+	step := Normal{Mu: 0, Sigma: proposalStd, Source: src}
+	var x float64
+	lp := logTarget(x)
+	samples := make([]float64, n)
+	for i := -burnin; i < n; i++ {
+		xNew := x + step.Rand()
+		lpNew := logTarget(xNew)
+		// The logarithm of a uniform variate is a negated exponential
+		// variate.
+		stuck := math.IsInf(lp, 0) || math.IsNaN(lp)
+		if lpNew > math.Inf(-1) && (stuck || lpNew >= lp || -randExpFloat64(src) < lpNew-lp) {
+			x, lp = xNew, lpNew
+		}
+		if i >= 0 {
+			samples[i] = x
+		}
+	}
+	return samples
+}
+
+// sliceMaxSteps is the largest number of steps of width w by which
+// SliceSample expands the interval around the current state.
+const sliceMaxSteps = 1000
+
+// SliceSample returns n samples from the density proportional to
+// exp(logTarget(x)) using Neal's slice sampler with stepping out and
+// shrinkage, starting from x0 (Neal, Slice Sampling, Annals of Statistics,
+// 2003). Each step draws a level uniformly below the density at the current
+// state, places an interval of width w at random around the state and expands
+// it in steps of w until both ends are below the level, then samples
+// uniformly from the interval, shrinking it towards the current state after
+// each rejection. The expansion is limited to sliceMaxSteps steps in total.
+//
+// Unlike MetropolisHastings every step moves the chain, and w only affects
+// the cost of a step rather than the correctness or, to first order, the
+// mixing, so it needs little tuning. A value near the width of the target is
+// efficient. logTarget need only be known up to an additive constant, and may
+// be -Inf outside the support. SliceSample panics if w is not positive and
+// finite, if n is negative, or if logTarget(x0) is not finite.
+func SliceSample(logTarget func(float64) float64, x0, w float64, n int, src Source) []float64 {
+	if !(w > 0) || math.IsInf(w, 1) {
+		panic("dist: slice width not positive and finite")
+	}
+	if n < 0 {
+		panic("dist: negative sample count")
+	}
+	x := x0
+	lp := logTarget(x)
+	if math.IsInf(lp, 0) || math.IsNaN(lp) {
+		panic("dist: log density not finite at the initial state")
+	}
+	samples := make([]float64, n)
+	for i := range samples {
+		// The logarithm of a uniform variate is a negated exponential
+		// variate.
+		level := lp - randExpFloat64(src)
+
+		lo := x - w*randFloat64(src)
+		hi := lo + w
+		left := int(sliceMaxSteps * randFloat64(src))
+		right := sliceMaxSteps - 1 - left
+		for ; left > 0 && logTarget(lo) > level; left-- {
+			lo -= w
+		}
+		for ; right > 0 && logTarget(hi) > level; right-- {
+			hi += w
+		}
+
+		for {
+			xNew := lo + (hi-lo)*randFloat64(src)
+			if lpNew := logTarget(xNew); lpNew > level {
+				x, lp = xNew, lpNew
+				break
+			}
+			if xNew < x {
+				lo = xNew
+			} else {
+				hi = xNew
+			}
+		}
+		samples[i] = x
+	}
+	return samples
+}
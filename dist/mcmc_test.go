@@ -0,0 +1,110 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// sampleMeanVariance returns the sample mean and unbiased sample variance
+// of x.
+func sampleMeanVariance(x []float64) (mean, variance float64) {
+	for _, v := range x {
+		mean += v
+	}
+	mean /= float64(len(x))
+	for _, v := range x {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, variance / float64(len(x)-1)
+}
+
+func TestMetropolisHastings(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		g           Gamma
+		proposalStd float64
+	}{
+		{Gamma{Alpha: 3, Beta: 2}, 1},
+		{Gamma{Alpha: 0.8, Beta: 0.5}, 4},
+		{Gamma{Alpha: 20, Beta: 1}, 5},
+	} {
+		x := MetropolisHastings(test.g.LogProb, test.proposalStd, 200000, 1000, src)
+		for _, v := range x {
+			if !(v >= 0) {
+				t.Fatalf("Sample outside the support of %v: %v", test.g, v)
+			}
+		}
+		// The samples are correlated, so the tolerances are wider than
+		// for independent samples.
+		mean, variance := sampleMeanVariance(x)
+		if math.Abs(mean-test.g.Mean()) > 0.03*test.g.Mean() {
+			t.Errorf("Sample mean mismatch for %v. Expected %v, Found %v", test.g, test.g.Mean(), mean)
+		}
+		if math.Abs(variance-test.g.Variance()) > 0.08*test.g.Variance() {
+			t.Errorf("Sample variance mismatch for %v. Expected %v, Found %v", test.g, test.g.Variance(), variance)
+		}
+	}
+	if len(MetropolisHastings(Normal{Mu: 0, Sigma: 1}.LogProb, 1, 0, 10, src)) != 0 {
+		t.Errorf("Expected no samples")
+	}
+	if !panics(func() { MetropolisHastings(Normal{Mu: 0, Sigma: 1}.LogProb, 0, 10, 0, src) }) {
+		t.Errorf("MetropolisHastings did not panic with zero proposal scale")
+	}
+	if !panics(func() { MetropolisHastings(Normal{Mu: 0, Sigma: 1}.LogProb, 1, 10, -1, src) }) {
+		t.Errorf("MetropolisHastings did not panic with negative burn-in")
+	}
+}
+
+func TestSliceSample(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	norm := Normal{Mu: 0, Sigma: 1}
+	for _, w := range []float64{0.1, 1, 10} {
+		x := SliceSample(norm.LogProb, 3, w, 100000, src)
+		mean, variance := sampleMeanVariance(x)
+		if math.Abs(mean) > 0.03 {
+			t.Errorf("Sample mean mismatch for w = %v. Expected 0, Found %v", w, mean)
+		}
+		if math.Abs(variance-1) > 0.03 {
+			t.Errorf("Sample variance mismatch for w = %v. Expected 1, Found %v", w, variance)
+		}
+		// Every step moves the chain a distance comparable to the
+		// width of the target, so the autocorrelation decays quickly.
+		for _, test := range []struct {
+			lag int
+			max float64
+		}{{1, 0.5}, {10, 0.05}} {
+			lag, max := test.lag, test.max
+			var c float64
+			for i := lag; i < len(x); i++ {
+				c += (x[i] - mean) * (x[i-lag] - mean)
+			}
+			c /= float64(len(x)-lag) * variance
+			if math.Abs(c) > max {
+				t.Errorf("Autocorrelation at lag %d too large for w = %v. Expected at most %v, Found %v", lag, w, max, c)
+			}
+		}
+	}
+
+	// A density with bounded support.
+	g := Gamma{Alpha: 0.8, Beta: 0.5}
+	x := SliceSample(g.LogProb, 1, 2, 100000, src)
+	mean, variance := sampleMeanVariance(x)
+	if math.Abs(mean-g.Mean()) > 0.03*g.Mean() {
+		t.Errorf("Sample mean mismatch for %v. Expected %v, Found %v", g, g.Mean(), mean)
+	}
+	if math.Abs(variance-g.Variance()) > 0.05*g.Variance() {
+		t.Errorf("Sample variance mismatch for %v. Expected %v, Found %v", g, g.Variance(), variance)
+	}
+
+	if !panics(func() { SliceSample(norm.LogProb, 0, 0, 10, src) }) {
+		t.Errorf("SliceSample did not panic with zero width")
+	}
+	if !panics(func() { SliceSample(g.LogProb, -1, 1, 10, src) }) {
+		t.Errorf("SliceSample did not panic with a start outside the support")
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// momentIntervals is the number of intervals used by the Moment methods that
+// integrate numerically.
+const momentIntervals = 4000
+
+// checkMomentOrder panics if the moment order k is negative.
+func checkMomentOrder(k int) {
+	if k < 0 {
+		panic("dist: negative moment order")
+	}
+}
+
+// binomial returns the binomial coefficient n choose k.
+func binomial(n, k int) float64 {
+	c := 1.0
+	for i := 1; i <= k; i++ {
+		c = c * float64(n-k+i) / float64(i)
+	}
+	return c
+}
+
+// centralFromRaw returns the k-th central moment from the raw moments
+// raw(j), j = 0, …, k, by expanding E[(X-μ)^k] binomially. The expansion
+// cancels when the mean is large relative to the spread.
+func centralFromRaw(k int, raw func(j int) float64) float64 {
+	mu := raw(1)
+	var m float64
+	for j := 0; j <= k; j++ {
+		m += binomial(k, j) * raw(j) * math.Pow(-mu, float64(k-j))
+	}
+	return m
+}
+
+// rawFromCentral returns the k-th raw moment from the mean mu and the
+// central moments central(j), j = 0, …, k, by expanding E[(μ+(X-μ))^k]
+// binomially.
+func rawFromCentral(k int, mu float64, central func(j int) float64) float64 {
+	var m float64
+	for j := 0; j <= k; j++ {
+		m += binomial(k, j) * central(j) * math.Pow(mu, float64(k-j))
+	}
+	return m
+}
+
+// numericalMoment returns the k-th raw moment of d, or the k-th central
+// moment if central is true, as the integral of x^k or (x-μ)^k times the
+// density over the support [Quantile(0), Quantile(1)], computed by
+// integrateDensity with momentIntervals intervals. The mean μ is itself
+// integrated numerically. The result is meaningless if the moment does not
+// exist.
+func numericalMoment(d interface {
+	Prober
+	Quantiler
+}, k int, central bool) float64 {
+	checkMomentOrder(k)
+	if k == 0 {
+		return 1
+	}
+	lo, hi := d.Quantile(0), d.Quantile(1)
+	var c float64
+	if central {
+		if k == 1 {
+			return 0
+		}
+		c = integrateDensity(d, func(x float64) float64 { return x }, lo, hi, momentIntervals)
+	}
+	p := float64(k)
+	return integrateDensity(d, func(x float64) float64 { return math.Pow(x-c, p) }, lo, hi, momentIntervals)
+}
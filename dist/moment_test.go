@@ -0,0 +1,104 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"testing"
+)
+
+type momenter interface {
+	Moment(k int, central bool) float64
+	Mean() float64
+	Variance() float64
+}
+
+func TestMoment(t *testing.T) {
+	for _, test := range []struct {
+		dist momenter
+		tol  float64
+	}{
+		{Normal{Mu: 1, Sigma: 2}, 1e-14},
+		{Exponential{Rate: 2}, 1e-14},
+		{Gamma{Alpha: 2.5, Beta: 3}, 1e-14},
+		{Erlang{K: 3, Lambda: 2}, 1e-14},
+		{Weibull{K: 2, Lambda: 3}, 1e-14},
+		{Uniform{Min: -1, Max: 3}, 1e-14},
+		{Laplace{Mu: 1, Scale: 2}, 1e-14},
+		{HalfNormal{Sigma: 2}, 1e-14},
+		{InverseGamma{Alpha: 5, Beta: 2}, 1e-14},
+		{Kumaraswamy{A: 2, B: 5}, 1e-14},
+		{Burr{C: 2, K: 3, Lambda: 1}, 1e-12},
+		{GeneralizedGamma{A: 1, D: 2, P: 1.5}, 1e-14},
+		{GeneralizedGamma{A: 2, D: 0.5, P: 0.5}, 1e-14},
+		{LogLogistic{Alpha: 2, Beta: 6}, 1e-14},
+		{LogLogistic{Alpha: 1, Beta: 3}, 1e-14},
+		// The moments are integrated numerically.
+		{GeneralizedExtremeValue{Mu: 1, Sigma: 2, Xi: 0.1}, 1e-8},
+		{GeneralizedExtremeValue{Mu: 1, Sigma: 2, Xi: -0.3}, 1e-8},
+		{GeneralizedPareto{Mu: 1, Sigma: 2, Xi: 0.1}, 1e-8},
+		{GeneralizedPareto{Mu: 1, Sigma: 2, Xi: 0.3}, 1e-8},
+		{TruncatedNormal{Mu: 0, Sigma: 1, Lower: -1, Upper: 2}, 1e-10},
+	} {
+		d := test.dist
+		for _, m := range []struct {
+			name      string
+			got, want float64
+		}{
+			{"Moment(0, false)", d.Moment(0, false), 1},
+			{"Moment(1, false)", d.Moment(1, false), d.Mean()},
+			{"Moment(1, true)", d.Moment(1, true), 0},
+			{"Moment(2, true)", d.Moment(2, true), d.Variance()},
+			{"Moment(2, false)", d.Moment(2, false), d.Variance() + d.Mean()*d.Mean()},
+		} {
+			if math.Abs(m.got-m.want) > test.tol*math.Max(1, math.Abs(m.want)) {
+				t.Errorf("%s mismatch for %v. Expected %v, Found %v", m.name, d, m.want, m.got)
+			}
+		}
+	}
+}
+
+func TestMomentHigher(t *testing.T) {
+	// The fourth central moment of the normal is 3σ⁴.
+	if got, want := (Normal{Mu: 1, Sigma: 2}).Moment(4, true), 48.0; !equalRel(got, want, 1e-15) {
+		t.Errorf("Normal fourth central moment mismatch. Expected %v, Found %v", want, got)
+	}
+	// The closed forms agree with the numerical integration.
+	for _, d := range []interface {
+		Prober
+		Quantiler
+		Moment(int, bool) float64
+	}{
+		Normal{Mu: 1, Sigma: 2},
+		Gamma{Alpha: 2.5, Beta: 3},
+		Weibull{K: 2, Lambda: 3},
+		Laplace{Mu: 1, Scale: 2},
+		HalfNormal{Sigma: 2},
+		InverseGamma{Alpha: 8, Beta: 2},
+		Kumaraswamy{A: 2, B: 5},
+		Uniform{Min: 1, Max: 3},
+		GeneralizedGamma{A: 2, D: 0.5, P: 0.5},
+		LogLogistic{Alpha: 1, Beta: 9},
+	} {
+		for k := 3; k <= 4; k++ {
+			for _, central := range []bool{false, true} {
+				got := d.Moment(k, central)
+				want := numericalMoment(d, k, central)
+				if math.Abs(got-want) > 1e-8*math.Max(1, math.Abs(want)) {
+					t.Errorf("Moment(%d, %t) mismatch for %v. Expected %v, Found %v", k, central, d, want, got)
+				}
+			}
+		}
+	}
+	if got := (InverseGamma{Alpha: 2, Beta: 1}).Moment(2, false); !math.IsInf(got, 1) {
+		t.Errorf("InverseGamma moment beyond the shape mismatch. Expected +Inf, Found %v", got)
+	}
+	if got := (LogLogistic{Alpha: 1, Beta: 3}).Moment(3, true); !math.IsInf(got, 1) {
+		t.Errorf("LogLogistic moment beyond the shape mismatch. Expected +Inf, Found %v", got)
+	}
+	if !panics(func() { (Normal{Mu: 0, Sigma: 1}).Moment(-1, false) }) {
+		t.Errorf("Moment did not panic with a negative order")
+	}
+}
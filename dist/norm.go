@@ -6,7 +6,6 @@ package dist
 
 import (
 	"math"
-	"math/rand"
 
 	"github.com/gonum/floats"
 	"github.com/gonum/stat"
@@ -19,17 +18,62 @@ var UnitNormal = Normal{Mu: 0, Sigma: 1}
 type Normal struct {
 	Mu     float64 // Mean of the normal distribution
 	Sigma  float64 // Standard deviation of the normal distribution
-	Source *rand.Rand
+	Source Source
 
 	// Needs to be Mu and Sigma and not Mean and StdDev because Normal has functions
 	// Mean and StdDev
 }
 
+// NewNormal returns a normal distribution with mean mu and standard deviation
+// sigma that samples from src. NewNormal returns an error if mu is not finite
+// or if sigma is not positive and finite.
+func NewNormal(mu, sigma float64, src Source) (Normal, error) {
+	n := Normal{Mu: mu, Sigma: sigma, Source: src}
+	if err := n.Validate(); err != nil {
+		return Normal{}, err
+	}
+	return n, nil
+}
+
 // CDF computes the value of the cumulative density function at x.
 func (n Normal) CDF(x float64) float64 {
 	return 0.5 * (1 + math.Erf((x-n.Mu)/(n.Sigma*math.Sqrt2)))
 }
 
+// CDFSlice returns the value of the cumulative density function at each of
+// the values in xs. See CDFSliceTo.
+func (n Normal) CDFSlice(xs []float64) []float64 {
+	return n.CDFSliceTo(make([]float64, len(xs)), xs)
+}
+
+// CDFSliceTo stores in dst the value of the cumulative density function at
+// each of the values in xs, and returns dst. The scaling of the inputs
+// is computed once for the whole slice.
+// CDFSliceTo panics if len(dst) != len(xs).
+func (n Normal) CDFSliceTo(dst, xs []float64) []float64 {
+	if len(dst) != len(xs) {
+		panic("dist: slice length mismatch")
+	}
+	scale := 1 / (n.Sigma * math.Sqrt2)
+	for i, x := range xs {
+		dst[i] = 0.5 * (1 + math.Erf((x-n.Mu)*scale))
+	}
+	return dst
+}
+
+// CGF returns the cumulant generating function K(s) = μs + σ²s²/2 and its
+// first and second derivatives with respect to s.
+func (n Normal) CGF(s float64) (k, dk, d2k float64) {
+	v := n.Sigma * n.Sigma
+	return n.Mu*s + 0.5*v*s*s, n.Mu + v*s, v
+}
+
+// CGFDomain returns the interval on which the cumulant generating function
+// is finite, which is the whole real line.
+func (n Normal) CGFDomain() (lo, hi float64) {
+	return math.Inf(-1), math.Inf(1)
+}
+
 // ConjugateUpdate updates the parameters of the distribution from the sufficient
 // statistics of a set of samples. The sufficient statistics, suffStat, have been
 // observed with nSamples observations. The prior values of the distribution are those
@@ -65,6 +109,22 @@ func (n *Normal) ConjugateUpdate(suffStat []float64, nSamples float64, priorStre
 	floats.AddConst(nSamples, priorStrength)
 }
 
+// Cumulants returns the first count cumulants κ_1, …, κ_count of the
+// distribution, which are μ, σ² and then zero.
+func (n Normal) Cumulants(count int) []float64 {
+	if count < 0 {
+		panic("dist: negative cumulant count")
+	}
+	k := make([]float64, count)
+	if count > 0 {
+		k[0] = n.Mu
+	}
+	if count > 1 {
+		k[1] = n.Sigma * n.Sigma
+	}
+	return k
+}
+
 // DLogProbDX computes the derivative of the log of the probability with respect
 // to the input x.
 func (n Normal) DLogProbDX(x float64) float64 {
@@ -81,8 +141,9 @@ func (n Normal) DLogProbDParam(x float64, deriv []float64) {
 		panic("dist: slice length mismatch")
 	}
 
-	deriv[0] = n.Mu * (x - n.Mu) / (n.Sigma * n.Sigma)
-	deriv[1] = 1 / n.Sigma * (-1 + (x-n.Mu)*(x-n.Mu)/2.0)
+	z := (x - n.Mu) / n.Sigma
+	deriv[0] = z / n.Sigma
+	deriv[1] = (z*z - 1) / n.Sigma
 
 	return
 }
@@ -106,11 +167,31 @@ func (n *Normal) Fit(samples []float64, weights []float64) {
 	n.ConjugateUpdate(suffStat, nSamples, []float64{0, 0})
 }
 
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with count intervals.
+func (n Normal) IntegrateProb(lo, hi float64, count int) float64 {
+	return integrateProb(n, lo, hi, count)
+}
+
+// InverseSurvival returns the inverse of the survival function.
+func (n Normal) InverseSurvival(q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	return n.Mu - n.Sigma*zQuantile(q)
+}
+
 // LogProb computes the natural logarithm of the value of the probability density function at x.
 func (n Normal) LogProb(x float64) float64 {
 	return negLogRoot2Pi - math.Log(n.Sigma) - (x-n.Mu)*(x-n.Mu)/(2*n.Sigma*n.Sigma)
 }
 
+// MGF returns the moment generating function E[exp(sX)] = exp(μs + σ²s²/2).
+func (n Normal) MGF(s float64) float64 {
+	k, _, _ := n.CGF(s)
+	return math.Exp(k)
+}
+
 // MarshalParameters implements the ParameterMarshaler interface
 func (n Normal) MarshalParameters(p []Parameter) {
 	nParam := n.NumParameters()
@@ -139,6 +220,34 @@ func (n Normal) Mode() float64 {
 	return n.Mu
 }
 
+// Moment returns the k-th raw moment E[X^k] of the distribution, or the k-th
+// central moment E[(X-μ)^k] if central is true. The moments are computed in
+// closed form: the central moments are 0 for odd k and σ^k (k-1)!! for even
+// k. Moment panics if k is negative.
+func (n Normal) Moment(k int, central bool) float64 {
+	checkMomentOrder(k)
+	c := func(j int) float64 {
+		if j%2 != 0 {
+			return 0
+		}
+		m := 1.0
+		for i := j - 1; i > 1; i -= 2 {
+			m *= float64(i)
+		}
+		return m * math.Pow(n.Sigma, float64(j))
+	}
+	if central {
+		return c(k)
+	}
+	return rawFromCentral(k, n.Mu, c)
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (n Normal) NormalizationCheck() float64 {
+	return normalization(n)
+}
+
 // NumParameters returns the number of parameters in the distribution.
 func (Normal) NumParameters() int {
 	return 2
@@ -153,9 +262,32 @@ func (n Normal) Prob(x float64) float64 {
 	return math.Exp(n.LogProb(x))
 }
 
+// ProbSlice returns the value of the probability density function at each
+// of the values in xs. See ProbSliceTo.
+func (n Normal) ProbSlice(xs []float64) []float64 {
+	return n.ProbSliceTo(make([]float64, len(xs)), xs)
+}
+
+// ProbSliceTo stores in dst the value of the probability density function at
+// each of the values in xs, and returns dst. The normalization constant
+// is computed once for the whole slice.
+// ProbSliceTo panics if len(dst) != len(xs).
+func (n Normal) ProbSliceTo(dst, xs []float64) []float64 {
+	if len(dst) != len(xs) {
+		panic("dist: slice length mismatch")
+	}
+	logNorm := negLogRoot2Pi - math.Log(n.Sigma)
+	scale := 1 / (2 * n.Sigma * n.Sigma)
+	for i, x := range xs {
+		dst[i] = math.Exp(logNorm - (x-n.Mu)*(x-n.Mu)*scale)
+	}
+	return dst
+}
+
 // Quantile returns the inverse of the cumulative probability distribution.
+// Quantile(0) is -Inf and Quantile(1) is +Inf, the bounds of the support.
 func (n Normal) Quantile(p float64) float64 {
-	if p < 0 || p > 1 {
+	if !(p >= 0 && p <= 1) {
 		panic("dist: percentile out of bounds")
 	}
 	return n.Mu + n.Sigma*zQuantile(p)
@@ -163,15 +295,23 @@ func (n Normal) Quantile(p float64) float64 {
 
 // Rand returns a random sample drawn from the distribution.
 func (n Normal) Rand() float64 {
-	var rnd float64
-	if n.Source == nil {
-		rnd = rand.NormFloat64()
-	} else {
-		rnd = n.Source.NormFloat64()
-	}
+	rnd := randNormFloat64(n.Source)
 	return rnd*n.Sigma + n.Mu
 }
 
+// RandAntithetic returns count samples drawn from the distribution in antithetic
+// pairs, as described for randAntithetic.
+func (n Normal) RandAntithetic(count int) []float64 {
+	return randAntithetic(n, count, n.Source)
+}
+
+// SaddlepointProb returns the saddlepoint approximation to the density at x.
+// See CGFer for details. For the normal distribution the approximation is
+// exact.
+func (n Normal) SaddlepointProb(x float64) float64 {
+	return saddlepointProb(n, x)
+}
+
 // Skewness returns the skewness of the distribution.
 func (Normal) Skewness() float64 {
 	return 0
@@ -182,6 +322,21 @@ func (n Normal) StdDev() float64 {
 	return n.Sigma
 }
 
+// StratifiedSample returns count stratified samples from the distribution, as
+// described for stratifiedSample, drawing uniforms from src or, if src is nil,
+// from the Source of the distribution.
+func (n Normal) StratifiedSample(count int, src Source) []float64 {
+	if src == nil {
+		src = n.Source
+	}
+	return stratifiedSample(n, count, src)
+}
+
+// String implements the fmt.Stringer interface.
+func (n Normal) String() string {
+	return formatParams(&n)
+}
+
 // SuffStat computes the sufficient statistics of a set of samples to update
 // the distribution. The sufficient statistics are stored in place, and the
 // effective number of samples are returned.
@@ -217,7 +372,7 @@ func (Normal) SuffStat(samples, weights, suffStat []float64) (nSamples float64)
 
 // Survival returns the survival function (complementary CDF) at x.
 func (n Normal) Survival(x float64) float64 {
-	return 0.5 * (1 - math.Erf((x-n.Mu)/(n.Sigma*math.Sqrt2)))
+	return 0.5 * math.Erfc((x-n.Mu)/(n.Sigma*math.Sqrt2))
 }
 
 // UnmarshalParameters implements the ParameterMarshaler interface
@@ -235,6 +390,16 @@ func (n *Normal) UnmarshalParameters(p []Parameter) {
 	n.Sigma = p[1].Value
 }
 
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Mu is finite and Sigma is
+// positive and finite.
+func (n Normal) Validate() error {
+	return firstError(
+		checkFinite("normal", "Mu", n.Mu),
+		checkPositive("normal", "Sigma", n.Sigma),
+	)
+}
+
 // Variance returns the variance of the probability distribution.
 func (n Normal) Variance() float64 {
 	return n.Sigma * n.Sigma
@@ -0,0 +1,84 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// Outliers returns the indices, in increasing order, of the samples whose
+// two-sided tail probability under d,
+//  2 min(CDF(x), 1 - CDF(x)),
+// is below alpha. If d also implements Survivaler, the upper tail is computed
+// with Survival to keep its precision far into the tail. The threshold
+// applies to each sample separately, so with n samples drawn from d about
+// alpha·n are flagged by chance; divide alpha by n for a Bonferroni
+// correction. Samples that are NaN are not flagged.
+//
+// Outliers panics if alpha is not in (0, 1].
+func Outliers(samples []float64, d CDFer, alpha float64) []int {
+	if !(alpha > 0 && alpha <= 1) {
+		panic("dist: significance level out of range")
+	}
+	s, hasSurvival := d.(Survivaler)
+	var idx []int
+	for i, x := range samples {
+		lower := d.CDF(x)
+		var upper float64
+		if hasSurvival {
+			upper = s.Survival(x)
+		} else {
+			upper = 1 - lower
+		}
+		if 2*math.Min(lower, upper) < alpha {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// GrubbsTest returns the index of the sample farthest from the sample mean,
+// the Grubbs statistic
+//  G = max |xᵢ - x̄| / s,
+// where s is the sample standard deviation, and the p-value of the two-sided
+// test that the farthest sample is an outlier of otherwise normal data. The
+// p-value is
+//  min(1, 2n P(T > t)),  t² = n(n-2)G² / ((n-1)² - nG²),
+// where T has Student's t distribution with n-2 degrees of freedom, which is
+// the Bonferroni bound used for Grubbs' critical values. If all samples are
+// equal, G is 0 and the p-value is 1. The test assumes the data are normal
+// and looks for a single outlier; repeated application after removing the
+// flagged sample loses power when there are several.
+//
+// GrubbsTest panics if there are fewer than three samples.
+func GrubbsTest(samples []float64) (index int, G, pValue float64) {
+	n := len(samples)
+	if n < 3 {
+		panic("dist: too few samples")
+	}
+	var mean float64
+	for _, x := range samples {
+		mean += x
+	}
+	mean /= float64(n)
+	var ss, dev float64
+	for i, x := range samples {
+		d := math.Abs(x - mean)
+		ss += d * d
+		if d > dev {
+			index, dev = i, d
+		}
+	}
+	if ss == 0 {
+		return 0, 0, 1
+	}
+	G = dev / math.Sqrt(ss/float64(n-1))
+	fn := float64(n)
+	den := (fn-1)*(fn-1) - fn*G*G
+	if den <= 0 {
+		// G is at its upper bound (n-1)/√n up to rounding.
+		return index, G, 0
+	}
+	t := math.Sqrt(fn * (fn - 2) * G * G / den)
+	return index, G, math.Min(1, 2*fn*tSurvival(t, fn-2))
+}
@@ -0,0 +1,80 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestOutliers(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	n := Normal{Mu: 2, Sigma: 3}
+	x := make([]float64, 200)
+	for i := range x {
+		// Clean samples within three standard deviations of the mean.
+		x[i] = math.Max(-7, math.Min(11, rnd.NormFloat64()*n.Sigma+n.Mu))
+	}
+	x[17] = 30
+	x[150] = -25
+	x[199] = math.NaN()
+	alpha := 0.01 / float64(len(x))
+	if got, want := Outliers(x, n, alpha), []int{17, 150}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Outliers mismatch. Expected %v, Found %v", want, got)
+	}
+	// Far in the upper tail 1-CDF is zero, which would flag 40, while
+	// Survival still resolves the tail probability.
+	e := Exponential{Rate: 1}
+	if got := Outliers([]float64{0.5, 40, 50}, e, 1e-20); !reflect.DeepEqual(got, []int{2}) {
+		t.Errorf("Outliers mismatch in the far tail. Expected [2], Found %v", got)
+	}
+	if got := Outliers([]float64{0.5, 1, 2}, e, 0.05); got != nil {
+		t.Errorf("Outliers flagged clean samples. Found %v", got)
+	}
+	if !panics(func() { Outliers(x, n, 0) }) {
+		t.Errorf("Outliers did not panic with zero significance level")
+	}
+}
+
+func TestGrubbsTest(t *testing.T) {
+	// The last sample is far from the rest. The statistic exceeds the critical
+	// value 2.127 at α = 0.05 for eight samples.
+	x := []float64{199.31, 199.53, 200.19, 200.82, 201.92, 201.95, 202.18, 245.57}
+	index, G, p := GrubbsTest(x)
+	if index != 7 {
+		t.Errorf("GrubbsTest index mismatch. Expected 7, Found %v", index)
+	}
+	if want := 2.468764611212452; !equalRel(G, want, 1e-12) {
+		t.Errorf("GrubbsTest statistic mismatch. Expected %v, Found %v", want, G)
+	}
+	if want := 3.0026386799164584e-07; !equalRel(p, want, 1e-8) {
+		t.Errorf("GrubbsTest p-value mismatch. Expected %v, Found %v", want, p)
+	}
+
+	// Clean normal data rarely have a significant outlier.
+	rnd := rand.New(rand.NewSource(1))
+	var rejected int
+	const trials = 200
+	for i := 0; i < trials; i++ {
+		for j := range x {
+			x[j] = rnd.NormFloat64()*2 + 5
+		}
+		if _, _, p := GrubbsTest(x); p < 0.05 {
+			rejected++
+		}
+	}
+	if rejected > trials/10 {
+		t.Errorf("GrubbsTest rejected clean data too often. Found %v of %v", rejected, trials)
+	}
+
+	if _, G, p := GrubbsTest([]float64{3, 3, 3}); G != 0 || p != 1 {
+		t.Errorf("GrubbsTest mismatch for equal samples. Expected 0 and 1, Found %v and %v", G, p)
+	}
+	if !panics(func() { GrubbsTest([]float64{1, 2}) }) {
+		t.Errorf("GrubbsTest did not panic with too few samples")
+	}
+}
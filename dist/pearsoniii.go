@@ -0,0 +1,243 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// PearsonIII represents the Pearson type III distribution, a gamma
+// distribution shifted to have mean Mu and standard deviation Sigma and
+// reflected if its skewness Gamma is negative
+// (https://en.wikipedia.org/wiki/Pearson_distribution).
+// The logarithm of flood discharges is commonly modeled as Pearson type III.
+//
+// The underlying gamma distribution has shape α = 4/Gamma^2 and scale
+// β = Sigma*|Gamma|/2, and is shifted to start at ξ = Mu - 2*Sigma/Gamma.
+// For Gamma > 0 the valid range for x is [ξ,+∞), and for Gamma < 0 it is
+// (-∞,ξ]. When Gamma is zero the distribution is the normal distribution.
+type PearsonIII struct {
+	Mu     float64 // Mean
+	Sigma  float64 // Standard deviation
+	Gamma  float64 // Skewness
+	Source Source
+}
+
+// NewPearsonIII returns a Pearson type III distribution with mean mu,
+// standard deviation sigma and skewness gamma that samples from src.
+// NewPearsonIII returns an error if mu or gamma is not finite, or if sigma is
+// not positive and finite.
+func NewPearsonIII(mu, sigma, gamma float64, src Source) (PearsonIII, error) {
+	p := PearsonIII{Mu: mu, Sigma: sigma, Gamma: gamma, Source: src}
+	if err := p.Validate(); err != nil {
+		return PearsonIII{}, err
+	}
+	return p, nil
+}
+
+// gammaParams returns the shape, scale and location of the underlying gamma
+// distribution.
+func (p PearsonIII) gammaParams() (alpha, beta, xi float64) {
+	alpha = 4 / (p.Gamma * p.Gamma)
+	beta = p.Sigma * math.Abs(p.Gamma) / 2
+	xi = p.Mu - 2*p.Sigma/p.Gamma
+	return alpha, beta, xi
+}
+
+// normal returns the normal distribution with the same mean and standard
+// deviation.
+func (p PearsonIII) normal() Normal {
+	return Normal{Mu: p.Mu, Sigma: p.Sigma}
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (p PearsonIII) CDF(x float64) float64 {
+	if p.Gamma == 0 {
+		return p.normal().CDF(x)
+	}
+	alpha, beta, xi := p.gammaParams()
+	if p.Gamma > 0 {
+		if x <= xi {
+			return 0
+		}
+		return gammaIncReg(alpha, (x-xi)/beta)
+	}
+	if x >= xi {
+		return 1
+	}
+	return gammaIncRegComp(alpha, (xi-x)/beta)
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution.
+func (p PearsonIII) ExKurtosis() float64 {
+	return 1.5 * p.Gamma * p.Gamma
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (p PearsonIII) LogProb(x float64) float64 {
+	if p.Gamma == 0 {
+		return p.normal().LogProb(x)
+	}
+	alpha, beta, xi := p.gammaParams()
+	z := (x - xi) / beta
+	if p.Gamma < 0 {
+		z = -z
+	}
+	if z < 0 || math.IsInf(z, 1) {
+		return math.Inf(-1)
+	}
+	if z == 0 {
+		switch {
+		case alpha < 1:
+			return math.Inf(1)
+		case alpha == 1:
+			return -math.Log(beta)
+		}
+		return math.Inf(-1)
+	}
+	lg, _ := math.Lgamma(alpha)
+	return (alpha-1)*math.Log(z) - z - lg - math.Log(beta)
+}
+
+// MarshalParameters implements the ParameterMarshaler interface
+func (p PearsonIII) MarshalParameters(params []Parameter) {
+	if len(params) != p.NumParameters() {
+		panic("pearsoniii: improper parameter length")
+	}
+	params[0].Name = "Mu"
+	params[0].Value = p.Mu
+	params[1].Name = "Sigma"
+	params[1].Value = p.Sigma
+	params[2].Name = "Gamma"
+	params[2].Value = p.Gamma
+	return
+}
+
+// Mean returns the mean of the probability distribution.
+func (p PearsonIII) Mean() float64 {
+	return p.Mu
+}
+
+// Mode returns the mode of the probability distribution.
+func (p PearsonIII) Mode() float64 {
+	if p.Gamma == 0 {
+		return p.Mu
+	}
+	alpha, beta, xi := p.gammaParams()
+	if alpha < 1 {
+		return xi
+	}
+	if p.Gamma > 0 {
+		return xi + beta*(alpha-1)
+	}
+	return xi - beta*(alpha-1)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (PearsonIII) NumParameters() int {
+	return 3
+}
+
+// Prob computes the value of the probability density function at x.
+func (p PearsonIII) Prob(x float64) float64 {
+	return math.Exp(p.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+// The inverse of the incomplete gamma function is found iteratively.
+// Quantile(0) and Quantile(1) are the bounds of the support.
+func (p PearsonIII) Quantile(prob float64) float64 {
+	if !(prob >= 0 && prob <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	if p.Gamma == 0 {
+		return p.normal().Quantile(prob)
+	}
+	alpha, beta, xi := p.gammaParams()
+	if p.Gamma > 0 {
+		return xi + beta*gammaIncRegInv(alpha, prob)
+	}
+	return xi - beta*gammaIncRegInv(alpha, 1-prob)
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (p PearsonIII) Rand() float64 {
+	if p.Gamma == 0 {
+		return p.Mu + p.Sigma*randNormFloat64(p.Source)
+	}
+	alpha, beta, xi := p.gammaParams()
+	g := beta * randGamma(alpha, p.Source)
+	if p.Gamma > 0 {
+		return xi + g
+	}
+	return xi - g
+}
+
+// Skewness returns the skewness of the distribution.
+func (p PearsonIII) Skewness() float64 {
+	return p.Gamma
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (p PearsonIII) StdDev() float64 {
+	return p.Sigma
+}
+
+// String implements the fmt.Stringer interface.
+func (p PearsonIII) String() string {
+	return formatParams(&p)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (p PearsonIII) Survival(x float64) float64 {
+	if p.Gamma == 0 {
+		return p.normal().Survival(x)
+	}
+	alpha, beta, xi := p.gammaParams()
+	if p.Gamma > 0 {
+		if x <= xi {
+			return 1
+		}
+		return gammaIncRegComp(alpha, (x-xi)/beta)
+	}
+	if x >= xi {
+		return 0
+	}
+	return gammaIncReg(alpha, (xi-x)/beta)
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface
+func (p *PearsonIII) UnmarshalParameters(params []Parameter) {
+	if len(params) != p.NumParameters() {
+		panic("pearsoniii: incorrect number of parameters to set")
+	}
+	if params[0].Name != "Mu" {
+		panic("pearsoniii: " + panicNameMismatch)
+	}
+	if params[1].Name != "Sigma" {
+		panic("pearsoniii: " + panicNameMismatch)
+	}
+	if params[2].Name != "Gamma" {
+		panic("pearsoniii: " + panicNameMismatch)
+	}
+	p.Mu = params[0].Value
+	p.Sigma = params[1].Value
+	p.Gamma = params[2].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Mu and Gamma are finite and
+// Sigma is positive and finite.
+func (p PearsonIII) Validate() error {
+	return firstError(
+		checkFinite("pearsoniii", "Mu", p.Mu),
+		checkPositive("pearsoniii", "Sigma", p.Sigma),
+		checkFinite("pearsoniii", "Gamma", p.Gamma),
+	)
+}
+
+// Variance returns the variance of the probability distribution.
+func (p PearsonIII) Variance() float64 {
+	return p.Sigma * p.Sigma
+}
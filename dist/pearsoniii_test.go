@@ -0,0 +1,88 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPearsonIIINormal(t *testing.T) {
+	p := PearsonIII{Mu: 1, Sigma: 2, Gamma: 0}
+	n := Normal{Mu: 1, Sigma: 2}
+	for _, x := range []float64{-5, -1, 0, 1, 2.5, 7} {
+		if p.Prob(x) != n.Prob(x) {
+			t.Errorf("Prob mismatch at %v. Expected %v, Found %v", x, n.Prob(x), p.Prob(x))
+		}
+		if p.CDF(x) != n.CDF(x) {
+			t.Errorf("CDF mismatch at %v. Expected %v, Found %v", x, n.CDF(x), p.CDF(x))
+		}
+	}
+	for _, q := range []float64{0.01, 0.5, 0.9} {
+		if p.Quantile(q) != n.Quantile(q) {
+			t.Errorf("Quantile mismatch at %v. Expected %v, Found %v", q, n.Quantile(q), p.Quantile(q))
+		}
+	}
+
+	// A small skewness is close to the normal distribution.
+	p.Gamma = 1e-3
+	for _, x := range []float64{-3, 0, 1, 2, 5} {
+		if math.Abs(p.CDF(x)-n.CDF(x)) > 1e-3 {
+			t.Errorf("CDF mismatch at %v for small skewness. Expected %v, Found %v", x, n.CDF(x), p.CDF(x))
+		}
+	}
+}
+
+func TestPearsonIIIMoments(t *testing.T) {
+	for _, p := range []PearsonIII{
+		{Mu: 0, Sigma: 1, Gamma: 0.5},
+		{Mu: 2, Sigma: 0.5, Gamma: 1.5},
+		{Mu: -1, Sigma: 2, Gamma: -0.8},
+		{Mu: 3, Sigma: 1, Gamma: 1},
+	} {
+		lo, hi := p.Quantile(1e-12), p.Quantile(1-1e-12)
+		if total := integrate(p.Prob, lo, hi, 200000); math.Abs(total-1) > 1e-8 {
+			t.Errorf("Density of %#v does not integrate to 1. Found %v", p, total)
+		}
+		mean := integrate(func(x float64) float64 { return x * p.Prob(x) }, lo, hi, 200000)
+		m2 := integrate(func(x float64) float64 { return math.Pow(x-mean, 2) * p.Prob(x) }, lo, hi, 200000)
+		m3 := integrate(func(x float64) float64 { return math.Pow(x-mean, 3) * p.Prob(x) }, lo, hi, 200000)
+		if math.Abs(mean-p.Mean()) > 1e-7 {
+			t.Errorf("Mean mismatch for %#v. Expected %v, Found %v", p, mean, p.Mean())
+		}
+		if math.Abs(m2-p.Variance()) > 1e-6 {
+			t.Errorf("Variance mismatch for %#v. Expected %v, Found %v", p, m2, p.Variance())
+		}
+		if skew := m3 / math.Pow(m2, 1.5); math.Abs(skew-p.Gamma) > 1e-5 {
+			t.Errorf("Skewness mismatch for %#v. Expected %v, Found %v", p, p.Gamma, skew)
+		}
+
+		for _, q := range []float64{0.001, 0.1, 0.5, 0.9, 0.999} {
+			x := p.Quantile(q)
+			if c := p.CDF(x); math.Abs(c-q) > 1e-8 {
+				t.Errorf("CDF(Quantile) mismatch for %#v at %v. Expected %v, Found %v", p, q, q, c)
+			}
+			if s := p.Survival(x); math.Abs(s-(1-q)) > 1e-8 {
+				t.Errorf("Survival(Quantile) mismatch for %#v at %v. Expected %v, Found %v", p, q, 1-q, s)
+			}
+		}
+
+		// Reflecting the skewness mirrors the distribution.
+		r := PearsonIII{Mu: -p.Mu, Sigma: p.Sigma, Gamma: -p.Gamma}
+		for _, x := range []float64{lo, p.Mu, hi} {
+			if !equalRel(p.Prob(x), r.Prob(-x), 1e-12) {
+				t.Errorf("Reflected Prob mismatch for %#v at %v. Expected %v, Found %v", p, x, p.Prob(x), r.Prob(-x))
+			}
+		}
+
+		p.Source = rand.New(rand.NewSource(1))
+		x := make([]float64, 100000)
+		for i := range x {
+			x[i] = p.Rand()
+		}
+		checkMeanVariance(t, x, p.Mean(), p.Variance(), "PearsonIII")
+	}
+}
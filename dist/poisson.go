@@ -0,0 +1,249 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// Poisson represents the Poisson distribution (https://en.wikipedia.org/wiki/Poisson_distribution).
+// Valid range for x is the non-negative integers.
+type Poisson struct {
+	// Lambda is the rate parameter and the mean of the distribution.
+	// Valid range is (0,+∞).
+	Lambda float64
+	// Source of random numbers
+	Source Source
+}
+
+// NewPoisson returns a Poisson distribution with rate lambda that samples
+// from src. NewPoisson returns an error if lambda is not positive and finite.
+func NewPoisson(lambda float64, src Source) (Poisson, error) {
+	p := Poisson{Lambda: lambda, Source: src}
+	if err := p.Validate(); err != nil {
+		return Poisson{}, err
+	}
+	return p, nil
+}
+
+// CDF computes the value of the cumulative density function at x,
+//  P(X ≤ x) = Q(⌊x⌋+1, λ),
+// the regularized upper incomplete gamma function.
+func (p Poisson) CDF(x float64) float64 {
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 {
+		return 0
+	}
+	if math.IsInf(x, 1) {
+		return 1
+	}
+	return gammaIncRegComp(math.Floor(x)+1, p.Lambda)
+}
+
+// CGF returns the cumulant generating function K(s) = λ(e^s - 1) and its
+// first and second derivatives with respect to s.
+func (p Poisson) CGF(s float64) (k, dk, d2k float64) {
+	e := p.Lambda * math.Exp(s)
+	return p.Lambda * math.Expm1(s), e, e
+}
+
+// CGFDomain returns the interval on which the cumulant generating function
+// is finite, which is the whole real line.
+func (p Poisson) CGFDomain() (lo, hi float64) {
+	return math.Inf(-1), math.Inf(1)
+}
+
+// Cumulants returns the first n cumulants κ_1, …, κ_n of the distribution,
+// which are all λ.
+func (p Poisson) Cumulants(n int) []float64 {
+	if n < 0 {
+		panic("dist: negative cumulant count")
+	}
+	k := make([]float64, n)
+	for i := range k {
+		k[i] = p.Lambda
+	}
+	return k
+}
+
+// DLogProbDParam returns the derivative of the log of the probability with
+// respect to the parameters of the distribution. The deriv slice must have length
+// equal to the number of parameters of the distribution.
+//
+// The order is ∂LogProb / ∂Lambda. The derivative is 0 at x outside the
+// support.
+func (p Poisson) DLogProbDParam(x float64, deriv []float64) {
+	if len(deriv) != p.NumParameters() {
+		panic("poisson: slice length mismatch")
+	}
+	if x < 0 || math.Floor(x) != x || math.IsInf(x, 1) {
+		deriv[0] = 0
+		return
+	}
+	deriv[0] = x/p.Lambda - 1
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution.
+func (p Poisson) ExKurtosis() float64 {
+	return 1 / p.Lambda
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x. LogProb returns -Inf if x is negative or is not an
+// integer.
+//
+// The factorial term is computed in log space, so LogProb remains finite for
+// large x.
+func (p Poisson) LogProb(x float64) float64 {
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 || math.Floor(x) != x || math.IsInf(x, 1) {
+		return math.Inf(-1)
+	}
+	return p.logProbInt(int(x))
+}
+
+// logProbInt returns the log probability of the integer k ≥ 0.
+func (p Poisson) logProbInt(k int) float64 {
+	return float64(k)*math.Log(p.Lambda) - p.Lambda - logFactorial(k)
+}
+
+// MGF returns the moment generating function E[exp(sX)] = exp(λ(e^s - 1)).
+func (p Poisson) MGF(s float64) float64 {
+	k, _, _ := p.CGF(s)
+	return math.Exp(k)
+}
+
+// MarshalParameters implements the ParameterMarshaler interface.
+func (p Poisson) MarshalParameters(params []Parameter) {
+	if len(params) != p.NumParameters() {
+		panic("poisson: improper parameter length")
+	}
+	params[0].Name = "Lambda"
+	params[0].Value = p.Lambda
+	return
+}
+
+// Mean returns the mean of the probability distribution.
+func (p Poisson) Mean() float64 {
+	return p.Lambda
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Poisson) NumParameters() int {
+	return 1
+}
+
+// Prob computes the value of the probability density function at x.
+func (p Poisson) Prob(x float64) float64 {
+	return math.Exp(p.LogProb(x))
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (p Poisson) Rand() float64 {
+	if p.Lambda < 10 {
+		// Knuth's multiplication method.
+		limit := math.Exp(-p.Lambda)
+		k := 0
+		prod := randFloat64(p.Source)
+		for prod > limit {
+			k++
+			prod *= randFloat64(p.Source)
+		}
+		return float64(k)
+	}
+
+	// Transformed rejection with squeeze (PTRS), from
+	// W. Hörmann, "The transformed rejection method for generating Poisson
+	// random variables", Insurance: Mathematics and Economics 12 (1993).
+	slam := math.Sqrt(p.Lambda)
+	logLambda := math.Log(p.Lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := randFloat64(p.Source) - 0.5
+		v := randFloat64(p.Source)
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + p.Lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return k
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		if math.Log(v)+math.Log(invAlpha)-math.Log(a/(us*us)+b) <= -p.Lambda+k*logLambda-logFactorial(int(k)) {
+			return k
+		}
+	}
+}
+
+// SaddlepointProb returns the saddlepoint approximation to the probability
+// mass at positive x. See CGFer for details. The approximation is Prob with
+// the factorial replaced by Stirling's formula.
+func (p Poisson) SaddlepointProb(x float64) float64 {
+	return saddlepointProb(p, x)
+}
+
+// Skewness returns the skewness of the distribution.
+func (p Poisson) Skewness() float64 {
+	return 1 / math.Sqrt(p.Lambda)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (p Poisson) StdDev() float64 {
+	return math.Sqrt(p.Lambda)
+}
+
+// String implements the fmt.Stringer interface.
+func (p Poisson) String() string {
+	return formatParams(&p)
+}
+
+// SupportRange returns the bounds of the support, 0 and +Inf.
+func (p Poisson) SupportRange() (lo, hi float64) {
+	return 0, math.Inf(1)
+}
+
+// Survival returns the survival function (complementary CDF) at x,
+//  P(X > x) = P(⌊x⌋+1, λ),
+// the regularized lower incomplete gamma function, which keeps its relative
+// precision in the upper tail.
+func (p Poisson) Survival(x float64) float64 {
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 {
+		return 1
+	}
+	if math.IsInf(x, 1) {
+		return 0
+	}
+	return gammaIncReg(math.Floor(x)+1, p.Lambda)
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface.
+func (p *Poisson) UnmarshalParameters(params []Parameter) {
+	if len(params) != p.NumParameters() {
+		panic("poisson: incorrect number of parameters to set")
+	}
+	if !isLambda(params[0].Name) {
+		panic("poisson: " + panicNameMismatch)
+	}
+	p.Lambda = params[0].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Lambda is positive and finite.
+func (p Poisson) Validate() error {
+	return checkPositive("poisson", "Lambda", p.Lambda)
+}
+
+// Variance returns the variance of the probability distribution.
+func (p Poisson) Variance() float64 {
+	return p.Lambda
+}
@@ -0,0 +1,111 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPoissonLogProb(t *testing.T) {
+	for _, test := range []struct {
+		lambda, x, want float64
+	}{
+		{2.5, 4, -2.0128909028513253},
+		{1000, 1000, -4.372899506026442},
+		{1000, 1500, -112.77326644455025},
+		{3000, 2500, -49.02710288708113},
+		{2.5, -1, math.Inf(-1)},
+		{2.5, 1.5, math.Inf(-1)},
+	} {
+		p := Poisson{Lambda: test.lambda}
+		got := p.LogProb(test.x)
+		if math.IsInf(test.want, -1) {
+			if !math.IsInf(got, -1) {
+				t.Errorf("LogProb mismatch for λ = %v, x = %v. Expected -Inf, Found %v", test.lambda, test.x, got)
+			}
+			continue
+		}
+		if math.IsInf(got, 0) || math.IsNaN(got) {
+			t.Errorf("LogProb not finite for λ = %v, x = %v. Found %v", test.lambda, test.x, got)
+			continue
+		}
+		if math.Abs(got-test.want) > 1e-9*math.Max(1, math.Abs(test.want)) {
+			t.Errorf("LogProb mismatch for λ = %v, x = %v. Expected %v, Found %v", test.lambda, test.x, test.want, got)
+		}
+	}
+}
+
+func TestPoissonCDF(t *testing.T) {
+	for _, lambda := range []float64{0.5, 4, 100} {
+		p := Poisson{Lambda: lambda}
+		cdf := p.CDF(lambda + 20*math.Sqrt(lambda) + 20)
+		if math.Abs(cdf-1) > 1e-12 {
+			t.Errorf("CDF does not reach 1 for λ = %v. Found %v", lambda, cdf)
+		}
+		if p.CDF(3.7) != p.CDF(3) {
+			t.Errorf("CDF not constant between integers for λ = %v", lambda)
+		}
+		var want float64
+		for k := 0; k <= 10; k++ {
+			want += p.Prob(float64(k))
+			if got := p.CDF(float64(k)); !equalRel(got, want, 1e-12) {
+				t.Errorf("CDF mismatch for λ = %v at %d. Expected %v, Found %v", lambda, k, want, got)
+			}
+		}
+		// The survival function keeps its precision far in the upper tail.
+		x := math.Floor(lambda + 30*math.Sqrt(lambda) + 30)
+		want = 0
+		for k := x + 1; k < x+200; k++ {
+			want += p.Prob(k)
+		}
+		if got := p.Survival(x); !equalRel(got, want, 1e-10) {
+			t.Errorf("Survival mismatch for λ = %v at %v. Expected %v, Found %v", lambda, x, want, got)
+		}
+		for _, x := range []float64{1e20, math.MaxFloat64} {
+			if got := p.CDF(x); got != 1 {
+				t.Errorf("CDF mismatch for λ = %v at %v. Expected 1, Found %v", lambda, x, got)
+			}
+			if got := p.Survival(x); got != 0 {
+				t.Errorf("Survival mismatch for λ = %v at %v. Expected 0, Found %v", lambda, x, got)
+			}
+		}
+	}
+}
+
+func TestPoissonRand(t *testing.T) {
+	for _, lambda := range []float64{0.5, 4, 30, 1000} {
+		p := Poisson{Lambda: lambda, Source: rand.New(rand.NewSource(1))}
+		n := 100000
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = p.Rand()
+		}
+		checkMeanVariance(t, x, p.Mean(), p.Variance(), "Poisson")
+	}
+}
+
+// checkMeanVariance checks that the sample mean and variance of x match
+// the expected values to within a few standard errors.
+func checkMeanVariance(t *testing.T, x []float64, mean, variance float64, name string) {
+	var m float64
+	for _, v := range x {
+		m += v
+	}
+	n := float64(len(x))
+	m /= n
+	var s float64
+	for _, v := range x {
+		s += (v - m) * (v - m)
+	}
+	s /= n - 1
+	if math.Abs(m-mean) > 5*math.Sqrt(variance/n) {
+		t.Errorf("Sample mean mismatch for %v. Expected %v, Found %v", name, mean, m)
+	}
+	if math.Abs(s-variance) > 0.05*variance {
+		t.Errorf("Sample variance mismatch for %v. Expected %v, Found %v", name, variance, s)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "testing"
+
+func TestSampleSizeForMeanTest(t *testing.T) {
+	// Sample sizes for the exact one-sample t test at α = 0.05 and power 0.8,
+	// as given by R's power.t.test(type = "one.sample").
+	for _, test := range []struct {
+		effect, sigma float64
+		want          int
+	}{
+		{0.2, 1, 199},
+		{0.5, 1, 34},
+		{1, 1, 10},
+		{-1.5, 3, 34},
+		// Large effects reach the t quantiles at one to three degrees of
+		// freedom, where these are the sizes given by the formula.
+		{3, 1, 4},
+		{10, 1, 2},
+	} {
+		if got := SampleSizeForMeanTest(test.effect, test.sigma, 0.05, 0.8); got != test.want {
+			t.Errorf("SampleSizeForMeanTest mismatch for effect %v and sigma %v. Expected %v, Found %v", test.effect, test.sigma, test.want, got)
+		}
+	}
+	// More power needs more samples.
+	if SampleSizeForMeanTest(0.5, 1, 0.05, 0.9) <= SampleSizeForMeanTest(0.5, 1, 0.05, 0.8) {
+		t.Errorf("SampleSizeForMeanTest did not grow with power")
+	}
+	for _, f := range []func(){
+		func() { SampleSizeForMeanTest(0, 1, 0.05, 0.8) },
+		func() { SampleSizeForMeanTest(1, 0, 0.05, 0.8) },
+		func() { SampleSizeForMeanTest(1, 1, 1, 0.8) },
+		func() { SampleSizeForMeanTest(1, 1, 0.05, 0) },
+	} {
+		if !panics(f) {
+			t.Errorf("SampleSizeForMeanTest did not panic with invalid arguments")
+		}
+	}
+}
+
+func TestTQuantile(t *testing.T) {
+	// Values for one and two degrees of freedom are from the closed forms
+	// tan(π(p-1/2)) and (2p-1)/√(2p(1-p)).
+	for _, test := range []struct {
+		p, nu, want float64
+	}{
+		{0.975, 1, 12.706204736174696},
+		{0.99, 1, 31.820515953773853},
+		{0.75, 1, 1},
+		{0.975, 2, 4.302652729749461},
+		{0.99, 2, 6.964556734283271},
+		{0.25, 2, -0.816496580927726},
+		{0.975, 5, 2.570581835636314},
+		{0.995, 5, 4.032142983557536},
+		{0.975, 10, 2.228138851986274},
+		{0.95, 5, 2.0150483733330242},
+		{0.8, 30, 0.8537672614712972},
+	} {
+		if got := tQuantile(test.p, test.nu); !equalRel(got, test.want, 1e-10) {
+			t.Errorf("tQuantile mismatch at p = %v, ν = %v. Expected %v, Found %v", test.p, test.nu, test.want, got)
+		}
+		if got := tSurvival(tQuantile(test.p, test.nu), test.nu); !equalRel(got, 1-test.p, 1e-10) {
+			t.Errorf("tQuantile not the inverse of tSurvival at p = %v, ν = %v. Found %v", test.p, test.nu, 1-got)
+		}
+	}
+}
+
+func TestTSurvival(t *testing.T) {
+	// Values from the closed forms for integer degrees of freedom,
+	// Abramowitz and Stegun 26.7.3 and 26.7.4.
+	for _, test := range []struct {
+		t, nu, want float64
+	}{
+		{0.5, 1, 0.35241638234956674},
+		{1.5, 3, 0.11529193262241144},
+		{2, 6, 0.04621315576583751},
+		{3, 10, 0.006671827511284811},
+		{4.2, 25, 0.00014800194946507084},
+		{0, 7, 0.5},
+	} {
+		if got := tSurvival(test.t, test.nu); !equalRel(got, test.want, 1e-12) {
+			t.Errorf("tSurvival mismatch for t = %v and nu = %v. Expected %v, Found %v", test.t, test.nu, test.want, got)
+		}
+		if got, want := tSurvival(-test.t, test.nu), 1-test.want; !equalRel(got, want, 1e-12) {
+			t.Errorf("tSurvival mismatch for t = %v and nu = %v. Expected %v, Found %v", -test.t, test.nu, want, got)
+		}
+	}
+}
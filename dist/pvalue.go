@@ -0,0 +1,59 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// CombinePValues returns the p-value of the joint null hypothesis of k
+// independent tests with the given p-values by Fisher's method. The statistic
+//  X = -2 Σ ln pᵢ
+// has a chi-squared distribution with 2k degrees of freedom under the null,
+// and the combined p-value is its upper tail probability Q(k, X/2), the
+// regularized upper incomplete gamma function. A p-value of 0 gives a
+// combined p-value of 0.
+//
+// CombinePValues panics if pvals is empty or a p-value is not in [0, 1].
+func CombinePValues(pvals []float64) (combined float64) {
+	checkPValues(pvals)
+	var x float64
+	for _, p := range pvals {
+		x -= 2 * math.Log(p)
+	}
+	return gammaIncRegComp(float64(len(pvals)), x/2)
+}
+
+// CombinePValuesStouffer returns the p-value of the joint null hypothesis of
+// k independent one-sided tests with the given p-values by Stouffer's Z
+// method. Each p-value is converted to the standard normal deviate
+// zᵢ = Φ⁻¹(1-pᵢ), and the combined p-value is the upper tail probability of
+//  Z = Σ zᵢ / √k,
+// which is standard normal under the null. Unlike Fisher's method, which is
+// dominated by the smallest p-value, evidence against the null in one test
+// can be offset by another test favouring it. The result is NaN if the
+// p-values include both 0 and 1.
+//
+// CombinePValuesStouffer panics if pvals is empty or a p-value is not in
+// [0, 1].
+func CombinePValuesStouffer(pvals []float64) (combined float64) {
+	checkPValues(pvals)
+	var z float64
+	for _, p := range pvals {
+		z -= zQuantile(p)
+	}
+	z /= math.Sqrt(float64(len(pvals)))
+	return 0.5 * math.Erfc(z/math.Sqrt2)
+}
+
+// checkPValues panics if pvals is empty or contains a value outside [0, 1].
+func checkPValues(pvals []float64) {
+	if len(pvals) == 0 {
+		panic("dist: no p-values")
+	}
+	for _, p := range pvals {
+		if !(p >= 0 && p <= 1) {
+			panic("dist: p-value out of range")
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "testing"
+
+func TestCombinePValues(t *testing.T) {
+	// For even degrees of freedom the chi-squared survival function is
+	// e^(-X/2) Σ_{j<k} (X/2)^j / j!, which gives the expected values.
+	for _, test := range []struct {
+		pvals            []float64
+		fisher, stouffer float64
+	}{
+		{[]float64{0.01, 0.2, 0.3}, 0.021561751324834653, 0.016512032608962945},
+		{[]float64{0.1, 0.1, 0.1, 0.1}, 0.018284495516258596, 0.005187061403669979},
+		{[]float64{0.3, 0.3, 0.3, 0.3, 0.3}, 0.2824064515103883, 0.12047908883522229},
+		{[]float64{0.4}, 0.4, 0.4},
+	} {
+		if got := CombinePValues(test.pvals); !equalRel(got, test.fisher, 1e-12) {
+			t.Errorf("CombinePValues mismatch for %v. Expected %v, Found %v", test.pvals, test.fisher, got)
+		}
+		if got := CombinePValuesStouffer(test.pvals); !equalRel(got, test.stouffer, 1e-12) {
+			t.Errorf("CombinePValuesStouffer mismatch for %v. Expected %v, Found %v", test.pvals, test.stouffer, got)
+		}
+	}
+	// Agreement between independent tests strengthens the evidence.
+	for _, combine := range []func([]float64) float64{CombinePValues, CombinePValuesStouffer} {
+		if got := combine([]float64{0.2, 0.2, 0.2}); !(got < 0.2) {
+			t.Errorf("Combined p-value of identical p-values not smaller. Found %v", got)
+		}
+	}
+	if got := CombinePValues([]float64{0, 0.5}); got != 0 {
+		t.Errorf("CombinePValues mismatch with a zero p-value. Expected 0, Found %v", got)
+	}
+	for _, pvals := range [][]float64{nil, {0.5, 1.5}, {-0.1}} {
+		if !panics(func() { CombinePValues(pvals) }) {
+			t.Errorf("CombinePValues did not panic for %v", pvals)
+		}
+		if !panics(func() { CombinePValuesStouffer(pvals) }) {
+			t.Errorf("CombinePValuesStouffer did not panic for %v", pvals)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "sort"
+
+// probWeightedMoments returns the unbiased estimates b_0, …, b_{n-1} of the
+// probability-weighted moments β_r = E[X F(X)^r] from the samples,
+//  b_r = 1/m \sum_{j=1}^m x_(j) (j-1)(j-2)…(j-r) / ((m-1)(m-2)…(m-r))
+// where x_(1) <= … <= x_(m) are the sorted samples (Landwehr et al., 1979).
+// Unlike estimates based on a plotting position such as (j-0.35)/m, these are
+// unbiased for every sample size. The samples are not modified.
+func probWeightedMoments(samples []float64, n int) []float64 {
+	m := len(samples)
+	if m < n {
+		panic("dist: too few samples for probability-weighted moments")
+	}
+	x := make([]float64, m)
+	copy(x, samples)
+	sort.Float64s(x)
+	b := make([]float64, n)
+	for j, v := range x {
+		w := 1.0
+		for r := 0; r < n; r++ {
+			if r > 0 {
+				w *= float64(j-r+1) / float64(m-r)
+			}
+			b[r] += w * v
+		}
+	}
+	for r := range b {
+		b[r] /= float64(m)
+	}
+	return b
+}
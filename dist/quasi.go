@@ -0,0 +1,149 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+// QuasiSequence is a low-discrepancy sequence of numbers in [0,1). Successive
+// values fill the unit interval more evenly than pseudo-random numbers.
+type QuasiSequence interface {
+	Next() float64
+}
+
+// QuasiRander generates quasi-random samples from a distribution by mapping a
+// low-discrepancy sequence through the quantile function of the distribution.
+// Averages over quasi-random samples typically converge faster than averages
+// over pseudo-random samples, which is useful for numerical integration.
+// The samples are not independent, so QuasiRander should not be used where
+// statistical randomness is required.
+type QuasiRander struct {
+	seq  QuasiSequence
+	dist Quantiler
+}
+
+// NewQuasiRander returns a QuasiRander sampling from dist using the points of
+// seq.
+func NewQuasiRander(seq QuasiSequence, dist Quantiler) *QuasiRander {
+	return &QuasiRander{seq: seq, dist: dist}
+}
+
+// Rand returns the next quasi-random sample from the distribution. Points of
+// the sequence equal to zero are skipped, as they map to the lower bound of
+// the support.
+func (q *QuasiRander) Rand() float64 {
+	p := q.seq.Next()
+	for p == 0 {
+		p = q.seq.Next()
+	}
+	return q.dist.Quantile(p)
+}
+
+// Halton generates one coordinate of the Halton sequence, the van der Corput
+// sequence in the given base. The sequence starts at 0. Coordinates of a
+// multi-dimensional Halton sequence use distinct prime bases.
+type Halton struct {
+	base int
+	n    int
+}
+
+// NewHalton returns a Halton sequence in the given base. NewHalton panics if
+// base is less than 2.
+func NewHalton(base int) *Halton {
+	if base < 2 {
+		panic("halton: base must be at least 2")
+	}
+	return &Halton{base: base}
+}
+
+// Next returns the next point of the sequence, the radical inverse of the
+// index of the point in the base of the sequence.
+func (h *Halton) Next() float64 {
+	var r float64
+	f := 1 / float64(h.base)
+	for i := h.n; i > 0; i /= h.base {
+		r += f * float64(i%h.base)
+		f /= float64(h.base)
+	}
+	h.n++
+	return r
+}
+
+// sobolDirections holds the degree s, polynomial coefficients a and initial
+// direction numbers m of the primitive polynomials for dimensions 2 and up of
+// the Sobol sequence, from
+//  S. Joe and F. Y. Kuo, "Constructing Sobol sequences with better
+//  two-dimensional projections", SIAM J. Sci. Comput. 30 (2008).
+var sobolDirections = []struct {
+	s, a int
+	m    []uint32
+}{
+	{1, 0, []uint32{1}},
+	{2, 1, []uint32{1, 3}},
+	{3, 1, []uint32{1, 3, 1}},
+	{3, 2, []uint32{1, 1, 1}},
+	{4, 1, []uint32{1, 1, 3, 3}},
+	{4, 4, []uint32{1, 3, 5, 13}},
+	{5, 2, []uint32{1, 1, 5, 5, 17}},
+	{5, 4, []uint32{1, 1, 5, 5, 5}},
+	{5, 7, []uint32{1, 1, 7, 11, 19}},
+}
+
+// SobolMaxDim is the largest dimension supported by NewSobol.
+const SobolMaxDim = 10
+
+// Sobol generates one coordinate of the Sobol sequence. The sequence starts
+// at 0, and the first 2^m points place exactly one point in each interval
+// [j/2^m, (j+1)/2^m).
+type Sobol struct {
+	v [32]uint32
+	x uint32
+	n uint32
+}
+
+// NewSobol returns the sequence of coordinate dim of the Sobol sequence,
+// where dim is between 1 and SobolMaxDim. Sequences with different dim may be
+// combined to form multi-dimensional points. NewSobol panics if dim is out of
+// range.
+func NewSobol(dim int) *Sobol {
+	if dim < 1 || dim > SobolMaxDim {
+		panic("sobol: dimension out of range")
+	}
+	s := &Sobol{}
+	if dim == 1 {
+		for i := range s.v {
+			s.v[i] = 1 << uint(31-i)
+		}
+		return s
+	}
+	d := sobolDirections[dim-2]
+	for i := 0; i < d.s; i++ {
+		s.v[i] = d.m[i] << uint(31-i)
+	}
+	for i := d.s; i < len(s.v); i++ {
+		s.v[i] = s.v[i-d.s] ^ (s.v[i-d.s] >> uint(d.s))
+		for k := 1; k < d.s; k++ {
+			if (d.a>>uint(d.s-1-k))&1 == 1 {
+				s.v[i] ^= s.v[i-k]
+			}
+		}
+	}
+	return s
+}
+
+// Next returns the next point of the sequence. Next panics after 2^32 - 1
+// points have been generated.
+func (s *Sobol) Next() float64 {
+	r := float64(s.x) / (1 << 32)
+	// Gray code ordering: the next point differs from this one by the
+	// direction number of the lowest zero bit of the index.
+	var c int
+	for n := s.n; n&1 == 1; n >>= 1 {
+		c++
+	}
+	if c == len(s.v) {
+		panic("sobol: sequence exhausted")
+	}
+	s.x ^= s.v[c]
+	s.n++
+	return r
+}
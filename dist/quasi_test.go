@@ -0,0 +1,120 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestHalton(t *testing.T) {
+	h := NewHalton(3)
+	for i, want := range []float64{0, 1.0 / 3, 2.0 / 3, 1.0 / 9, 4.0 / 9, 7.0 / 9, 2.0 / 9, 5.0 / 9, 8.0 / 9, 1.0 / 27} {
+		if got := h.Next(); math.Abs(got-want) > 1e-15 {
+			t.Errorf("Halton point %d mismatch. Expected %v, Found %v", i, want, got)
+		}
+	}
+}
+
+func TestSobol(t *testing.T) {
+	s := NewSobol(2)
+	for i, want := range []float64{0, 0.5, 0.25, 0.75, 0.375, 0.875, 0.125, 0.625} {
+		if got := s.Next(); got != want {
+			t.Errorf("Sobol point %d mismatch. Expected %v, Found %v", i, want, got)
+		}
+	}
+
+	// Each coordinate of the first 2^m points places one point in each of the
+	// 2^m equal subintervals of [0,1).
+	const m = 10
+	const n = 1 << m
+	points := make([][]float64, SobolMaxDim)
+	for dim := 1; dim <= SobolMaxDim; dim++ {
+		s := NewSobol(dim)
+		x := make([]float64, n)
+		seen := make([]bool, n)
+		for i := range x {
+			x[i] = s.Next()
+			j := int(x[i] * n)
+			if seen[j] {
+				t.Errorf("Sobol dimension %d places two points in interval %d", dim, j)
+			}
+			seen[j] = true
+		}
+		points[dim-1] = x
+	}
+
+	// The first two coordinates form a (0, m, 2)-net: every elementary
+	// interval of area 2^-m contains exactly one point.
+	for k := 0; k <= m; k++ {
+		cols, rows := 1<<uint(k), 1<<uint(m-k)
+		count := make([]int, n)
+		for i := 0; i < n; i++ {
+			c := int(points[0][i] * float64(cols))
+			r := int(points[1][i] * float64(rows))
+			count[c*rows+r]++
+		}
+		for j, c := range count {
+			if c != 1 {
+				t.Errorf("Sobol net property violated for %d×%d intervals: interval %d has %d points", cols, rows, j, c)
+				break
+			}
+		}
+	}
+
+	if !panics(func() { NewSobol(0) }) {
+		t.Errorf("expected panic for dimension 0")
+	}
+	if !panics(func() { NewSobol(SobolMaxDim + 1) }) {
+		t.Errorf("expected panic for dimension beyond SobolMaxDim")
+	}
+}
+
+func TestQuasiRanderConvergence(t *testing.T) {
+	const n = 1 << 14
+	w := Weibull{K: 1.5, Lambda: 2}
+	mean := w.Mean()
+
+	// Average the error of plain Monte Carlo over several seeds.
+	const seeds = 20
+	var mcError float64
+	for seed := int64(1); seed <= seeds; seed++ {
+		w.Source = rand.New(rand.NewSource(seed))
+		var sum float64
+		for i := 0; i < n; i++ {
+			sum += w.Rand()
+		}
+		mcError += math.Abs(sum/n - mean)
+	}
+	mcError /= seeds
+	w.Source = nil
+
+	for _, test := range []struct {
+		name   string
+		newSeq func() QuasiSequence
+	}{
+		{"Sobol", func() QuasiSequence { return NewSobol(1) }},
+		{"Halton", func() QuasiSequence { return NewHalton(3) }},
+	} {
+		quasiError := func(n int) float64 {
+			q := NewQuasiRander(test.newSeq(), w)
+			var sum float64
+			for i := 0; i < n; i++ {
+				sum += q.Rand()
+			}
+			return math.Abs(sum/float64(n) - mean)
+		}
+		small, large := quasiError(n/4), quasiError(n)
+		if large > mcError/3 {
+			t.Errorf("%s quasi-Monte Carlo error %v not much smaller than Monte Carlo error %v", test.name, large, mcError)
+		}
+		// Monte Carlo error halves when the number of samples is quadrupled.
+		// Quasi-Monte Carlo error decreases faster.
+		if large > small/2.5 {
+			t.Errorf("%s quasi-Monte Carlo error decreased from %v to %v, no faster than Monte Carlo", test.name, small, large)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]func() ParameterMarshaler)
+)
+
+func init() {
+	for name, factory := range map[string]func() ParameterMarshaler{
+		"Binomial":                func() ParameterMarshaler { return &Binomial{} },
+		"Burr":                    func() ParameterMarshaler { return &Burr{} },
+		"Erlang":                  func() ParameterMarshaler { return &Erlang{} },
+		"Exponential":             func() ParameterMarshaler { return &Exponential{} },
+		"FoldedNormal":            func() ParameterMarshaler { return &FoldedNormal{} },
+		"Gamma":                   func() ParameterMarshaler { return &Gamma{} },
+		"GeneralizedExtremeValue": func() ParameterMarshaler { return &GeneralizedExtremeValue{} },
+		"GeneralizedGamma":        func() ParameterMarshaler { return &GeneralizedGamma{} },
+		"GeneralizedPareto":       func() ParameterMarshaler { return &GeneralizedPareto{} },
+		"HalfNormal":              func() ParameterMarshaler { return &HalfNormal{} },
+		"InverseGamma":            func() ParameterMarshaler { return &InverseGamma{} },
+		"Kumaraswamy":             func() ParameterMarshaler { return &Kumaraswamy{} },
+		"Laplace":                 func() ParameterMarshaler { return &Laplace{} },
+		"LogLogistic":             func() ParameterMarshaler { return &LogLogistic{} },
+		"Normal":                  func() ParameterMarshaler { return &Normal{} },
+		"PearsonIII":              func() ParameterMarshaler { return &PearsonIII{} },
+		"Poisson":                 func() ParameterMarshaler { return &Poisson{} },
+		"Rician":                  func() ParameterMarshaler { return &Rician{} },
+		"TruncatedNormal":         func() ParameterMarshaler { return &TruncatedNormal{} },
+		"Tweedie":                 func() ParameterMarshaler { return &Tweedie{} },
+		"Uniform":                 func() ParameterMarshaler { return &Uniform{} },
+		"Weibull":                 func() ParameterMarshaler { return &Weibull{} },
+	} {
+		Register(name, factory)
+	}
+}
+
+// Register makes a distribution available by name to New. The factory
+// returns a new zero-valued distribution each time it is called, typically a
+// pointer so that UnmarshalParameters can set its parameters. The built-in
+// distributions are registered under their type names, such as "Weibull".
+//
+// Register panics if factory is nil or if name is already registered.
+// Register is safe for concurrent use.
+func Register(name string, factory func() ParameterMarshaler) {
+	if factory == nil {
+		panic("dist: Register factory is nil")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, dup := registry[name]; dup {
+		panic("dist: Register called twice for " + name)
+	}
+	registry[name] = factory
+}
+
+// New returns a new zero-valued distribution of the type registered under
+// name. The parameters are typically set afterwards with UnmarshalParameters.
+// New returns an error if no distribution is registered under name.
+// New is safe for concurrent use.
+func New(name string) (ParameterMarshaler, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("dist: unknown distribution %q", name)
+	}
+	return factory(), nil
+}
@@ -0,0 +1,96 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegistryBuiltin(t *testing.T) {
+	for _, name := range []string{
+		"Binomial",
+		"Burr",
+		"Erlang",
+		"Exponential",
+		"FoldedNormal",
+		"Gamma",
+		"GeneralizedExtremeValue",
+		"GeneralizedGamma",
+		"GeneralizedPareto",
+		"HalfNormal",
+		"InverseGamma",
+		"Kumaraswamy",
+		"Laplace",
+		"LogLogistic",
+		"Normal",
+		"PearsonIII",
+		"Poisson",
+		"Rician",
+		"TruncatedNormal",
+		"Tweedie",
+		"Uniform",
+		"Weibull",
+	} {
+		d, err := New(name)
+		if err != nil {
+			t.Errorf("New(%q) returned error: %v", name, err)
+			continue
+		}
+		if got := reflect.Indirect(reflect.ValueOf(d)).Type().Name(); got != name {
+			t.Errorf("New(%q) type mismatch. Found %v", name, got)
+		}
+		if !reflect.Indirect(reflect.ValueOf(d)).IsZero() {
+			t.Errorf("New(%q) is not zero-valued. Found %v", name, d)
+		}
+		np, ok := d.(interface {
+			NumParameters() int
+		})
+		if !ok {
+			t.Errorf("New(%q) has no NumParameters method", name)
+			continue
+		}
+		p := make([]Parameter, np.NumParameters())
+		for i := range p {
+			p[i].Value = float64(i + 1)
+		}
+		d.MarshalParameters(p)
+		for i := range p {
+			p[i].Value = float64(i + 1)
+		}
+		d.UnmarshalParameters(p)
+		q := make([]Parameter, len(p))
+		d.MarshalParameters(q)
+		if !reflect.DeepEqual(p, q) {
+			t.Errorf("New(%q) parameters mismatch. Expected %v, Found %v", name, p, q)
+		}
+	}
+
+	if _, err := New("NoSuchDistribution"); err == nil {
+		t.Errorf("expected error for unknown distribution")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	defer func() {
+		registryMu.Lock()
+		delete(registry, "CustomWeibull")
+		registryMu.Unlock()
+	}()
+	Register("CustomWeibull", func() ParameterMarshaler { return &Weibull{K: 2, Lambda: 1} })
+	d, err := New("CustomWeibull")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w, ok := d.(*Weibull); !ok || w.K != 2 {
+		t.Errorf("New(\"CustomWeibull\") mismatch. Found %v", d)
+	}
+	if !panics(func() { Register("CustomWeibull", func() ParameterMarshaler { return &Weibull{} }) }) {
+		t.Errorf("expected panic for duplicate registration")
+	}
+	if !panics(func() { Register("Nil", nil) }) {
+		t.Errorf("expected panic for nil factory")
+	}
+}
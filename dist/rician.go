@@ -0,0 +1,145 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// Rician represents the Rice distribution, the distribution of the magnitude
+// of a two-dimensional normal vector with independent components of standard
+// deviation Sigma whose mean lies at distance Nu from the origin
+// (https://en.wikipedia.org/wiki/Rice_distribution).
+// Valid range for x is [0,+∞). When Nu is zero the Rice distribution is the
+// Rayleigh distribution.
+type Rician struct {
+	Nu     float64 // Distance of the mean from the origin
+	Sigma  float64 // Standard deviation of each component
+	Source Source
+}
+
+// NewRician returns a Rice distribution with noncentrality nu and scale sigma
+// that samples from src. NewRician returns an error if nu is not non-negative
+// and finite or if sigma is not positive and finite.
+func NewRician(nu, sigma float64, src Source) (Rician, error) {
+	r := Rician{Nu: nu, Sigma: sigma, Source: src}
+	if err := r.Validate(); err != nil {
+		return Rician{}, err
+	}
+	return r, nil
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (r Rician) CDF(x float64) float64 {
+	x = snapNonNegative(x)
+	if x <= 0 {
+		return 0
+	}
+	return marcumSum(r.Nu/r.Sigma, x/r.Sigma, false)
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (r Rician) LogProb(x float64) float64 {
+	x = snapNonNegative(x)
+	if x < 0 {
+		return math.Inf(-1)
+	}
+	if math.IsInf(x, 1) {
+		return math.Inf(-1)
+	}
+	// Use the exponentially scaled Bessel function so that the exponential
+	// factors cancel for large x ν / σ^2.
+	s2 := r.Sigma * r.Sigma
+	d := x - r.Nu
+	return math.Log(x/s2) - d*d/(2*s2) + math.Log(besselI0e(x*r.Nu/s2))
+}
+
+// MarshalParameters implements the ParameterMarshaler interface
+func (r Rician) MarshalParameters(p []Parameter) {
+	if len(p) != r.NumParameters() {
+		panic("rician: improper parameter length")
+	}
+	p[0].Name = "Nu"
+	p[0].Value = r.Nu
+	p[1].Name = "Sigma"
+	p[1].Value = r.Sigma
+	return
+}
+
+// Mean returns the mean of the probability distribution.
+func (r Rician) Mean() float64 {
+	// The mean is σ sqrt(π/2) L_{1/2}(-ν^2/(2σ^2)), where L_{1/2} is the
+	// Laguerre polynomial of order one half,
+	//  L_{1/2}(x) = e^{x/2} ((1-x) I_0(-x/2) - x I_1(-x/2)).
+	t := r.Nu * r.Nu / (4 * r.Sigma * r.Sigma)
+	l := (1+2*t)*besselI0e(t) + 2*t*besselI1e(t)
+	return r.Sigma * math.Sqrt(math.Pi/2) * l
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Rician) NumParameters() int {
+	return 2
+}
+
+// Prob computes the value of the probability density function at x.
+func (r Rician) Prob(x float64) float64 {
+	return math.Exp(r.LogProb(x))
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (r Rician) Rand() float64 {
+	x := r.Nu + r.Sigma*randNormFloat64(r.Source)
+	y := r.Sigma * randNormFloat64(r.Source)
+	return math.Hypot(x, y)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (r Rician) StdDev() float64 {
+	return math.Sqrt(r.Variance())
+}
+
+// String implements the fmt.Stringer interface.
+func (r Rician) String() string {
+	return formatParams(&r)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (r Rician) Survival(x float64) float64 {
+	x = snapNonNegative(x)
+	if x <= 0 {
+		return 1
+	}
+	return marcumQ(r.Nu/r.Sigma, x/r.Sigma)
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface
+func (r *Rician) UnmarshalParameters(p []Parameter) {
+	if len(p) != r.NumParameters() {
+		panic("rician: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Nu" {
+		panic("rician: " + panicNameMismatch)
+	}
+	if p[1].Name != "Sigma" {
+		panic("rician: " + panicNameMismatch)
+	}
+	r.Nu = p[0].Value
+	r.Sigma = p[1].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Nu is non-negative and
+// finite and Sigma is positive and finite.
+func (r Rician) Validate() error {
+	return firstError(
+		checkNonNegative("rician", "Nu", r.Nu),
+		checkPositive("rician", "Sigma", r.Sigma),
+	)
+}
+
+// Variance returns the variance of the probability distribution.
+func (r Rician) Variance() float64 {
+	m := r.Mean()
+	return 2*r.Sigma*r.Sigma + r.Nu*r.Nu - m*m
+}
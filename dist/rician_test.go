@@ -0,0 +1,80 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRicianRayleigh(t *testing.T) {
+	for _, sigma := range []float64{0.5, 1, 3} {
+		r := Rician{Nu: 0, Sigma: sigma}
+		s2 := sigma * sigma
+		for _, x := range []float64{0, 0.1, 0.5, 1, 2, 5, 10} {
+			prob := x / s2 * math.Exp(-x*x/(2*s2))
+			if !absEq(r.Prob(x), prob) {
+				t.Errorf("Prob mismatch at %v for σ = %v. Expected %v, Found %v", x, sigma, prob, r.Prob(x))
+			}
+			cdf := -math.Expm1(-x * x / (2 * s2))
+			if !absEq(r.CDF(x), cdf) {
+				t.Errorf("CDF mismatch at %v for σ = %v. Expected %v, Found %v", x, sigma, cdf, r.CDF(x))
+			}
+			if !absEq(r.Survival(x), 1-cdf) {
+				t.Errorf("Survival mismatch at %v for σ = %v. Expected %v, Found %v", x, sigma, 1-cdf, r.Survival(x))
+			}
+		}
+		mean := sigma * math.Sqrt(math.Pi/2)
+		if !equalRel(r.Mean(), mean, 1e-14) {
+			t.Errorf("Mean mismatch for σ = %v. Expected %v, Found %v", sigma, mean, r.Mean())
+		}
+		variance := (4 - math.Pi) / 2 * s2
+		if !equalRel(r.Variance(), variance, 1e-13) {
+			t.Errorf("Variance mismatch for σ = %v. Expected %v, Found %v", sigma, variance, r.Variance())
+		}
+	}
+}
+
+func TestRicianMoments(t *testing.T) {
+	for _, r := range []Rician{
+		{Nu: 0.5, Sigma: 1},
+		{Nu: 1, Sigma: 1},
+		{Nu: 3, Sigma: 0.5},
+		{Nu: 10, Sigma: 2},
+		{Nu: 50, Sigma: 1},
+	} {
+		hi := r.Nu + 12*r.Sigma
+		if total := integrate(r.Prob, 0, hi, 20000); math.Abs(total-1) > 1e-10 {
+			t.Errorf("Density of %#v does not integrate to 1. Found %v", r, total)
+		}
+		mean := integrate(func(x float64) float64 { return x * r.Prob(x) }, 0, hi, 20000)
+		variance := integrate(func(x float64) float64 { return (x - mean) * (x - mean) * r.Prob(x) }, 0, hi, 20000)
+		if math.Abs(r.Mean()-mean) > 1e-8 {
+			t.Errorf("Mean mismatch for %#v. Expected %v, Found %v", r, mean, r.Mean())
+		}
+		if math.Abs(r.Variance()-variance) > 1e-8 {
+			t.Errorf("Variance mismatch for %#v. Expected %v, Found %v", r, variance, r.Variance())
+		}
+		for _, x := range []float64{r.Nu - r.Sigma, r.Nu, r.Nu + 2*r.Sigma} {
+			if x <= 0 {
+				continue
+			}
+			if cdf := integrate(r.Prob, 0, x, 20000); math.Abs(cdf-r.CDF(x)) > 1e-10 {
+				t.Errorf("CDF mismatch at %v for %#v. Expected %v, Found %v", x, r, cdf, r.CDF(x))
+			}
+			if s := r.CDF(x) + r.Survival(x); math.Abs(s-1) > 1e-14 {
+				t.Errorf("CDF and Survival of %#v do not sum to 1 at %v. Found %v", r, x, s)
+			}
+		}
+
+		r.Source = rand.New(rand.NewSource(1))
+		x := make([]float64, 100000)
+		for i := range x {
+			x[i] = r.Rand()
+		}
+		checkMeanVariance(t, x, r.Mean(), r.Variance(), "Rician")
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// esTailProb is the smallest upper-tail probability reached by the quadrature
+// in ExpectedShortfall.
+const esTailProb = 1e-16
+
+// ExpectedShortfall returns the expected shortfall, or conditional value at
+// risk, of d at level alpha, the mean of the upper tail beyond the
+// alpha-quantile when d is a distribution of losses,
+//  ES(α) = 1/(1-α) ∫_α^1 Quantile(u) du = E[X | X ≥ Quantile(α)],
+// where the conditional form holds for continuous distributions.
+//
+// Closed forms are used for the following distributions:
+//  Normal: μ + σ φ(z_α)/(1-α)
+//  Exponential: Quantile(α) + 1/rate
+//  Weibull: λ/(1-α) Γ(1+1/K, -log(1-α)), with Γ the upper incomplete gamma function
+// For other distributions the integral is computed by quadrature after the
+// change of variables u = 1 - (1-α)e^{-t}, which removes the singularity of
+// the quantile at u = 1. The expected shortfall is infinite for tails as
+// heavy as 1/x, where the quadrature result is not meaningful.
+//
+// ExpectedShortfall panics if alpha is not in [0, 1).
+func ExpectedShortfall(d Quantiler, alpha float64) float64 {
+	if !(alpha >= 0 && alpha < 1) {
+		panic("dist: percentile out of bounds")
+	}
+	if es, ok := expectedShortfallExact(d, alpha); ok {
+		return es
+	}
+	return expectedShortfallQuad(d, alpha)
+}
+
+// expectedShortfallExact returns the expected shortfall of d at level alpha
+// if it is known in closed form.
+func expectedShortfallExact(d Quantiler, alpha float64) (float64, bool) {
+	switch d := d.(type) {
+	case Normal:
+		z := UnitNormal.Quantile(alpha)
+		return d.Mu + d.Sigma*UnitNormal.Prob(z)/(1-alpha), true
+	case Exponential:
+		return d.Quantile(alpha) + 1/d.Rate, true
+	case Weibull:
+		a := 1 + 1/d.K
+		g := math.Gamma(a) * gammaIncRegComp(a, -math.Log1p(-alpha))
+		return d.Lambda * g / (1 - alpha), true
+	}
+	return 0, false
+}
+
+// expectedShortfallQuad returns the expected shortfall of d at level alpha,
+// computed as
+//  ∫_0^∞ Quantile(1 - (1-α)e^{-t}) e^{-t} dt
+// truncated where the tail probability (1-α)e^{-t} reaches esTailProb. The
+// quantile may have an infinite derivative at α, for example for the
+// Weibull at α = 0, so Simpson's rule is applied after the further change of
+// variables t = T s³, which clusters the nodes near t = 0 and makes the
+// integrand smooth there.
+func expectedShortfallQuad(d Quantiler, alpha float64) float64 {
+	const n = 2000
+	hi := math.Log((1 - alpha) / esTailProb)
+	h := 1.0 / n
+	var sum float64
+	// The term at s = 0 vanishes, and Quantile(α) may be infinite there.
+	for i := 1; i <= n; i++ {
+		s := float64(i) * h
+		w := 2.0
+		switch {
+		case i == n:
+			w = 1
+		case i%2 == 1:
+			w = 4
+		}
+		t := hi * s * s * s
+		e := math.Exp(-t)
+		sum += w * d.Quantile(1-(1-alpha)*e) * e * 3 * hi * s * s
+	}
+	return sum * h / 3
+}
+
+// VaR returns the value at risk of d at level alpha, the loss that is
+// exceeded with probability 1-alpha when d is a distribution of losses. It is
+// d.Quantile(alpha), and ExpectedShortfall(d, alpha) is at least as large.
+// VaR panics if alpha is not in [0, 1].
+func VaR(d Quantiler, alpha float64) float64 {
+	return d.Quantile(alpha)
+}
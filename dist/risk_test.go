@@ -0,0 +1,74 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "testing"
+
+func TestExpectedShortfall(t *testing.T) {
+	for _, d := range []interface {
+		Quantiler
+		Mean() float64
+	}{
+		Normal{Mu: 1, Sigma: 2},
+		Exponential{Rate: 3},
+		Weibull{K: 0.7, Lambda: 2},
+		Weibull{K: 1.5, Lambda: 2},
+		Weibull{K: 3, Lambda: 0.5},
+	} {
+		for _, alpha := range []float64{0, 0.5, 0.9, 0.99, 0.999} {
+			exact, ok := expectedShortfallExact(d, alpha)
+			if !ok {
+				t.Fatalf("No closed form for %v", d)
+			}
+			if got := ExpectedShortfall(d, alpha); got != exact {
+				t.Errorf("ExpectedShortfall did not use the closed form for %v at %v. Expected %v, Found %v", d, alpha, exact, got)
+			}
+			if quad := expectedShortfallQuad(d, alpha); !equalRel(quad, exact, 1e-6) {
+				t.Errorf("Quadrature mismatch for %v at %v. Expected %v, Found %v", d, alpha, exact, quad)
+			}
+			if q := d.Quantile(alpha); exact < q {
+				t.Errorf("ExpectedShortfall below the quantile for %v at %v. Quantile %v, Found %v", d, alpha, q, exact)
+			}
+		}
+		if es := ExpectedShortfall(d, 0); !equalRel(es, d.Mean(), 1e-12) {
+			t.Errorf("ExpectedShortfall at 0 mismatch for %v. Expected the mean %v, Found %v", d, d.Mean(), es)
+		}
+	}
+
+	// The tail of the uniform is uniform on [Quantile(α), Max].
+	u := Uniform{Min: -1, Max: 3}
+	for _, alpha := range []float64{0, 0.3, 0.95} {
+		want := (u.Quantile(alpha) + u.Max) / 2
+		if got := ExpectedShortfall(u, alpha); !equalRel(got, want, 1e-8) {
+			t.Errorf("ExpectedShortfall mismatch for %v at %v. Expected %v, Found %v", u, alpha, want, got)
+		}
+	}
+	if !panics(func() { ExpectedShortfall(u, 1) }) {
+		t.Errorf("expected panic for alpha = 1")
+	}
+}
+
+func TestVaR(t *testing.T) {
+	for _, d := range []Quantiler{
+		Normal{Mu: 1, Sigma: 2},
+		Exponential{Rate: 3},
+		Weibull{K: 1.5, Lambda: 2},
+		Gamma{Alpha: 2, Beta: 1},
+	} {
+		for _, alpha := range []float64{0.5, 0.9, 0.99, 0.999} {
+			v := VaR(d, alpha)
+			if q := d.Quantile(alpha); v != q {
+				t.Errorf("VaR mismatch for %v at %v. Expected %v, Found %v", d, alpha, q, v)
+			}
+			if es := ExpectedShortfall(d, alpha); es < v {
+				t.Errorf("ExpectedShortfall below VaR for %v at %v. VaR %v, Found %v", d, alpha, v, es)
+			}
+		}
+	}
+	w := Weibull{K: 1.5, Lambda: 2}
+	if got, want := w.VaR(0.99), w.Quantile(0.99); got != want {
+		t.Errorf("Weibull VaR mismatch. Expected %v, Found %v", want, got)
+	}
+}
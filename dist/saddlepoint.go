@@ -0,0 +1,60 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// saddlepointProb returns the Daniels saddlepoint approximation to the
+// density of d at x,
+//  f(x) ≈ exp(K(ŝ) - ŝx) / sqrt(2π K''(ŝ)),
+// where the saddlepoint ŝ solves K'(ŝ) = x. It returns 0 if x is outside the
+// range of K', which is the interior of the support of d.
+func saddlepointProb(d CGFer, x float64) float64 {
+	s, ok := saddlepoint(d, x)
+	if !ok {
+		return 0
+	}
+	k, _, d2k := d.CGF(s)
+	return math.Exp(k-s*x) / math.Sqrt(2*math.Pi*d2k)
+}
+
+// saddlepoint solves K'(s) = x for s. K' is increasing, so Newton's method is
+// safeguarded by a bracket that starts as the domain of K and shrinks with
+// each iterate. Convergence is judged by the size of the Newton step relative
+// to s, since if x is outside the range of K' the iterates move towards an
+// end of the domain with steps that do not shrink. ok is false if no solution
+// was found.
+func saddlepoint(d CGFer, x float64) (s float64, ok bool) {
+	const maxIter = 200
+	lo, hi := d.CGFDomain()
+	for i := 0; i < maxIter; i++ {
+		_, dk, d2k := d.CGF(s)
+		step := (dk - x) / d2k
+		if dk == x || math.Abs(step) <= 1e-14*math.Max(1, math.Abs(s)) {
+			return s - step, true
+		}
+		if dk < x {
+			lo = s
+		} else {
+			hi = s
+		}
+		next := s - step
+		if !(next > lo && next < hi) {
+			switch {
+			case dk < x && math.IsInf(hi, 1):
+				next = s + math.Max(1, math.Abs(s))
+			case dk > x && math.IsInf(lo, -1):
+				next = s - math.Max(1, math.Abs(s))
+			default:
+				next = lo + (hi-lo)/2
+			}
+		}
+		if next == s || math.IsInf(next, 0) {
+			break
+		}
+		s = next
+	}
+	return s, false
+}
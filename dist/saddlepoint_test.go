@@ -0,0 +1,68 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSaddlepointProbGamma(t *testing.T) {
+	for _, g := range []Gamma{
+		{Alpha: 0.5, Beta: 1},
+		{Alpha: 2, Beta: 3},
+		{Alpha: 10, Beta: 0.5},
+		{Alpha: 100, Beta: 1},
+	} {
+		// The saddlepoint approximation replaces Γ(α) in the density by
+		// Stirling's formula, so its ratio to the exact density is constant.
+		lg, _ := math.Lgamma(g.Alpha)
+		ratio := math.Exp(lg - (0.5*math.Log(2*math.Pi) + (g.Alpha-0.5)*math.Log(g.Alpha) - g.Alpha))
+		for _, p := range []float64{1e-10, 0.01, 0.5, 0.99, 1 - 1e-10} {
+			x := g.Quantile(p)
+			if r := g.SaddlepointProb(x) / g.Prob(x); !equalRel(r, ratio, 1e-10) {
+				t.Errorf("Saddlepoint ratio mismatch for %#v at %v. Expected %v, Found %v", g, x, ratio, r)
+			}
+		}
+		// The approximation is within 1/(12α) relative error, so it is
+		// accurate in the far tails.
+		x := g.Mean() + 50*g.StdDev()
+		if r := g.SaddlepointProb(x) / g.Prob(x); math.Abs(r-1) > 1/(11*g.Alpha) {
+			t.Errorf("Saddlepoint tail mismatch for %#v at %v. Ratio %v", g, x, r)
+		}
+		for _, x := range []float64{-1, 0} {
+			if p := g.SaddlepointProb(x); p != 0 {
+				t.Errorf("Saddlepoint outside support for %#v at %v. Expected 0, Found %v", g, x, p)
+			}
+		}
+	}
+}
+
+func TestSaddlepointProb(t *testing.T) {
+	n := Normal{Mu: 1, Sigma: 2}
+	for _, x := range []float64{-20, -3, 0, 1, 4, 30} {
+		if !equalRel(n.SaddlepointProb(x), n.Prob(x), 1e-12) {
+			t.Errorf("Normal saddlepoint mismatch at %v. Expected %v, Found %v", x, n.Prob(x), n.SaddlepointProb(x))
+		}
+	}
+
+	e := Exponential{Rate: 2}
+	g := Gamma{Alpha: 1, Beta: 2}
+	for _, x := range []float64{0.01, 1, 10} {
+		if !equalRel(e.SaddlepointProb(x), g.SaddlepointProb(x), 1e-12) {
+			t.Errorf("Exponential saddlepoint mismatch at %v. Expected %v, Found %v", x, g.SaddlepointProb(x), e.SaddlepointProb(x))
+		}
+	}
+
+	p := Poisson{Lambda: 4}
+	for _, k := range []float64{1, 4, 10, 40} {
+		lg, _ := math.Lgamma(k + 1)
+		stirling := 0.5*math.Log(2*math.Pi*k) + k*math.Log(k) - k
+		want := p.Prob(k) * math.Exp(lg-stirling)
+		if !equalRel(p.SaddlepointProb(k), want, 1e-10) {
+			t.Errorf("Poisson saddlepoint mismatch at %v. Expected %v, Found %v", k, want, p.SaddlepointProb(k))
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// Sampler is a type that can generate random samples. It has the method set
+// of Rander, and is accepted by sampling utilities such as SampleN that need
+// nothing more of a distribution than its samples.
+type Sampler interface {
+	Rand() float64
+}
+
+// Assert that the random variables implement Sampler.
+var (
+	_ Sampler = Binomial{}
+	_ Sampler = Burr{}
+	_ Sampler = Categorical{}
+	_ Sampler = (*ConcurrentRander)(nil)
+	_ Sampler = Erlang{}
+	_ Sampler = Exponential{}
+	_ Sampler = FoldedNormal{}
+	_ Sampler = Gamma{}
+	_ Sampler = GeneralizedExtremeValue{}
+	_ Sampler = GeneralizedGamma{}
+	_ Sampler = GeneralizedPareto{}
+	_ Sampler = HalfNormal{}
+	_ Sampler = InverseGamma{}
+	_ Sampler = Kumaraswamy{}
+	_ Sampler = Laplace{}
+	_ Sampler = LocationScale{}
+	_ Sampler = LogLogistic{}
+	_ Sampler = Normal{}
+	_ Sampler = PearsonIII{}
+	_ Sampler = Poisson{}
+	_ Sampler = (*QuasiRander)(nil)
+	_ Sampler = Rician{}
+	_ Sampler = Transform{}
+	_ Sampler = TruncatedNormal{}
+	_ Sampler = Tweedie{}
+	_ Sampler = Uniform{}
+	_ Sampler = Weibull{}
+	_ Sampler = WeibullMixture{}
+)
+
+// randOpen returns a uniform random number in (0,1) from src, so that it and
+// 1 minus it map to finite values through Quantile.
+func randOpen(src Source) float64 {
+	for {
+		if u := randFloat64(src); u != 0 {
+			return u
+		}
+	}
+}
+
+// randAntithetic returns n samples from d drawn from src in antithetic pairs.
+// Elements 2i and 2i+1 are d.Quantile(u) and d.Quantile(1-u) for an
+// independent uniform u. The two samples of a pair are negatively correlated
+// and distinct pairs are independent, so for monotone integrands the mean of
+// the samples has lower variance than the mean of n independent samples. If n
+// is odd the last sample is an independent inverse-transform sample.
+func randAntithetic(d Quantiler, n int, src Source) []float64 {
+	if n < 0 {
+		panic("dist: negative sample count")
+	}
+	x := make([]float64, n)
+	for i := 0; i+1 < n; i += 2 {
+		u := randOpen(src)
+		x[i] = d.Quantile(u)
+		x[i+1] = d.Quantile(1 - u)
+	}
+	if n%2 == 1 {
+		x[n-1] = d.Quantile(randOpen(src))
+	}
+	return x
+}
+
+// SampleN returns n samples drawn from s by successive calls to Rand.
+// SampleN panics if n is negative.
+func SampleN(s Sampler, n int) []float64 {
+	if n < 0 {
+		panic("dist: negative sample count")
+	}
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = s.Rand()
+	}
+	return x
+}
+
+// SampleWeighted returns n samples drawn from s, as SampleN, together with
+// equal weights of 1/n. The weights are those of an importance sample whose
+// proposal is the target itself, and are the starting point for reweighting
+// the samples towards another distribution, for example by multiplying by
+// p(x)/q(x) and renormalizing. SampleWeighted panics if n is negative.
+func SampleWeighted(s Sampler, n int) (samples, weights []float64) {
+	samples = SampleN(s, n)
+	weights = make([]float64, n)
+	for i := range weights {
+		weights[i] = 1 / float64(n)
+	}
+	return samples, weights
+}
+
+// stratifiedSample returns n samples from d, one from each of the n strata
+// [i/n, (i+1)/n) of the unit interval. Sample i is d.Quantile((i+v)/n), where
+// v is a uniform drawn independently for each stratum from src, so the
+// samples are in increasing order. Each stratum has probability 1/n, and the
+// mean of stratified samples has lower variance than the mean of n
+// independent samples because the spread between strata is removed.
+func stratifiedSample(d Quantiler, n int, src Source) []float64 {
+	if n < 0 {
+		panic("dist: negative sample count")
+	}
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = d.Quantile((float64(i) + randOpen(src)) / float64(n))
+	}
+	return x
+}
+
+// SamplingImportanceResampling returns n points drawn with replacement from
+// samples, where samples[i] is chosen with probability proportional to
+// exp(logWeights[i]). If samples are drawn from a proposal distribution q
+// and logWeights[i] = log p(samples[i]) - log q(samples[i]) for a target p,
+// known up to a constant, the result is approximately a sample from p.
+//
+// The weights are normalized by their maximum before exponentiation, so
+// logWeights may be unnormalized log densities of any magnitude. A weight of
+// -Inf is never chosen. Resampling uses an alias table, so it takes
+// O(len(samples) + n) time. SamplingImportanceResampling panics if the lengths
+// of samples and logWeights differ, if n is negative, if a log weight is NaN,
+// or if no weight is positive and finite.
+func SamplingImportanceResampling(samples, logWeights []float64, n int, src Source) []float64 {
+	if len(samples) != len(logWeights) {
+		panic("dist: slice length mismatch")
+	}
+	if n < 0 {
+		panic("dist: negative sample count")
+	}
+	max := math.Inf(-1)
+	for _, lw := range logWeights {
+		if math.IsNaN(lw) {
+			panic("dist: NaN importance weight")
+		}
+		if lw > max {
+			max = lw
+		}
+	}
+	if math.IsInf(max, 0) || math.IsNaN(max) {
+		panic("dist: no finite positive importance weight")
+	}
+	w := make([]float64, len(logWeights))
+	for i, lw := range logWeights {
+		w[i] = math.Exp(lw - max)
+	}
+	t := newAliasTable(w)
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = samples[t.sample(src)]
+	}
+	return x
+}
@@ -0,0 +1,213 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// meanVariance returns the variance across repetitions of the mean of the
+// samples returned by draw.
+func meanVariance(reps int, draw func() []float64) float64 {
+	var sum, sum2 float64
+	for r := 0; r < reps; r++ {
+		x := draw()
+		var m float64
+		for _, v := range x {
+			m += v
+		}
+		m /= float64(len(x))
+		sum += m
+		sum2 += m * m
+	}
+	mean := sum / float64(reps)
+	return sum2/float64(reps) - mean*mean
+}
+
+func TestRandAntithetic(t *testing.T) {
+	const (
+		n    = 100
+		reps = 2000
+	)
+	w := Weibull{K: 1.5, Lambda: 2, Source: rand.New(rand.NewSource(1))}
+	indep := meanVariance(reps, func() []float64 {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = w.Rand()
+		}
+		return x
+	})
+	anti := meanVariance(reps, func() []float64 { return w.RandAntithetic(n) })
+	if anti > indep/3 {
+		t.Errorf("Antithetic sampling did not reduce variance of the mean. Independent %v, Antithetic %v", indep, anti)
+	}
+
+	x := w.RandAntithetic(n + 1)
+	if len(x) != n+1 {
+		t.Fatalf("Wrong number of samples. Expected %v, Found %v", n+1, len(x))
+	}
+	for i := 0; i+1 < n; i += 2 {
+		if p := w.CDF(x[i]) + w.CDF(x[i+1]); math.Abs(p-1) > 1e-12 {
+			t.Errorf("Samples %d and %d are not antithetic: CDFs sum to %v", i, i+1, p)
+		}
+	}
+
+	// For a symmetric distribution each pair averages to the mean exactly.
+	norm := Normal{Mu: 3, Sigma: 2, Source: rand.New(rand.NewSource(1))}
+	x = norm.RandAntithetic(10)
+	for i := 0; i < len(x); i += 2 {
+		if m := (x[i] + x[i+1]) / 2; math.Abs(m-norm.Mu) > 1e-12 {
+			t.Errorf("Normal antithetic pair mean mismatch. Expected %v, Found %v", norm.Mu, m)
+		}
+	}
+}
+
+func TestSampleN(t *testing.T) {
+	const n = 20000
+	w := Weibull{K: 1.5, Lambda: 2, Source: rand.New(rand.NewSource(1))}
+	x := SampleN(w, n)
+	if len(x) != n {
+		t.Fatalf("Wrong number of samples. Expected %v, Found %v", n, len(x))
+	}
+	mean, variance := sampleMeanVariance(x)
+	if math.Abs(mean-w.Mean()) > 4*math.Sqrt(w.Variance()/n) {
+		t.Errorf("SampleN mean mismatch. Expected %v, Found %v", w.Mean(), mean)
+	}
+	if !equalRel(variance, w.Variance(), 0.05) {
+		t.Errorf("SampleN variance mismatch. Expected %v, Found %v", w.Variance(), variance)
+	}
+
+	// SampleN draws the same stream as successive calls to Rand.
+	w.Source = rand.New(rand.NewSource(2))
+	x = SampleN(w, 5)
+	w.Source = rand.New(rand.NewSource(2))
+	for i, v := range x {
+		if r := w.Rand(); v != r {
+			t.Errorf("SampleN mismatch at %d. Expected %v, Found %v", i, r, v)
+		}
+	}
+
+	x, weights := SampleWeighted(w, 4)
+	if len(x) != 4 || len(weights) != 4 {
+		t.Fatalf("Wrong number of weighted samples. Expected 4, Found %v and %v", len(x), len(weights))
+	}
+	for _, v := range weights {
+		if v != 0.25 {
+			t.Errorf("SampleWeighted weight mismatch. Expected 0.25, Found %v", v)
+		}
+	}
+	if !panics(func() { SampleN(w, -1) }) {
+		t.Errorf("SampleN did not panic with a negative count")
+	}
+}
+
+func TestStratifiedSample(t *testing.T) {
+	const (
+		n    = 100
+		reps = 2000
+	)
+	src := rand.New(rand.NewSource(1))
+	w := Weibull{K: 1.5, Lambda: 2, Source: src}
+	indep := meanVariance(reps, func() []float64 {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = w.Rand()
+		}
+		return x
+	})
+	strat := meanVariance(reps, func() []float64 { return w.StratifiedSample(n, nil) })
+	if strat > indep/10 {
+		t.Errorf("Stratified sampling did not reduce variance of the mean. Independent %v, Stratified %v", indep, strat)
+	}
+
+	x := w.StratifiedSample(n, src)
+	for i, v := range x {
+		p := w.CDF(v)
+		if p < float64(i)/n || p > float64(i+1)/n {
+			t.Errorf("Sample %d with CDF %v outside its stratum", i, p)
+		}
+	}
+
+	// The same source state gives the same samples.
+	a := w.StratifiedSample(10, rand.New(rand.NewSource(2)))
+	b := w.StratifiedSample(10, rand.New(rand.NewSource(2)))
+	for i := range a {
+		if a[i] != b[i] {
+			t.Errorf("Stratified samples differ for the same source at %d", i)
+		}
+	}
+}
+
+func TestAliasTable(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, weights := range [][]float64{
+		{1},
+		{1, 1, 1, 1},
+		{0, 3, 1, 0, 6},
+		{1e-3, 1, 10, 100},
+	} {
+		table := newAliasTable(weights)
+		var sum float64
+		for _, w := range weights {
+			sum += w
+		}
+		const n = 100000
+		counts := make([]float64, len(weights))
+		for i := 0; i < n; i++ {
+			counts[table.sample(src)]++
+		}
+		for i, w := range weights {
+			p := w / sum
+			if got := counts[i] / n; math.Abs(got-p) > 5*math.Sqrt(p*(1-p)/n)+1e-12 {
+				t.Errorf("Alias table frequency mismatch for %v at %v. Expected %v, Found %v", weights, i, p, got)
+			}
+		}
+	}
+}
+
+func TestSamplingImportanceResampling(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	proposal := Normal{Mu: 0, Sigma: 2, Source: src}
+	target := Normal{Mu: 1, Sigma: 1}
+	samples := make([]float64, 20000)
+	logWeights := make([]float64, len(samples))
+	for i := range samples {
+		x := proposal.Rand()
+		samples[i] = x
+		// Offset the weights to check they may be unnormalized.
+		logWeights[i] = target.LogProb(x) - proposal.LogProb(x) + 1000
+	}
+	x := SamplingImportanceResampling(samples, logWeights, 10000, src)
+	if len(x) != 10000 {
+		t.Fatalf("SamplingImportanceResampling length mismatch. Expected 10000, Found %v", len(x))
+	}
+	var mean, variance float64
+	for _, v := range x {
+		mean += v
+	}
+	mean /= float64(len(x))
+	for _, v := range x {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(x))
+	if math.Abs(mean-target.Mu) > 0.05 {
+		t.Errorf("Resampled mean mismatch. Expected %v, Found %v", target.Mu, mean)
+	}
+	if math.Abs(variance-1) > 0.1 {
+		t.Errorf("Resampled variance mismatch. Expected 1, Found %v", variance)
+	}
+
+	x = SamplingImportanceResampling([]float64{1, 2, 3}, []float64{math.Inf(-1), 0, math.Inf(-1)}, 10, src)
+	for _, v := range x {
+		if v != 2 {
+			t.Errorf("Resampled a sample with zero weight. Found %v", v)
+		}
+	}
+	if !panics(func() { SamplingImportanceResampling([]float64{1}, []float64{math.Inf(-1)}, 1, src) }) {
+		t.Errorf("expected panic for no positive weight")
+	}
+}
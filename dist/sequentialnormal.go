@@ -0,0 +1,61 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// SequentialNormal maintains the normal-inverse-gamma posterior over the mean μ
+// and variance σ² of normally distributed data that arrive one point at a
+// time. Under the posterior
+//  σ² ~ InverseGamma(α, β),  μ | σ² ~ Normal(m, σ²/κ),
+// and each observation updates the parameters by
+//  κ' = κ + 1,  m' = (κm + x)/κ',  α' = α + 1/2,  β' = β + κ(x-m)²/(2κ').
+//
+// The update is Normal.ConjugateUpdate of a single sample, with the
+// prior having seen κ samples of the mean and 2α samples of the variance
+// β/α.
+type SequentialNormal struct {
+	normal   Normal
+	strength [2]float64
+}
+
+// NewSequentialNormal returns a SequentialNormal with the normal-inverse-gamma
+// prior with mean mu, mean strength kappa, shape alpha and scale beta.
+// NewSequentialNormal returns an error if mu is not finite or if kappa, alpha
+// or beta is not positive and finite.
+func NewSequentialNormal(mu, kappa, alpha, beta float64) (SequentialNormal, error) {
+	err := firstError(
+		checkFinite("sequentialnormal", "Mu", mu),
+		checkPositive("sequentialnormal", "Kappa", kappa),
+		checkPositive("sequentialnormal", "Alpha", alpha),
+		checkPositive("sequentialnormal", "Beta", beta),
+	)
+	if err != nil {
+		return SequentialNormal{}, err
+	}
+	return SequentialNormal{
+		normal:   Normal{Mu: mu, Sigma: math.Sqrt(beta / alpha)},
+		strength: [2]float64{kappa, 2 * alpha},
+	}, nil
+}
+
+// Observe updates the posterior with the observation x.
+func (s *SequentialNormal) Observe(x float64) {
+	s.normal.ConjugateUpdate([]float64{x, 0}, 1, s.strength[:])
+}
+
+// Posterior returns the current posterior. The inverse gamma is the marginal
+// posterior of σ². The normal is the posterior of μ conditional on σ² = β/α,
+// the reciprocal of the posterior mean of the precision, and has mean m and
+// standard deviation sqrt(β/(ακ)). The marginal posterior of μ is a Student's
+// t distribution with 2α degrees of freedom and the same location and scale,
+// which the normal approaches as observations accumulate.
+func (s SequentialNormal) Posterior() (Normal, InverseGamma) {
+	kappa := s.strength[0]
+	alpha := s.strength[1] / 2
+	variance := s.normal.Sigma * s.normal.Sigma
+	mu := Normal{Mu: s.normal.Mu, Sigma: s.normal.Sigma / math.Sqrt(kappa)}
+	return mu, InverseGamma{Alpha: alpha, Beta: alpha * variance}
+}
@@ -0,0 +1,86 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSequentialNormalUpdate(t *testing.T) {
+	const (
+		m0, kappa0, alpha0, beta0 = 1, 2, 3, 4
+	)
+	s, err := NewSequentialNormal(m0, kappa0, alpha0, beta0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xs := []float64{0.5, 2, -1, 3.5}
+	m, kappa, alpha, beta := float64(m0), float64(kappa0), float64(alpha0), float64(beta0)
+	for _, x := range xs {
+		s.Observe(x)
+		beta += kappa * (x - m) * (x - m) / (2 * (kappa + 1))
+		m = (kappa*m + x) / (kappa + 1)
+		kappa++
+		alpha += 0.5
+	}
+	mu, sigma2 := s.Posterior()
+	if !equalRel(sigma2.Alpha, alpha, 1e-14) || !equalRel(sigma2.Beta, beta, 1e-14) {
+		t.Errorf("InverseGamma mismatch. Expected α = %v, β = %v, Found %v", alpha, beta, sigma2)
+	}
+	if !equalRel(mu.Mu, m, 1e-14) || !equalRel(mu.Sigma, math.Sqrt(beta/(alpha*kappa)), 1e-14) {
+		t.Errorf("Normal mismatch. Expected μ = %v, σ = %v, Found %v", m, math.Sqrt(beta/(alpha*kappa)), mu)
+	}
+
+	for _, test := range []struct{ mu, kappa, alpha, beta float64 }{
+		{math.NaN(), 1, 1, 1},
+		{0, 0, 1, 1},
+		{0, 1, -1, 1},
+		{0, 1, 1, math.Inf(1)},
+	} {
+		if _, err := NewSequentialNormal(test.mu, test.kappa, test.alpha, test.beta); err == nil {
+			t.Errorf("expected error for invalid prior %v", test)
+		}
+	}
+}
+
+func TestSequentialNormalConverge(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	const trueMu, trueSigma = 3, 2
+	s, err := NewSequentialNormal(0, 1, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sum float64
+	lastMuWidth, lastVarWidth := math.Inf(1), math.Inf(1)
+	n := 0
+	for _, checkpoint := range []int{10, 100, 1000, 10000, 100000} {
+		for ; n < checkpoint; n++ {
+			x := trueMu + trueSigma*rnd.NormFloat64()
+			sum += x
+			s.Observe(x)
+		}
+		mu, sigma2 := s.Posterior()
+		// The prior counts as a single observation of the mean 0.
+		if want := sum / float64(n+1); !equalRel(mu.Mu, want, 1e-12) {
+			t.Errorf("Posterior mean mismatch after %d observations. Expected %v, Found %v", n, want, mu.Mu)
+		}
+		muWidth := mu.Quantile(0.975) - mu.Quantile(0.025)
+		varWidth := sigma2.Quantile(0.975) - sigma2.Quantile(0.025)
+		if muWidth >= lastMuWidth || varWidth >= lastVarWidth {
+			t.Errorf("Credible intervals did not shrink after %d observations. Found widths %v and %v, previously %v and %v",
+				n, muWidth, varWidth, lastMuWidth, lastVarWidth)
+		}
+		lastMuWidth, lastVarWidth = muWidth, varWidth
+	}
+	mu, sigma2 := s.Posterior()
+	if math.Abs(mu.Mu-trueMu) > 0.03 {
+		t.Errorf("Posterior mean mismatch. Expected %v, Found %v", trueMu, mu.Mu)
+	}
+	if math.Abs(sigma2.Mean()-trueSigma*trueSigma) > 0.1 {
+		t.Errorf("Posterior variance mismatch. Expected %v, Found %v", trueSigma*trueSigma, sigma2.Mean())
+	}
+}
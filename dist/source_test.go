@@ -0,0 +1,252 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"bufio"
+	"flag"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+func TestSetDefaultSource(t *testing.T) {
+	defer SetDefaultSource(nil)
+
+	w := Weibull{K: 1.5, Lambda: 2}
+	draw := func(seed int64) []float64 {
+		SetDefaultSource(rand.New(rand.NewSource(seed)))
+		x := make([]float64, 20)
+		for i := range x {
+			x[i] = w.Rand()
+		}
+		return x
+	}
+	first := draw(1)
+	second := draw(1)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Samples with the same default source differ at %d. First is %v, second is %v", i, first[i], second[i])
+		}
+	}
+
+	// An explicit Source takes precedence over the default source.
+	SetDefaultSource(rand.New(rand.NewSource(2)))
+	w.Source = rand.New(rand.NewSource(1))
+	for i := range first {
+		if v := w.Rand(); v != first[i] {
+			t.Fatalf("Explicit source not used at %d. Expected %v, Found %v", i, first[i], v)
+		}
+	}
+}
+
+// xorshiftSource is a minimal Source that does not wrap math/rand.
+type xorshiftSource struct {
+	state uint64
+	calls int
+}
+
+func (x *xorshiftSource) Uint64() uint64 {
+	x.calls++
+	x.state ^= x.state << 13
+	x.state ^= x.state >> 7
+	x.state ^= x.state << 17
+	return x.state
+}
+
+func (x *xorshiftSource) Int63() int64 {
+	return int64(x.Uint64() >> 1)
+}
+
+func (x *xorshiftSource) Float64() float64 {
+	return float64(x.Uint64()>>11) / (1 << 53)
+}
+
+func (x *xorshiftSource) NormFloat64() float64 {
+	// Box-Muller, discarding the second variate.
+	u := 1 - x.Float64()
+	v := x.Float64()
+	return math.Sqrt(-2*math.Log(u)) * math.Cos(2*math.Pi*v)
+}
+
+// constSource returns fixed values.
+type constSource struct {
+	u, z float64
+}
+
+func (c constSource) Int63() int64         { return int64(c.u * (1 << 63)) }
+func (c constSource) Float64() float64     { return c.u }
+func (c constSource) NormFloat64() float64 { return c.z }
+
+func TestCustomSource(t *testing.T) {
+	src := constSource{u: 0.3, z: 0.5}
+	if x := (Normal{Mu: 1, Sigma: 2, Source: src}).Rand(); x != 2 {
+		t.Errorf("Normal sample mismatch. Expected 2, Found %v", x)
+	}
+	if x := (Uniform{Min: 1, Max: 3, Source: src}).Rand(); math.Abs(x-1.6) > 1e-15 {
+		t.Errorf("Uniform sample mismatch. Expected 1.6, Found %v", x)
+	}
+	want := -math.Log(0.7) / 2
+	if x := (Exponential{Rate: 2, Source: src}).Rand(); math.Abs(x-want) > 1e-15 {
+		t.Errorf("Exponential sample mismatch. Expected %v, Found %v", want, x)
+	}
+	if x, want := (Weibull{K: 2, Lambda: 1, Source: src}).Rand(), (Weibull{K: 2, Lambda: 1}).Quantile(0.3); math.Abs(x-want) > 1e-14 {
+		t.Errorf("Weibull sample mismatch. Expected %v, Found %v", want, x)
+	}
+
+	// Sampling draws from the custom source and the samples have the
+	// expected moments.
+	for _, test := range []struct {
+		name           string
+		newDist        func(src Source) Rander
+		mean, variance float64
+	}{
+		{"Normal", func(src Source) Rander { return Normal{Mu: 1, Sigma: 2, Source: src} }, 1, 4},
+		{"Exponential", func(src Source) Rander { return Exponential{Rate: 2, Source: src} }, 0.5, 0.25},
+		{"Poisson", func(src Source) Rander { return Poisson{Lambda: 30, Source: src} }, 30, 30},
+		{"Binomial", func(src Source) Rander { return Binomial{N: 100, P: 0.3, Source: src} }, 30, 21},
+		{"GeneralizedGamma", func(src Source) Rander { return GeneralizedGamma{A: 1, D: 0.5, P: 1, Source: src} }, 0.5, 0.5},
+	} {
+		src := &xorshiftSource{state: 1}
+		d := test.newDist(src)
+		x := make([]float64, 100000)
+		for i := range x {
+			x[i] = d.Rand()
+		}
+		if src.calls < len(x) {
+			t.Errorf("%s: custom source used %d times for %d samples", test.name, src.calls, len(x))
+		}
+		checkMeanVariance(t, x, test.mean, test.variance, test.name)
+
+		d = test.newDist(&xorshiftSource{state: 1})
+		for i := 0; i < 10; i++ {
+			if v := d.Rand(); v != x[i] {
+				t.Errorf("%s: samples differ for the same source state at %d. Expected %v, Found %v", test.name, i, x[i], v)
+				break
+			}
+		}
+	}
+
+	// A nil *rand.Rand stored in Source falls back to the default source.
+	var r *rand.Rand
+	_ = Normal{Mu: 0, Sigma: 1, Source: r}.Rand()
+}
+
+// randGoldenFile holds the first samples of each distribution in
+// TestRandGolden. Run the test with -update to rewrite it after an
+// intentional change to a sampling algorithm.
+const randGoldenFile = "testdata/rand.golden"
+
+// TestRandGolden checks that the samples drawn from each distribution with a
+// fixed seed do not change between versions of the package.
+func TestRandGolden(t *testing.T) {
+	const n = 8
+	src := func() Source { return rand.New(rand.NewSource(1)) }
+	dists := []struct {
+		name string
+		d    Rander
+	}{
+		{"Binomial", Binomial{N: 20, P: 0.3, Source: src()}},
+		{"BinomialSmallMean", Binomial{N: 100, P: 0.005, Source: src()}},
+		{"Burr", Burr{C: 2, K: 3, Lambda: 1.5, Source: src()}},
+		{"Categorical", Categorical{Weights: []float64{1, 2, 3, 4}, Source: src()}},
+		{"Erlang", Erlang{K: 3, Lambda: 2, Source: src()}},
+		{"Exponential", Exponential{Rate: 2, Source: src()}},
+		{"FoldedNormal", FoldedNormal{Mu: 1, Sigma: 2, Source: src()}},
+		{"Gamma", Gamma{Alpha: 2.5, Beta: 3, Source: src()}},
+		{"GammaSmallShape", Gamma{Alpha: 0.3, Beta: 1, Source: src()}},
+		{"GeneralizedExtremeValue", GeneralizedExtremeValue{Mu: 1, Sigma: 2, Xi: 0.2, Source: src()}},
+		{"GeneralizedGamma", GeneralizedGamma{A: 1, D: 2, P: 3, Source: src()}},
+		{"GeneralizedPareto", GeneralizedPareto{Mu: 0, Sigma: 1, Xi: 0.3, Source: src()}},
+		{"HalfNormal", HalfNormal{Sigma: 2, Source: src()}},
+		{"InverseGamma", InverseGamma{Alpha: 3, Beta: 2, Source: src()}},
+		{"Kumaraswamy", Kumaraswamy{A: 2, B: 5, Source: src()}},
+		{"Laplace", Laplace{Mu: 1, Scale: 2, Source: src()}},
+		{"LogLogistic", LogLogistic{Alpha: 2, Beta: 3, Source: src()}},
+		{"Normal", Normal{Mu: 1, Sigma: 2, Source: src()}},
+		{"PearsonIII", PearsonIII{Mu: 1, Sigma: 2, Gamma: 0.5, Source: src()}},
+		{"Poisson", Poisson{Lambda: 3, Source: src()}},
+		{"Rician", Rician{Nu: 1, Sigma: 2, Source: src()}},
+		{"TruncatedNormal", TruncatedNormal{Mu: 0, Sigma: 1, Lower: -1, Upper: 2, Source: src()}},
+		{"Tweedie", Tweedie{Mu: 2, Phi: 1, Power: 1.5, Source: src()}},
+		{"Uniform", Uniform{Min: -1, Max: 3, Source: src()}},
+		{"Weibull", Weibull{K: 1.5, Lambda: 2, Source: src()}},
+	}
+
+	got := make(map[string][]float64)
+	var lines []string
+	for _, d := range dists {
+		x := make([]float64, n)
+		fields := []string{d.name}
+		for i := range x {
+			x[i] = d.d.Rand()
+			fields = append(fields, strconv.FormatFloat(x[i], 'g', -1, 64))
+		}
+		got[d.name] = x
+		lines = append(lines, strings.Join(fields, " "))
+	}
+
+	if *updateGolden {
+		if err := ioutil.WriteFile(randGoldenFile, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	f, err := os.Open(randGoldenFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	want := make(map[string][]float64)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		for _, v := range fields[1:] {
+			x, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want[fields[0]] = append(want[fields[0]], x)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range dists {
+		w, ok := want[d.name]
+		if !ok {
+			t.Errorf("%s: no golden samples in %s", d.name, randGoldenFile)
+			continue
+		}
+		// Allow for differences in the last bits between platforms, for
+		// example from fused multiply-add instructions.
+		if !floatsEqualRel(got[d.name], w, 1e-12) {
+			t.Errorf("%s: samples changed.\nExpected %v\nFound    %v", d.name, w, got[d.name])
+		}
+	}
+}
+
+func floatsEqualRel(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] && math.Abs(a[i]-b[i]) > tol*math.Max(math.Abs(a[i]), math.Abs(b[i])) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"fmt"
+	"math"
+)
+
+// SPRT is Wald's sequential probability ratio test between the simple
+// hypotheses that observations are drawn from H0 or from H1. Observations
+// are supplied one at a time to Update, which accumulates the log-likelihood
+// ratio
+//  Λ = Σ log H1(x_i) - log H0(x_i)
+// and accepts H1 once Λ ≥ LogA, or H0 once Λ ≤ LogB. LogB < 0 < LogA is
+// required.
+//
+// The zero value of the accumulated state is a test with no observations, so
+// an SPRT may be constructed as a struct literal, or by NewSPRT from the
+// desired error rates.
+type SPRT struct {
+	H0, H1     LogProber
+	LogA, LogB float64
+
+	llr      float64
+	n        int
+	decision int
+	done     bool
+}
+
+// NewSPRT returns a sequential probability ratio test between h0 and h1 with
+// Wald's boundaries
+//  LogA = log((1-β)/α), LogB = log(β/(1-α))
+// for a probability alpha of accepting H1 when H0 is true and a probability
+// beta of accepting H0 when H1 is true. The achieved error rates are
+// approximately, and in total at most, the requested ones. NewSPRT returns an
+// error unless alpha and beta are in (0, 1) with alpha + beta < 1.
+func NewSPRT(h0, h1 LogProber, alpha, beta float64) (SPRT, error) {
+	if !(alpha > 0 && alpha < 1) {
+		return SPRT{}, fmt.Errorf("sprt: alpha must be in (0, 1), found %v", alpha)
+	}
+	if !(beta > 0 && beta < 1) {
+		return SPRT{}, fmt.Errorf("sprt: beta must be in (0, 1), found %v", beta)
+	}
+	if !(alpha+beta < 1) {
+		return SPRT{}, fmt.Errorf("sprt: alpha + beta must be less than 1, found %v", alpha+beta)
+	}
+	return SPRT{
+		H0:   h0,
+		H1:   h1,
+		LogA: math.Log((1 - beta) / alpha),
+		LogB: math.Log(beta / (1 - alpha)),
+	}, nil
+}
+
+// LogLikelihoodRatio returns the accumulated log-likelihood ratio of H1 to
+// H0 and the number of observations it includes.
+func (s *SPRT) LogLikelihoodRatio() (llr float64, n int) {
+	return s.llr, s.n
+}
+
+// Reset discards the accumulated observations so that a new test can be run
+// with the same hypotheses and boundaries.
+func (s *SPRT) Reset() {
+	s.llr = 0
+	s.n = 0
+	s.decision = 0
+	s.done = false
+}
+
+// Update adds the observation x to the test. If a boundary has been crossed,
+// done is true and decision is the index of the accepted hypothesis, 0 for H0
+// or 1 for H1. Otherwise done is false, decision is -1, and more observations
+// are needed. Once the test is done, Update returns the same decision without
+// using x until Reset is called.
+func (s *SPRT) Update(x float64) (decision int, done bool) {
+	if s.done {
+		return s.decision, true
+	}
+	s.llr += s.H1.LogProb(x) - s.H0.LogProb(x)
+	s.n++
+	switch {
+	case s.llr >= s.LogA:
+		s.decision, s.done = 1, true
+	case s.llr <= s.LogB:
+		s.decision, s.done = 0, true
+	default:
+		return -1, false
+	}
+	return s.decision, true
+}
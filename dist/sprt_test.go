@@ -0,0 +1,87 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSPRT(t *testing.T) {
+	const (
+		alpha  = 0.05
+		beta   = 0.1
+		trials = 2000
+	)
+	src := rand.New(rand.NewSource(1))
+	h0 := Normal{Mu: 0, Sigma: 1}
+	h1 := Normal{Mu: 0.5, Sigma: 1}
+	s, err := NewSPRT(h0, h1, alpha, beta)
+	if err != nil {
+		t.Fatalf("NewSPRT returned error: %v", err)
+	}
+	for _, test := range []struct {
+		truth    Normal
+		wrong    int
+		maxError float64
+	}{
+		{Normal{Mu: 0, Sigma: 1, Source: src}, 1, alpha},
+		{Normal{Mu: 0.5, Sigma: 1, Source: src}, 0, beta},
+	} {
+		var errors, total int
+		for i := 0; i < trials; i++ {
+			s.Reset()
+			for {
+				decision, done := s.Update(test.truth.Rand())
+				if !done {
+					if decision != -1 {
+						t.Fatalf("Decision mismatch before the test is done. Expected -1, Found %v", decision)
+					}
+					continue
+				}
+				if decision == test.wrong {
+					errors++
+				}
+				_, n := s.LogLikelihoodRatio()
+				total += n
+				break
+			}
+		}
+		// Wald's approximations bound the error rates by α/(1-β) and
+		// β/(1-α). Allow for the sampling error of the estimate.
+		rate := float64(errors) / trials
+		bound := test.maxError / (1 - math.Min(alpha, beta))
+		if rate > bound+3*math.Sqrt(bound*(1-bound)/trials) {
+			t.Errorf("Error rate too large for %v. Expected at most %v, Found %v", test.truth, bound, rate)
+		}
+		// The mean sample size is far below that of a fixed-size test with
+		// the same error rates, which is about 34 here.
+		if mean := float64(total) / trials; mean > 25 {
+			t.Errorf("Mean sample number too large for %v. Found %v", test.truth, mean)
+		}
+	}
+
+	// Update after the decision returns it without accumulating.
+	s.Reset()
+	for {
+		if _, done := s.Update(5); done {
+			break
+		}
+	}
+	llr, n := s.LogLikelihoodRatio()
+	if decision, done := s.Update(-100); decision != 1 || !done {
+		t.Errorf("Update after the decision mismatch. Expected (1, true), Found (%v, %v)", decision, done)
+	}
+	if llr2, n2 := s.LogLikelihoodRatio(); llr2 != llr || n2 != n {
+		t.Errorf("Update after the decision changed the state from (%v, %v) to (%v, %v)", llr, n, llr2, n2)
+	}
+
+	for _, rates := range [][2]float64{{0, 0.1}, {0.1, 1}, {0.6, 0.5}, {math.NaN(), 0.1}} {
+		if _, err := NewSPRT(h0, h1, rates[0], rates[1]); err == nil {
+			t.Errorf("expected error for error rates %v", rates)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// Transform represents the distribution of Y = Forward(X) for X distributed
+// as Base, where Forward is a monotone, differentiable function with inverse
+// Inverse. LogJacobian(y) is the log of the absolute derivative of Inverse,
+//  LogJacobian(y) = log |d Inverse(y) / dy|,
+// so that by the change of variables
+//  LogProb(y) = Base.LogProb(Inverse(y)) + LogJacobian(y).
+// For example, the log-normal distribution is the transform of a normal
+// distribution by Forward = math.Exp, Inverse = math.Log and
+// LogJacobian(y) = -log(y).
+//
+// The density methods require Base to also implement LogProber, and panic
+// otherwise.
+type Transform struct {
+	Base        Rander
+	Forward     func(float64) float64
+	Inverse     func(float64) float64
+	LogJacobian func(float64) float64
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at y. LogProb returns -Inf if Inverse(y) is NaN for y that
+// is not NaN, which is how points outside the range of Forward are usually
+// reported, as by math.Log for negative arguments.
+func (t Transform) LogProb(y float64) float64 {
+	lp, ok := t.Base.(LogProber)
+	if !ok {
+		panic("dist: transform base does not implement LogProber")
+	}
+	if math.IsNaN(y) {
+		return math.NaN()
+	}
+	x := t.Inverse(y)
+	if math.IsNaN(x) {
+		return math.Inf(-1)
+	}
+	v := lp.LogProb(x)
+	if math.IsInf(v, -1) {
+		return v
+	}
+	return v + t.LogJacobian(y)
+}
+
+// Prob computes the value of the probability density function at y.
+func (t Transform) Prob(y float64) float64 {
+	return math.Exp(t.LogProb(y))
+}
+
+// Rand returns a random sample drawn from the distribution, Forward applied
+// to a sample from Base.
+func (t Transform) Rand() float64 {
+	return t.Forward(t.Base.Rand())
+}
@@ -0,0 +1,54 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTransformLogNormal(t *testing.T) {
+	const mu, sigma = 0.5, 0.8
+	logNormal := Transform{
+		Base:        Normal{Mu: mu, Sigma: sigma, Source: rand.New(rand.NewSource(1))},
+		Forward:     math.Exp,
+		Inverse:     math.Log,
+		LogJacobian: func(y float64) float64 { return -math.Log(y) },
+	}
+	for _, y := range []float64{1e-3, 0.1, 0.5, 1, 2, 5, 20} {
+		z := (math.Log(y) - mu) / sigma
+		want := math.Exp(-z*z/2) / (y * sigma * math.Sqrt(2*math.Pi))
+		if got := logNormal.Prob(y); !equalRel(got, want, 1e-13) {
+			t.Errorf("Prob mismatch at %v. Expected %v, Found %v", y, want, got)
+		}
+	}
+	if got := logNormal.LogProb(-1); !math.IsInf(got, -1) {
+		t.Errorf("LogProb mismatch outside the range. Expected -Inf, Found %v", got)
+	}
+	if got := logNormal.LogProb(math.NaN()); !math.IsNaN(got) {
+		t.Errorf("LogProb mismatch at NaN. Expected NaN, Found %v", got)
+	}
+	if got := integrate(logNormal.Prob, 0, 500, 200000); math.Abs(got-1) > 1e-8 {
+		t.Errorf("Density does not integrate to 1. Found %v", got)
+	}
+
+	x := make([]float64, 100000)
+	for i := range x {
+		x[i] = logNormal.Rand()
+	}
+	mean := math.Exp(mu + sigma*sigma/2)
+	variance := (math.Exp(sigma*sigma) - 1) * mean * mean
+	checkMeanVariance(t, x, mean, variance, "Transformed normal")
+
+	if !panics(func() { (Transform{Base: randOnly{}, Inverse: math.Log}).LogProb(1) }) {
+		t.Errorf("LogProb did not panic for a base without a density")
+	}
+}
+
+// randOnly is a Rander with no density.
+type randOnly struct{}
+
+func (randOnly) Rand() float64 { return 0 }
@@ -0,0 +1,512 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// TruncatedNormal represents a normal distribution truncated to the interval
+// [Lower, Upper] (https://en.wikipedia.org/wiki/Truncated_normal_distribution).
+// Either bound may be infinite.
+type TruncatedNormal struct {
+	Mu    float64 // Mean of the underlying normal distribution
+	Sigma float64 // Standard deviation of the underlying normal distribution
+	Lower float64 // Lower bound of the support
+	Upper float64 // Upper bound of the support
+
+	Source Source
+}
+
+// NewTruncatedNormal returns a normal distribution with mean mu and standard
+// deviation sigma truncated to [lower, upper] that samples from src.
+// NewTruncatedNormal returns an error if mu is not finite, if sigma is not
+// positive and finite, or if lower is not less than upper.
+func NewTruncatedNormal(mu, sigma, lower, upper float64, src Source) (TruncatedNormal, error) {
+	t := TruncatedNormal{Mu: mu, Sigma: sigma, Lower: lower, Upper: upper, Source: src}
+	if err := t.Validate(); err != nil {
+		return TruncatedNormal{}, err
+	}
+	return t, nil
+}
+
+// bounds returns the truncation bounds in standard units.
+func (t TruncatedNormal) bounds() (a, b float64) {
+	return (t.Lower - t.Mu) / t.Sigma, (t.Upper - t.Mu) / t.Sigma
+}
+
+// unitNormalSurvival returns the survival function of the standard normal
+// distribution. It is accurate far into the upper tail.
+func unitNormalSurvival(z float64) float64 {
+	return 0.5 * math.Erfc(z/math.Sqrt2)
+}
+
+// unitNormalCDF returns the cumulative distribution function of the standard
+// normal distribution. It is accurate far into the lower tail.
+func unitNormalCDF(z float64) float64 {
+	return 0.5 * math.Erfc(-z/math.Sqrt2)
+}
+
+// unitNormalProb returns the density of the standard normal distribution.
+func unitNormalProb(z float64) float64 {
+	return oneOverRoot2Pi * math.Exp(-0.5*z*z)
+}
+
+// farTail is the standardized bound beyond which Quantile cannot rely on
+// the normal quantile, since the standard normal survival function underflows
+// near 38.
+const farTail = 30
+
+// millsRatio returns the Mills ratio Q(z)/φ(z) for z ≥ 0, where Q and φ are
+// the survival function and density of the standard normal. Above 5 it is
+// evaluated from the continued fraction
+//  Q(z)/φ(z) = 1/(z+1/(z+2/(z+3/(z+…))))
+// which converges rapidly there and, unlike Q(z), does not underflow.
+func millsRatio(z float64) float64 {
+	if math.IsInf(z, 1) {
+		return 0
+	}
+	if z < 5 {
+		return unitNormalSurvival(z) / unitNormalProb(z)
+	}
+	var f float64
+	for k := 60; k > 0; k-- {
+		f = float64(k) / (z + f)
+	}
+	return 1 / (z + f)
+}
+
+// scaledTailMass returns (Q(u)-Q(v))/φ(u) for 0 ≤ u ≤ v, where v may be
+// infinite. It remains finite far in the tail where Q(u) and Q(v) underflow.
+func scaledTailMass(u, v float64) float64 {
+	if math.IsInf(v, 1) {
+		return millsRatio(u)
+	}
+	return millsRatio(u) - math.Exp(-0.5*(v-u)*(v+u))*millsRatio(v)
+}
+
+// tail returns the standardized support as an interval [a, b] of the upper
+// tail with 0 ≤ a, reflecting it about zero if it lies below zero, and
+// whether it was reflected. ok is false if the support contains zero.
+func (t TruncatedNormal) tail() (a, b float64, reflected, ok bool) {
+	a, b = t.bounds()
+	switch {
+	case a > 0:
+		return a, b, false, true
+	case b < 0:
+		return -b, -a, true, true
+	}
+	return a, b, false, false
+}
+
+// logMass returns the logarithm of the probability under the underlying
+// standard normal of the standardized support. In the tails it is computed
+// from the scaled mass, so it is finite even when the mass underflows.
+func (t TruncatedNormal) logMass() float64 {
+	if a, b, _, ok := t.tail(); ok {
+		return negLogRoot2Pi - 0.5*a*a + math.Log(scaledTailMass(a, b))
+	}
+	a, b := t.bounds()
+	return math.Log(unitNormalCDF(b) - unitNormalCDF(a))
+}
+
+// probs returns a function that computes the CDF and the survival function at
+// a standardized point z of the support. The normalizing mass is computed once
+// when probs is called.
+func (t TruncatedNormal) probs() func(z float64) (cdf, survival float64) {
+	if a, b, reflected, ok := t.tail(); ok {
+		// The masses below and above z are scaled by φ(a).
+		mass := scaledTailMass(a, b)
+		return func(z float64) (float64, float64) {
+			if reflected {
+				z = -z
+			}
+			below := scaledTailMass(a, z) / mass
+			above := math.Exp(-0.5*(z-a)*(z+a)) * scaledTailMass(z, b) / mass
+			if reflected {
+				return above, below
+			}
+			return below, above
+		}
+	}
+	a, b := t.bounds()
+	pa, pb := unitNormalCDF(a), unitNormalCDF(b)
+	qb := unitNormalSurvival(b)
+	mass := pb - pa
+	return func(z float64) (float64, float64) {
+		if z > 0 {
+			return (unitNormalCDF(z) - pa) / mass, (unitNormalSurvival(z) - qb) / mass
+		}
+		return (unitNormalCDF(z) - pa) / mass, (pb - unitNormalCDF(z)) / mass
+	}
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (t TruncatedNormal) CDF(x float64) float64 {
+	x = snapToSupport(x, t.Lower, t.Upper)
+	if x <= t.Lower {
+		return 0
+	}
+	if x >= t.Upper {
+		return 1
+	}
+	cdf, _ := t.probs()((x - t.Mu) / t.Sigma)
+	return cdf
+}
+
+// CDFSlice returns the value of the cumulative density function at each of
+// the values in xs. See CDFSliceTo.
+func (t TruncatedNormal) CDFSlice(xs []float64) []float64 {
+	return t.CDFSliceTo(make([]float64, len(xs)), xs)
+}
+
+// CDFSliceTo stores in dst the value of the cumulative density function at
+// each of the values in xs, and returns dst. The normalizing mass of the
+// truncated region is computed once for the whole slice.
+// CDFSliceTo panics if len(dst) != len(xs).
+func (t TruncatedNormal) CDFSliceTo(dst, xs []float64) []float64 {
+	if len(dst) != len(xs) {
+		panic("dist: slice length mismatch")
+	}
+	probs := t.probs()
+	for i, x := range xs {
+		x = snapToSupport(x, t.Lower, t.Upper)
+		switch {
+		case x <= t.Lower:
+			dst[i] = 0
+		case x >= t.Upper:
+			dst[i] = 1
+		default:
+			dst[i], _ = probs((x - t.Mu) / t.Sigma)
+		}
+	}
+	return dst
+}
+
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with n intervals.
+func (t TruncatedNormal) IntegrateProb(lo, hi float64, n int) float64 {
+	return integrateProb(t, lo, hi, n)
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (t TruncatedNormal) LogProb(x float64) float64 {
+	x = snapToSupport(x, t.Lower, t.Upper)
+	if x < t.Lower || x > t.Upper {
+		return math.Inf(-1)
+	}
+	z := (x - t.Mu) / t.Sigma
+	return negLogRoot2Pi - math.Log(t.Sigma) - 0.5*z*z - t.logMass()
+}
+
+// MarshalParameters implements the ParameterMarshaler interface
+func (t TruncatedNormal) MarshalParameters(p []Parameter) {
+	if len(p) != t.NumParameters() {
+		panic("truncatednormal: improper parameter length")
+	}
+	p[0].Name = "Mu"
+	p[0].Value = t.Mu
+	p[1].Name = "Sigma"
+	p[1].Value = t.Sigma
+	p[2].Name = "Lower"
+	p[2].Value = t.Lower
+	p[3].Name = "Upper"
+	p[3].Value = t.Upper
+	return
+}
+
+// Mean returns the mean of the probability distribution.
+func (t TruncatedNormal) Mean() float64 {
+	if a, b, reflected, ok := t.tail(); ok {
+		// (φ(a)-φ(b))/mass with both terms scaled by φ(a).
+		d := -math.Expm1(-0.5*(b-a)*(b+a)) / scaledTailMass(a, b)
+		if reflected {
+			d = -d
+		}
+		return t.Mu + t.Sigma*d
+	}
+	a, b := t.bounds()
+	return t.Mu + t.Sigma*(unitNormalProb(a)-unitNormalProb(b))/(unitNormalCDF(b)-unitNormalCDF(a))
+}
+
+// Moment returns the k-th raw moment E[X^k] of the distribution, or the k-th
+// central moment E[(X-μ)^k] if central is true. The moments are computed by
+// numerical integration of the density, and are inaccurate if the moment
+// does not exist. Moment panics if k is negative.
+func (t TruncatedNormal) Moment(k int, central bool) float64 {
+	return numericalMoment(t, k, central)
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (t TruncatedNormal) NormalizationCheck() float64 {
+	return normalization(t)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (TruncatedNormal) NumParameters() int {
+	return 4
+}
+
+// Prob computes the value of the probability density function at x.
+func (t TruncatedNormal) Prob(x float64) float64 {
+	return math.Exp(t.LogProb(x))
+}
+
+// ProbSlice returns the value of the probability density function at each
+// of the values in xs. See ProbSliceTo.
+func (t TruncatedNormal) ProbSlice(xs []float64) []float64 {
+	return t.ProbSliceTo(make([]float64, len(xs)), xs)
+}
+
+// ProbSliceTo stores in dst the value of the probability density function at
+// each of the values in xs, and returns dst. The normalizing mass of
+// the truncated region is computed once for the whole slice.
+// ProbSliceTo panics if len(dst) != len(xs).
+func (t TruncatedNormal) ProbSliceTo(dst, xs []float64) []float64 {
+	if len(dst) != len(xs) {
+		panic("dist: slice length mismatch")
+	}
+	logNorm := negLogRoot2Pi - math.Log(t.Sigma) - t.logMass()
+	for i, x := range xs {
+		x = snapToSupport(x, t.Lower, t.Upper)
+		if x < t.Lower || x > t.Upper {
+			dst[i] = 0
+			continue
+		}
+		z := (x - t.Mu) / t.Sigma
+		dst[i] = math.Exp(logNorm - 0.5*z*z)
+	}
+	return dst
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+// Quantile(0) is Lower and Quantile(1) is Upper, the bounds of the support.
+func (t TruncatedNormal) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	if p == 0 {
+		return t.Lower
+	}
+	if p == 1 {
+		return t.Upper
+	}
+	if a, b, reflected, ok := t.tail(); ok && a >= farTail {
+		z := t.quantileFarTail(a, b, reflected, p)
+		if reflected {
+			z = -z
+		}
+		return t.Mu + t.Sigma*z
+	}
+	a, b := t.bounds()
+	var z float64
+	if a > 0 {
+		// Work with the upper tail to avoid losing precision.
+		mass := unitNormalSurvival(a) - unitNormalSurvival(b)
+		z = -zQuantile(unitNormalSurvival(a) - p*mass)
+	} else {
+		mass := unitNormalCDF(b) - unitNormalCDF(a)
+		z = zQuantile(unitNormalCDF(a) + p*mass)
+	}
+	z = math.Max(a, math.Min(b, z))
+	return t.Mu + t.Sigma*z
+}
+
+// quantileFarTail returns the standardized p quantile of the support [a, b]
+// returned by tail when a is beyond farTail, where the normal quantile
+// of the mass is not representable.
+func (t TruncatedNormal) quantileFarTail(a, b float64, reflected bool, p float64) float64 {
+	// Solve for the smaller of the fractions of the mass below and above z in
+	// the upper tail to avoid losing precision.
+	frac, above := p, reflected
+	if p > 0.5 {
+		frac, above = 1-p, !reflected
+	}
+	// The fraction of the mass below z is concave in z, so Newton's method
+	// started from a converges monotonically from below.
+	mass := scaledTailMass(a, b)
+	z := a
+	for i := 0; i < 100; i++ {
+		var f float64
+		if above {
+			f = frac - math.Exp(-0.5*(z-a)*(z+a))*scaledTailMass(z, b)/mass
+		} else {
+			f = scaledTailMass(a, z)/mass - frac
+		}
+		step := f / (math.Exp(-0.5*(z-a)*(z+a)) / mass)
+		z -= step
+		if z >= b {
+			return b
+		}
+		if math.Abs(step) <= 1e-15*z {
+			break
+		}
+	}
+	return z
+}
+
+// Rand returns a random sample drawn from the distribution.
+//
+// Rand uses the rejection algorithm of
+//  C. P. Robert, "Simulation of truncated normal variables", Statistics and
+//  Computing 5 (1995).
+// which uses an exponential proposal in the tails and a uniform or normal
+// proposal in the bulk, so the expected number of proposals remains small
+// even when the truncation bounds are far in the tail.
+func (t TruncatedNormal) Rand() float64 {
+	a, b := t.bounds()
+	var z float64
+	switch {
+	case a >= 0:
+		z = t.randTail(a, b)
+	case b <= 0:
+		z = -t.randTail(-b, -a)
+	case b-a >= math.Sqrt(2*math.Pi):
+		// The interval contains zero and at least half of the mass of the
+		// normal, so plain normal rejection is efficient.
+		for {
+			z = randNormFloat64(t.Source)
+			if a <= z && z <= b {
+				break
+			}
+		}
+	default:
+		// Uniform proposal over a narrow interval that contains zero.
+		for {
+			z = a + (b-a)*randFloat64(t.Source)
+			if randFloat64(t.Source) <= math.Exp(-0.5*z*z) {
+				break
+			}
+		}
+	}
+	return t.Mu + t.Sigma*z
+}
+
+// RandAntithetic returns n samples drawn from the distribution in antithetic
+// pairs, as described for randAntithetic.
+func (t TruncatedNormal) RandAntithetic(n int) []float64 {
+	return randAntithetic(t, n, t.Source)
+}
+
+// randTail returns a sample from the standard normal truncated to [a, b]
+// where 0 ≤ a < b.
+func (t TruncatedNormal) randTail(a, b float64) float64 {
+	// Optimal rate of the exponential proposal.
+	alpha := (a + math.Sqrt(a*a+4)) / 2
+	if b-a < math.Exp(0.5+(a*a-a*math.Sqrt(a*a+4))/4)/alpha {
+		// The interval is narrow enough that a uniform proposal is more
+		// efficient than the exponential.
+		for {
+			z := a + (b-a)*randFloat64(t.Source)
+			if randFloat64(t.Source) <= math.Exp(0.5*(a*a-z*z)) {
+				return z
+			}
+		}
+	}
+	for {
+		z := a + randExpFloat64(t.Source)/alpha
+		if z > b {
+			continue
+		}
+		if randFloat64(t.Source) <= math.Exp(-0.5*(z-alpha)*(z-alpha)) {
+			return z
+		}
+	}
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (t TruncatedNormal) StdDev() float64 {
+	return math.Sqrt(t.Variance())
+}
+
+// StratifiedSample returns n stratified samples from the distribution, as
+// described for stratifiedSample, drawing uniforms from src or, if src is nil,
+// from the Source of the distribution.
+func (t TruncatedNormal) StratifiedSample(n int, src Source) []float64 {
+	if src == nil {
+		src = t.Source
+	}
+	return stratifiedSample(t, n, src)
+}
+
+// String implements the fmt.Stringer interface.
+func (t TruncatedNormal) String() string {
+	return formatParams(&t)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (t TruncatedNormal) Survival(x float64) float64 {
+	x = snapToSupport(x, t.Lower, t.Upper)
+	if x <= t.Lower {
+		return 1
+	}
+	if x >= t.Upper {
+		return 0
+	}
+	_, survival := t.probs()((x - t.Mu) / t.Sigma)
+	return survival
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface
+func (t *TruncatedNormal) UnmarshalParameters(p []Parameter) {
+	if len(p) != t.NumParameters() {
+		panic("truncatednormal: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Mu" {
+		panic("truncatednormal: " + panicNameMismatch)
+	}
+	if p[1].Name != "Sigma" {
+		panic("truncatednormal: " + panicNameMismatch)
+	}
+	if p[2].Name != "Lower" {
+		panic("truncatednormal: " + panicNameMismatch)
+	}
+	if p[3].Name != "Upper" {
+		panic("truncatednormal: " + panicNameMismatch)
+	}
+	t.Mu = p[0].Value
+	t.Sigma = p[1].Value
+	t.Lower = p[2].Value
+	t.Upper = p[3].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Mu is finite, Sigma is
+// positive and finite, and Lower is less than Upper.
+func (t TruncatedNormal) Validate() error {
+	return firstError(
+		checkFinite("truncatednormal", "Mu", t.Mu),
+		checkPositive("truncatednormal", "Sigma", t.Sigma),
+		checkOrdered("truncatednormal", "Lower", "Upper", t.Lower, t.Upper),
+	)
+}
+
+// Variance returns the variance of the probability distribution.
+func (t TruncatedNormal) Variance() float64 {
+	a, b := t.bounds()
+	var z, pa, pb float64
+	if ta, tb, _, ok := t.tail(); ok {
+		// Scale the densities and the mass by φ(a). The variance is unchanged
+		// by the reflection into the upper tail.
+		a, b = ta, tb
+		z = scaledTailMass(a, b)
+		pa = 1
+		pb = math.Exp(-0.5 * (b - a) * (b + a))
+	} else {
+		z = unitNormalCDF(b) - unitNormalCDF(a)
+		pa = unitNormalProb(a)
+		pb = unitNormalProb(b)
+	}
+	// a·φ(a) is zero for infinite a.
+	var apa, bpb float64
+	if !math.IsInf(a, 0) {
+		apa = a * pa
+	}
+	if !math.IsInf(b, 0) {
+		bpb = b * pb
+	}
+	d := (pa - pb) / z
+	return t.Sigma * t.Sigma * (1 + (apa-bpb)/z - d*d)
+}
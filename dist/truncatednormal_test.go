@@ -0,0 +1,165 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTruncatedNormalProbs(t *testing.T) {
+	for _, tn := range []TruncatedNormal{
+		{Mu: 0, Sigma: 1, Lower: -1, Upper: 2},
+		{Mu: 3, Sigma: 2, Lower: 4, Upper: math.Inf(1)},
+		{Mu: 3, Sigma: 2, Lower: math.Inf(-1), Upper: 1},
+		{Mu: 0, Sigma: 1, Lower: 5, Upper: 6},
+	} {
+		lo := math.Max(tn.Lower, tn.Mu-10*tn.Sigma)
+		hi := math.Min(tn.Upper, tn.Mu+10*tn.Sigma)
+		if total := integrate(tn.Prob, lo, hi, 10000); math.Abs(total-1) > 1e-8 {
+			t.Errorf("Density of %#v does not integrate to 1. Found %v", tn, total)
+		}
+		for _, p := range []float64{0.01, 0.2, 0.5, 0.9, 0.999} {
+			x := tn.Quantile(p)
+			if cdf := tn.CDF(x); math.Abs(cdf-p) > 1e-8 {
+				t.Errorf("CDF(Quantile(%v)) mismatch for %#v. Found %v", p, tn, cdf)
+			}
+			if s := tn.Survival(x); math.Abs(s-(1-p)) > 1e-8 {
+				t.Errorf("Survival(Quantile(%v)) mismatch for %#v. Found %v", p, tn, s)
+			}
+			if cdf := integrate(tn.Prob, lo, x, 10000); math.Abs(cdf-p) > 1e-7 {
+				t.Errorf("Integrated density mismatch at Quantile(%v) for %#v. Found %v", p, tn, cdf)
+			}
+		}
+	}
+}
+
+func TestTruncatedNormalRand(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, tn := range []TruncatedNormal{
+		{Mu: 0, Sigma: 1, Lower: -1, Upper: 2},
+		{Mu: 0, Sigma: 1, Lower: -0.5, Upper: 0.5},
+		{Mu: 0, Sigma: 1, Lower: -10, Upper: 10},
+		{Mu: 3, Sigma: 2, Lower: 4, Upper: math.Inf(1)},
+		{Mu: 3, Sigma: 2, Lower: math.Inf(-1), Upper: 1},
+		// Far in the tails, where naive rejection would almost never
+		// accept a proposal.
+		{Mu: 0, Sigma: 1, Lower: 10, Upper: math.Inf(1)},
+		{Mu: 0, Sigma: 1, Lower: 20, Upper: 20.5},
+		{Mu: 1, Sigma: 0.5, Lower: math.Inf(-1), Upper: -7},
+	} {
+		tn.Source = src
+		x := make([]float64, 100000)
+		for i := range x {
+			x[i] = tn.Rand()
+			if x[i] < tn.Lower || x[i] > tn.Upper {
+				t.Fatalf("Sample %v outside of the support of %#v", x[i], tn)
+			}
+		}
+		checkMeanVariance(t, x, tn.Mean(), tn.Variance(), "TruncatedNormal")
+	}
+}
+
+func TestTruncatedNormalMoments(t *testing.T) {
+	// With infinite bounds the truncated normal is the normal distribution.
+	tn := TruncatedNormal{Mu: 2, Sigma: 3, Lower: math.Inf(-1), Upper: math.Inf(1)}
+	if math.Abs(tn.Mean()-2) > 1e-14 {
+		t.Errorf("Mean mismatch. Expected 2, Found %v", tn.Mean())
+	}
+	if math.Abs(tn.Variance()-9) > 1e-14 {
+		t.Errorf("Variance mismatch. Expected 9, Found %v", tn.Variance())
+	}
+
+	// Compare with the moments computed by integrating the density.
+	tn = TruncatedNormal{Mu: 1, Sigma: 2, Lower: 0, Upper: 4}
+	mean := integrate(func(x float64) float64 { return x * tn.Prob(x) }, 0, 4, 10000)
+	variance := integrate(func(x float64) float64 { return (x - mean) * (x - mean) * tn.Prob(x) }, 0, 4, 10000)
+	if math.Abs(tn.Mean()-mean) > 1e-10 {
+		t.Errorf("Mean mismatch. Expected %v, Found %v", mean, tn.Mean())
+	}
+	if math.Abs(tn.Variance()-variance) > 1e-10 {
+		t.Errorf("Variance mismatch. Expected %v, Found %v", variance, tn.Variance())
+	}
+}
+
+func TestTruncatedNormalFarTail(t *testing.T) {
+	// Beyond about 38 standard deviations the mass of the support underflows,
+	// but the distribution remains well defined.
+	for _, test := range []struct {
+		tn      TruncatedNormal
+		x       float64
+		logProb float64
+		cdf     float64
+		mean    float64
+	}{
+		{
+			tn:      TruncatedNormal{Mu: 0, Sigma: 1, Lower: 40, Upper: math.Inf(1)},
+			x:       40.05,
+			logProb: 3.6895034805491154 - 0.5*0.05*80.05,
+			cdf:     0.865002317137229,
+			mean:    40.02496884720726,
+		},
+		{
+			tn:      TruncatedNormal{Mu: 0, Sigma: 1, Lower: math.Inf(-1), Upper: -45},
+			x:       -45.1,
+			logProb: -math.Log(millsRatio(45)) - 0.5*0.1*90.1,
+			cdf:     0.011029105184550555,
+			mean:    -45.0222003283436,
+		},
+		{
+			tn:      TruncatedNormal{Mu: 2, Sigma: 1, Lower: 52, Upper: 52.1},
+			x:       52,
+			logProb: 3.919136061190477,
+			cdf:     0,
+			mean:    math.NaN(),
+		},
+	} {
+		tn := test.tn
+		if lp := tn.LogProb(test.x); !equalRel(lp, test.logProb, 1e-12) {
+			t.Errorf("LogProb mismatch for %#v. Expected %v, Found %v", tn, test.logProb, lp)
+		}
+		cdf, survival := tn.CDF(test.x), tn.Survival(test.x)
+		if math.Abs(cdf-test.cdf) > 1e-12 {
+			t.Errorf("CDF mismatch for %#v. Expected %v, Found %v", tn, test.cdf, cdf)
+		}
+		if math.Abs(cdf+survival-1) > 1e-12 {
+			t.Errorf("CDF and Survival do not sum to 1 for %#v. Found %v", tn, cdf+survival)
+		}
+		if !math.IsNaN(test.mean) && !equalRel(tn.Mean(), test.mean, 1e-12) {
+			t.Errorf("Mean mismatch for %#v. Expected %v, Found %v", tn, test.mean, tn.Mean())
+		}
+		if v := tn.Variance(); !(v > 0) || v > 1 {
+			t.Errorf("Variance out of range for %#v. Found %v", tn, v)
+		}
+		if total := tn.NormalizationCheck(); math.Abs(total-1) > 1e-8 {
+			t.Errorf("Density of %#v does not integrate to 1. Found %v", tn, total)
+		}
+		for _, p := range []float64{1e-10, 0.01, 0.5, 0.9, 1 - 1e-10} {
+			x := tn.Quantile(p)
+			if x < tn.Lower || x > tn.Upper {
+				t.Errorf("Quantile(%v) of %#v outside of the support. Found %v", p, tn, x)
+				continue
+			}
+			if cdf := tn.CDF(x); math.Abs(cdf-p) > 1e-12 {
+				t.Errorf("CDF(Quantile(%v)) mismatch for %#v. Found %v", p, tn, cdf)
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		z, want float64
+	}{
+		{1, 0.6556795424187985},
+		{5, 0.19280810471531576},
+		{30, 0.033296419072497213},
+		{100, 0.009999000299850105},
+		{math.Inf(1), 0},
+	} {
+		if r := millsRatio(test.z); !equalRel(r, test.want, 1e-14) {
+			t.Errorf("Mills ratio mismatch at %v. Expected %v, Found %v", test.z, test.want, r)
+		}
+	}
+}
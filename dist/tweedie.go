@@ -0,0 +1,212 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"fmt"
+	"math"
+)
+
+// Tweedie represents the Tweedie distribution with power parameter between
+// 1 and 2 (https://en.wikipedia.org/wiki/Tweedie_distribution). The Tweedie
+// distributions are the exponential dispersion models with
+//  Variance = Phi * Mu^Power.
+//
+// For 1 < Power < 2 the distribution is compound Poisson-gamma: the sum of
+// N ~ Poisson(λ) gamma variables with shape α and scale θ, where
+//  λ = Mu^(2-Power) / (Phi*(2-Power))
+//  α = (2-Power) / (Power-1)
+//  θ = Phi*(Power-1)*Mu^(Power-1)
+// It has a point mass of exp(-λ) at zero and a continuous density on (0,+∞),
+// and is used to model data such as insurance claims that are either zero or
+// positive. Prob and LogProb return the probability of zero at x = 0 and
+// the density elsewhere.
+//
+// For Power = 1 the distribution is Phi times a Poisson variable with mean
+// Mu/Phi, and Prob returns the probability mass at multiples of Phi. For
+// Power = 2 it is the gamma distribution with shape 1/Phi and scale Mu*Phi.
+// Other values of Power are not supported.
+type Tweedie struct {
+	Mu     float64 // Mean
+	Phi    float64 // Dispersion
+	Power  float64 // Power of the variance function, in [1, 2]
+	Source Source
+}
+
+// NewTweedie returns a Tweedie distribution with mean mu, dispersion phi and
+// variance power power that samples from src. NewTweedie returns an error if
+// mu or phi is not positive and finite, or if power is not in [1, 2].
+func NewTweedie(mu, phi, power float64, src Source) (Tweedie, error) {
+	t := Tweedie{Mu: mu, Phi: phi, Power: power, Source: src}
+	if err := t.Validate(); err != nil {
+		return Tweedie{}, err
+	}
+	return t, nil
+}
+
+// compound returns the Poisson rate λ and the gamma shape α and scale θ of
+// the compound Poisson-gamma representation.
+func (t Tweedie) compound() (lambda, alpha, theta float64) {
+	p := t.Power
+	lambda = math.Pow(t.Mu, 2-p) / (t.Phi * (2 - p))
+	alpha = (2 - p) / (p - 1)
+	theta = t.Phi * (p - 1) * math.Pow(t.Mu, p-1)
+	return lambda, alpha, theta
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x, or of the probability mass at zero. See the Tweedie
+// documentation for the cases of Power equal to 1 and 2.
+func (t Tweedie) LogProb(x float64) float64 {
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 || math.IsInf(x, 1) {
+		return math.Inf(-1)
+	}
+	switch t.Power {
+	case 1:
+		return Poisson{Lambda: t.Mu / t.Phi}.LogProb(x / t.Phi)
+	case 2:
+		return GeneralizedGamma{A: t.Mu * t.Phi, D: 1 / t.Phi, P: 1}.LogProb(x)
+	}
+	lambda, alpha, theta := t.compound()
+	if x == 0 {
+		return -lambda
+	}
+	// The density is the series over the number of terms n of
+	//  Poisson(n; λ) * Gamma(x; nα, θ).
+	// Sum it outwards from the largest term, which is near
+	//  n = x^(2-Power) / (Phi*(2-Power)),
+	// until the terms are negligible (Dunn and Smyth, Statistics and
+	// Computing 15, 2005).
+	lx := math.Log(x)
+	ll := math.Log(lambda)
+	lt := math.Log(theta)
+	term := func(n int) float64 {
+		fn := float64(n)
+		lg, _ := math.Lgamma(fn * alpha)
+		return -lambda + fn*ll - logFactorial(n) + (fn*alpha-1)*lx - x/theta - fn*alpha*lt - lg
+	}
+	n0 := int(math.Max(1, math.Floor(math.Pow(x, 2-t.Power)/(t.Phi*(2-t.Power))+0.5)))
+	max := term(n0)
+	sum := 1.0
+	const negligible = 37 // exp(-37) < 1e-16
+	for n := n0 - 1; n >= 1; n-- {
+		v := term(n)
+		sum += math.Exp(v - max)
+		if v < max-negligible {
+			break
+		}
+	}
+	for n := n0 + 1; ; n++ {
+		v := term(n)
+		sum += math.Exp(v - max)
+		if v < max-negligible {
+			break
+		}
+	}
+	return max + math.Log(sum)
+}
+
+// MarshalParameters implements the ParameterMarshaler interface
+func (t Tweedie) MarshalParameters(p []Parameter) {
+	if len(p) != t.NumParameters() {
+		panic("tweedie: improper parameter length")
+	}
+	p[0].Name = "Mu"
+	p[0].Value = t.Mu
+	p[1].Name = "Phi"
+	p[1].Value = t.Phi
+	p[2].Name = "Power"
+	p[2].Value = t.Power
+	return
+}
+
+// Mean returns the mean of the probability distribution.
+func (t Tweedie) Mean() float64 {
+	return t.Mu
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Tweedie) NumParameters() int {
+	return 3
+}
+
+// Prob computes the value of the probability density function at x, or the
+// probability mass at zero. See the Tweedie documentation for the cases of
+// Power equal to 1 and 2.
+func (t Tweedie) Prob(x float64) float64 {
+	return math.Exp(t.LogProb(x))
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (t Tweedie) Rand() float64 {
+	switch t.Power {
+	case 1:
+		return t.Phi * Poisson{Lambda: t.Mu / t.Phi, Source: t.Source}.Rand()
+	case 2:
+		return t.Mu * t.Phi * randGamma(1/t.Phi, t.Source)
+	}
+	lambda, alpha, theta := t.compound()
+	n := Poisson{Lambda: lambda, Source: t.Source}.Rand()
+	if n == 0 {
+		return 0
+	}
+	// The sum of n independent gamma variables with shape α is gamma with
+	// shape nα.
+	return theta * randGamma(n*alpha, t.Source)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (t Tweedie) StdDev() float64 {
+	return math.Sqrt(t.Variance())
+}
+
+// String implements the fmt.Stringer interface.
+func (t Tweedie) String() string {
+	return formatParams(&t)
+}
+
+// UnmarshalParameters implements the ParameterMarshaler interface
+func (t *Tweedie) UnmarshalParameters(p []Parameter) {
+	if len(p) != t.NumParameters() {
+		panic("tweedie: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Mu" {
+		panic("tweedie: " + panicNameMismatch)
+	}
+	if p[1].Name != "Phi" {
+		panic("tweedie: " + panicNameMismatch)
+	}
+	if p[2].Name != "Power" {
+		panic("tweedie: " + panicNameMismatch)
+	}
+	t.Mu = p[0].Value
+	t.Phi = p[1].Value
+	t.Power = p[2].Value
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Mu and Phi are positive and
+// finite and Power is in [1, 2].
+func (t Tweedie) Validate() error {
+	err := firstError(
+		checkPositive("tweedie", "Mu", t.Mu),
+		checkPositive("tweedie", "Phi", t.Phi),
+	)
+	if err != nil {
+		return err
+	}
+	if !(t.Power >= 1 && t.Power <= 2) {
+		return fmt.Errorf("tweedie: Power must be in [1, 2], found %v", t.Power)
+	}
+	return nil
+}
+
+// Variance returns the variance of the probability distribution.
+func (t Tweedie) Variance() float64 {
+	return t.Phi * math.Pow(t.Mu, t.Power)
+}
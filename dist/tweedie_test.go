@@ -0,0 +1,100 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTweedieMoments(t *testing.T) {
+	for _, tw := range []Tweedie{
+		{Mu: 1, Phi: 1, Power: 1.5},
+		{Mu: 2, Phi: 0.5, Power: 1.2},
+		{Mu: 0.5, Phi: 2, Power: 1.8},
+		{Mu: 10, Phi: 1, Power: 1.6},
+		{Mu: 3, Phi: 0.5, Power: 1},
+		{Mu: 3, Phi: 0.5, Power: 2},
+	} {
+		tw.Source = rand.New(rand.NewSource(1))
+		x := make([]float64, 100000)
+		for i := range x {
+			x[i] = tw.Rand()
+		}
+		checkMeanVariance(t, x, tw.Mu, tw.Phi*math.Pow(tw.Mu, tw.Power), "Tweedie")
+		if tw.Mean() != tw.Mu {
+			t.Errorf("Mean mismatch for %#v. Expected %v, Found %v", tw, tw.Mu, tw.Mean())
+		}
+		if v := tw.Phi * math.Pow(tw.Mu, tw.Power); !equalRel(tw.Variance(), v, 1e-14) {
+			t.Errorf("Variance mismatch for %#v. Expected %v, Found %v", tw, v, tw.Variance())
+		}
+		if tw.Power == 1 || tw.Power == 2 {
+			continue
+		}
+
+		// The point mass at zero and the density on (0,∞) have total
+		// probability one, mean Mu and variance Phi*Mu^Power. The density may
+		// be unbounded at zero, so integrate over log x.
+		hi := math.Log(tw.Mu + 40*tw.StdDev())
+		lo := -700.0
+		logIntegrate := func(f func(float64) float64) float64 {
+			return integrate(func(u float64) float64 {
+				x := math.Exp(u)
+				return f(x) * tw.Prob(x) * x
+			}, lo, hi, 100000)
+		}
+		mass := tw.Prob(0)
+		total := mass + logIntegrate(func(float64) float64 { return 1 })
+		if math.Abs(total-1) > 1e-6 {
+			t.Errorf("Probability of %#v does not sum to 1. Found %v", tw, total)
+		}
+		mean := logIntegrate(func(x float64) float64 { return x })
+		if !equalRel(mean, tw.Mu, 1e-6) {
+			t.Errorf("Integrated mean mismatch for %#v. Expected %v, Found %v", tw, tw.Mu, mean)
+		}
+		m2 := tw.Mu*tw.Mu*mass + logIntegrate(func(x float64) float64 { return (x - tw.Mu) * (x - tw.Mu) })
+		if !equalRel(m2, tw.Variance(), 1e-6) {
+			t.Errorf("Integrated variance mismatch for %#v. Expected %v, Found %v", tw, tw.Variance(), m2)
+		}
+		var zeros int
+		for _, v := range x {
+			if v == 0 {
+				zeros++
+			}
+		}
+		if p := float64(zeros) / float64(len(x)); math.Abs(p-mass) > 5*math.Sqrt(mass*(1-mass)/float64(len(x))) {
+			t.Errorf("Fraction of zeros mismatch for %#v. Expected %v, Found %v", tw, mass, p)
+		}
+	}
+}
+
+func TestTweedieLimits(t *testing.T) {
+	// Power = 2 is the gamma distribution with shape 1/Phi and scale Mu*Phi.
+	tw := Tweedie{Mu: 3, Phi: 0.5, Power: 2}
+	g := GeneralizedGamma{A: 1.5, D: 2, P: 1}
+	near := Tweedie{Mu: 3, Phi: 0.5, Power: 1.9999}
+	for _, x := range []float64{0.1, 1, 3, 8} {
+		if tw.LogProb(x) != g.LogProb(x) {
+			t.Errorf("Gamma LogProb mismatch at %v. Expected %v, Found %v", x, g.LogProb(x), tw.LogProb(x))
+		}
+		if !equalRel(near.Prob(x), g.Prob(x), 1e-3) {
+			t.Errorf("Power near 2 Prob mismatch at %v. Expected %v, Found %v", x, g.Prob(x), near.Prob(x))
+		}
+	}
+
+	// Power = 1 is Phi times a Poisson variable with mean Mu/Phi.
+	tw = Tweedie{Mu: 3, Phi: 1, Power: 1}
+	p := Poisson{Lambda: 3}
+	for _, x := range []float64{0, 1, 2, 5, 2.5} {
+		if tw.LogProb(x) != p.LogProb(x) {
+			t.Errorf("Poisson LogProb mismatch at %v. Expected %v, Found %v", x, p.LogProb(x), tw.LogProb(x))
+		}
+	}
+	tw.Phi = 0.5
+	if got, want := tw.Prob(1.5), (Poisson{Lambda: 6}).Prob(3); got != want {
+		t.Errorf("Scaled Poisson Prob mismatch. Expected %v, Found %v", want, got)
+	}
+}
@@ -4,20 +4,29 @@
 
 package dist
 
-import (
-	"math"
-	"math/rand"
-)
+import "math"
 
 // Uniform represents a continuous uniform distribution (https://en.wikipedia.org/wiki/Uniform_distribution_%28continuous%29).
 type Uniform struct {
 	Min    float64
 	Max    float64
-	Source *rand.Rand
+	Source Source
+}
+
+// NewUniform returns a uniform distribution over [min, max] that samples from
+// src. NewUniform returns an error if min or max is not finite or if min is
+// not less than max.
+func NewUniform(min, max float64, src Source) (Uniform, error) {
+	u := Uniform{Min: min, Max: max, Source: src}
+	if err := u.Validate(); err != nil {
+		return Uniform{}, err
+	}
+	return u, nil
 }
 
 // CDF computes the value of the cumulative density function at x.
 func (u Uniform) CDF(x float64) float64 {
+	x = snapToSupport(x, u.Min, u.Max)
 	if x < u.Min {
 		return 0
 	}
@@ -27,6 +36,26 @@ func (u Uniform) CDF(x float64) float64 {
 	return (x - u.Min) / (u.Max - u.Min)
 }
 
+// CDFSlice returns the value of the cumulative density function at each of
+// the values in xs. See CDFSliceTo.
+func (u Uniform) CDFSlice(xs []float64) []float64 {
+	return u.CDFSliceTo(make([]float64, len(xs)), xs)
+}
+
+// CDFSliceTo stores in dst the value of the cumulative density function at
+// each of the values in xs, and returns dst.
+// CDFSliceTo panics if len(dst) != len(xs).
+func (u Uniform) CDFSliceTo(dst, xs []float64) []float64 {
+	if len(dst) != len(xs) {
+		panic("dist: slice length mismatch")
+	}
+	for i, x := range xs {
+		x = snapToSupport(x, u.Min, u.Max)
+		dst[i] = u.CDF(x)
+	}
+	return dst
+}
+
 // Uniform doesn't have any of the DLogProbD? because the derivative is 0 everywhere
 // except where it's undefined
 
@@ -42,8 +71,32 @@ func (Uniform) ExKurtosis() float64 {
 
 // Uniform doesn't have Fit because it's a bad idea to fit a uniform from data.
 
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with n intervals.
+func (u Uniform) IntegrateProb(lo, hi float64, n int) float64 {
+	return integrateProb(u, lo, hi, n)
+}
+
+// InverseSurvival returns the inverse of the survival function.
+func (u Uniform) InverseSurvival(q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		panic("dist: percentile out of bounds")
+	}
+	if q == 0 {
+		return u.Max
+	}
+	return u.Max - q*(u.Max-u.Min)
+}
+
 // LogProb computes the natural logarithm of the value of the probability density function at x.
 func (u Uniform) LogProb(x float64) float64 {
+	x = snapToSupport(x, u.Min, u.Max)
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < u.Min || x > u.Max {
+		return math.Inf(-1)
+	}
 	return -math.Log(u.Max - u.Min)
 }
 
@@ -61,16 +114,40 @@ func (u Uniform) MarshalParameters(p []Parameter) {
 
 // Mean returns the mean of the probability distribution.
 func (u Uniform) Mean() float64 {
-	return (u.Max - u.Min) / 2
+	return (u.Max + u.Min) / 2
 }
 
 // Median returns the median of the probability distribution.
 func (u Uniform) Median() float64 {
-	return (u.Max - u.Min) / 2
+	return (u.Max + u.Min) / 2
 }
 
 // Uniform doesn't have a mode because it's any value in the distribution
 
+// Moment returns the k-th raw moment E[X^k] of the distribution, or the k-th
+// central moment E[(X-μ)^k] if central is true. The moments are computed
+// in closed form: the central moments are 0 for odd k and ((Max-Min)/2)^k/(k+1)
+// for even k. Moment panics if k is negative.
+func (u Uniform) Moment(k int, central bool) float64 {
+	checkMomentOrder(k)
+	c := func(j int) float64 {
+		if j%2 != 0 {
+			return 0
+		}
+		return math.Pow((u.Max-u.Min)/2, float64(j)) / float64(j+1)
+	}
+	if central {
+		return c(k)
+	}
+	return rawFromCentral(k, u.Mean(), c)
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (u Uniform) NormalizationCheck() float64 {
+	return normalization(u)
+}
+
 // NumParameters returns the number of parameters in the distribution.
 func (Uniform) NumParameters() int {
 	return 2
@@ -78,28 +155,68 @@ func (Uniform) NumParameters() int {
 
 // Prob computes the value of the probability density function at x.
 func (u Uniform) Prob(x float64) float64 {
+	x = snapToSupport(x, u.Min, u.Max)
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < u.Min || x > u.Max {
+		return 0
+	}
 	return 1 / (u.Max - u.Min)
 }
 
+// ProbSlice returns the value of the probability density function at each
+// of the values in xs. See ProbSliceTo.
+func (u Uniform) ProbSlice(xs []float64) []float64 {
+	return u.ProbSliceTo(make([]float64, len(xs)), xs)
+}
+
+// ProbSliceTo stores in dst the value of the probability density function at
+// each of the values in xs, and returns dst.
+// ProbSliceTo panics if len(dst) != len(xs).
+func (u Uniform) ProbSliceTo(dst, xs []float64) []float64 {
+	if len(dst) != len(xs) {
+		panic("dist: slice length mismatch")
+	}
+	p := 1 / (u.Max - u.Min)
+	for i, x := range xs {
+		x = snapToSupport(x, u.Min, u.Max)
+		switch {
+		case math.IsNaN(x):
+			dst[i] = math.NaN()
+		case x < u.Min || x > u.Max:
+			dst[i] = 0
+		default:
+			dst[i] = p
+		}
+	}
+	return dst
+}
+
 // Quantile returns the inverse of the cumulative probability distribution.
+// Quantile(0) is Min and Quantile(1) is Max, the bounds of the support.
 func (u Uniform) Quantile(p float64) float64 {
-	if p < 0 || p > 1 {
+	if !(p >= 0 && p <= 1) {
 		panic("dist: percentile out of bounds")
 	}
+	if p == 1 {
+		return u.Max
+	}
 	return p*(u.Max-u.Min) + u.Min
 }
 
 // Rand returns a random sample drawn from the distribution.
 func (u Uniform) Rand() float64 {
-	var rnd float64
-	if u.Source == nil {
-		rnd = rand.Float64()
-	} else {
-		rnd = u.Source.Float64()
-	}
+	rnd := randFloat64(u.Source)
 	return rnd*(u.Max-u.Min) + u.Min
 }
 
+// RandAntithetic returns n samples drawn from the distribution in antithetic
+// pairs, as described for randAntithetic.
+func (u Uniform) RandAntithetic(n int) []float64 {
+	return randAntithetic(u, n, u.Source)
+}
+
 // Skewness returns the skewness of the distribution.
 func (Uniform) Skewness() float64 {
 	return 0
@@ -110,8 +227,24 @@ func (u Uniform) StdDev() float64 {
 	return math.Sqrt(u.Variance())
 }
 
+// StratifiedSample returns n stratified samples from the distribution, as
+// described for stratifiedSample, drawing uniforms from src or, if src is nil,
+// from the Source of the distribution.
+func (u Uniform) StratifiedSample(n int, src Source) []float64 {
+	if src == nil {
+		src = u.Source
+	}
+	return stratifiedSample(u, n, src)
+}
+
+// String implements the fmt.Stringer interface.
+func (u Uniform) String() string {
+	return formatParams(&u)
+}
+
 // Survival returns the survival function (complementary CDF) at x.
 func (u Uniform) Survival(x float64) float64 {
+	x = snapToSupport(x, u.Min, u.Max)
 	if x < u.Min {
 		return 1
 	}
@@ -137,6 +270,17 @@ func (u *Uniform) UnmarshalParameters(p []Parameter) {
 	u.Max = p[1].Value
 }
 
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if Min and Max are finite and Min
+// is less than Max.
+func (u Uniform) Validate() error {
+	return firstError(
+		checkFinite("uniform", "Min", u.Min),
+		checkFinite("uniform", "Max", u.Max),
+		checkOrdered("uniform", "Min", "Max", u.Min, u.Max),
+	)
+}
+
 // Variance returns the variance of the probability distribution.
 func (u Uniform) Variance() float64 {
 	return 1.0 / 12.0 * (u.Max - u.Min) * (u.Max - u.Min)
@@ -0,0 +1,60 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"fmt"
+	"math"
+)
+
+// checkPositive returns an error if v is not a finite positive number.
+func checkPositive(dist, name string, v float64) error {
+	if !(v > 0) || math.IsInf(v, 1) {
+		return fmt.Errorf("%s: %s must be positive and finite, found %v", dist, name, v)
+	}
+	return nil
+}
+
+// checkNonNegative returns an error if v is not a finite non-negative number.
+func checkNonNegative(dist, name string, v float64) error {
+	if !(v >= 0) || math.IsInf(v, 1) {
+		return fmt.Errorf("%s: %s must be non-negative and finite, found %v", dist, name, v)
+	}
+	return nil
+}
+
+// checkFinite returns an error if v is not a finite number.
+func checkFinite(dist, name string, v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Errorf("%s: %s must be finite, found %v", dist, name, v)
+	}
+	return nil
+}
+
+// checkProbability returns an error if v is not in [0, 1].
+func checkProbability(dist, name string, v float64) error {
+	if !(v >= 0 && v <= 1) {
+		return fmt.Errorf("%s: %s must be in [0, 1], found %v", dist, name, v)
+	}
+	return nil
+}
+
+// checkOrdered returns an error if lo is not less than hi, or if either is NaN.
+func checkOrdered(dist, loName, hiName string, lo, hi float64) error {
+	if !(lo < hi) {
+		return fmt.Errorf("%s: %s must be less than %s, found %v and %v", dist, loName, hiName, lo, hi)
+	}
+	return nil
+}
+
+// firstError returns the first non-nil error in errs.
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
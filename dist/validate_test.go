@@ -0,0 +1,165 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestConstructorsInvalid(t *testing.T) {
+	nan := math.NaN()
+	inf := math.Inf(1)
+	for i, test := range []struct {
+		name string
+		f    func() error
+	}{
+		{"Weibull K", func() error { _, err := NewWeibull(0, 1, nil); return err }},
+		{"Weibull λ", func() error { _, err := NewWeibull(1, -1, nil); return err }},
+		{"Weibull NaN", func() error { _, err := NewWeibull(nan, 1, nil); return err }},
+		{"Normal Mu", func() error { _, err := NewNormal(inf, 1, nil); return err }},
+		{"Normal Sigma", func() error { _, err := NewNormal(0, 0, nil); return err }},
+		{"Exponential", func() error { _, err := NewExponential(-2, nil); return err }},
+		{"Laplace", func() error { _, err := NewLaplace(0, nan, nil); return err }},
+		{"Uniform order", func() error { _, err := NewUniform(1, 1, nil); return err }},
+		{"Uniform inf", func() error { _, err := NewUniform(0, inf, nil); return err }},
+		{"Poisson", func() error { _, err := NewPoisson(0, nil); return err }},
+		{"Binomial N", func() error { _, err := NewBinomial(2.5, 0.5, nil); return err }},
+		{"Binomial P", func() error { _, err := NewBinomial(10, 1.5, nil); return err }},
+		{"TruncatedNormal", func() error { _, err := NewTruncatedNormal(0, 1, 2, -2, nil); return err }},
+		{"HalfNormal", func() error { _, err := NewHalfNormal(inf, nil); return err }},
+		{"FoldedNormal", func() error { _, err := NewFoldedNormal(0, -1, nil); return err }},
+		{"GeneralizedGamma", func() error { _, err := NewGeneralizedGamma(1, 1, 0, nil); return err }},
+		{"InverseGamma", func() error { _, err := NewInverseGamma(0, 1, nil); return err }},
+		{"Kumaraswamy", func() error { _, err := NewKumaraswamy(1, -1, nil); return err }},
+	} {
+		if err := test.f(); err == nil {
+			t.Errorf("%d: expected error for invalid %s", i, test.name)
+		}
+	}
+}
+
+func TestConstructorsValid(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	w, err := NewWeibull(1.5, 2, src)
+	if err != nil || w != (Weibull{K: 1.5, Lambda: 2, Source: src}) {
+		t.Errorf("Weibull mismatch: %v, %v", w, err)
+	}
+	n, err := NewNormal(-1, 3, src)
+	if err != nil || n != (Normal{Mu: -1, Sigma: 3, Source: src}) {
+		t.Errorf("Normal mismatch: %v, %v", n, err)
+	}
+	e, err := NewExponential(4, src)
+	if err != nil || e != (Exponential{Rate: 4, Source: src}) {
+		t.Errorf("Exponential mismatch: %v, %v", e, err)
+	}
+	l, err := NewLaplace(1, 2, src)
+	if err != nil || l != (Laplace{Mu: 1, Scale: 2, Source: src}) {
+		t.Errorf("Laplace mismatch: %v, %v", l, err)
+	}
+	u, err := NewUniform(-1, 1, src)
+	if err != nil || u != (Uniform{Min: -1, Max: 1, Source: src}) {
+		t.Errorf("Uniform mismatch: %v, %v", u, err)
+	}
+	p, err := NewPoisson(3, src)
+	if err != nil || p != (Poisson{Lambda: 3, Source: src}) {
+		t.Errorf("Poisson mismatch: %v, %v", p, err)
+	}
+	b, err := NewBinomial(10, 0, src)
+	if err != nil || b != (Binomial{N: 10, P: 0, Source: src}) {
+		t.Errorf("Binomial mismatch: %v, %v", b, err)
+	}
+	tn, err := NewTruncatedNormal(0, 1, math.Inf(-1), 2, src)
+	if err != nil || tn != (TruncatedNormal{Mu: 0, Sigma: 1, Lower: math.Inf(-1), Upper: 2, Source: src}) {
+		t.Errorf("TruncatedNormal mismatch: %v, %v", tn, err)
+	}
+	h, err := NewHalfNormal(2, src)
+	if err != nil || h != (HalfNormal{Sigma: 2, Source: src}) {
+		t.Errorf("HalfNormal mismatch: %v, %v", h, err)
+	}
+	f, err := NewFoldedNormal(1, 2, src)
+	if err != nil || f != (FoldedNormal{Mu: 1, Sigma: 2, Source: src}) {
+		t.Errorf("FoldedNormal mismatch: %v, %v", f, err)
+	}
+	g, err := NewGeneralizedGamma(1, 2, 3, src)
+	if err != nil || g != (GeneralizedGamma{A: 1, D: 2, P: 3, Source: src}) {
+		t.Errorf("GeneralizedGamma mismatch: %v, %v", g, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	nan := math.NaN()
+	for i, test := range []struct {
+		v     Validator
+		param string
+	}{
+		{Weibull{K: 0, Lambda: 1}, "K"},
+		{Weibull{K: 1, Lambda: -1}, "λ"},
+		{Weibull{K: nan, Lambda: 1}, "K"},
+		{Weibull{K: 1, Lambda: nan}, "λ"},
+		{Normal{Mu: nan, Sigma: 1}, "Mu"},
+		{Normal{Mu: 0, Sigma: 0}, "Sigma"},
+		{Exponential{Rate: nan}, "Rate"},
+		{Laplace{Mu: math.Inf(-1), Scale: 1}, "Mu"},
+		{Laplace{Mu: 0, Scale: -1}, "Scale"},
+		{Uniform{Min: nan, Max: 1}, "Min"},
+		{Uniform{Min: 0, Max: math.Inf(1)}, "Max"},
+		{Uniform{Min: 2, Max: 1}, "Min"},
+		{Poisson{Lambda: -1}, "Lambda"},
+		{Binomial{N: -1, P: 0.5}, "N"},
+		{Binomial{N: 5, P: nan}, "P"},
+		{TruncatedNormal{Mu: 0, Sigma: nan, Lower: -1, Upper: 1}, "Sigma"},
+		{TruncatedNormal{Mu: 0, Sigma: 1, Lower: 1, Upper: 1}, "Lower"},
+		{HalfNormal{Sigma: 0}, "Sigma"},
+		{FoldedNormal{Mu: nan, Sigma: 1}, "Mu"},
+		{GeneralizedGamma{A: 1, D: -1, P: 1}, "D"},
+		{GeneralizedGamma{A: 1, D: 1, P: nan}, "P"},
+		{Rician{Nu: -1, Sigma: 1}, "Nu"},
+		{Rician{Nu: 1, Sigma: 0}, "Sigma"},
+		{Burr{C: 0, K: 1, Lambda: 1}, "C"},
+		{Burr{C: 1, K: 1, Lambda: nan}, "λ"},
+		{LogLogistic{Alpha: -1, Beta: 1}, "Alpha"},
+		{LogLogistic{Alpha: 1, Beta: 0}, "Beta"},
+		{PearsonIII{Mu: 0, Sigma: 1, Gamma: nan}, "Gamma"},
+		{Tweedie{Mu: 1, Phi: 1, Power: 2.5}, "Power"},
+		{Gamma{Alpha: 0, Beta: 1}, "Alpha"},
+		{Gamma{Alpha: 1, Beta: math.Inf(1)}, "Beta"},
+		{InverseGamma{Alpha: nan, Beta: 1}, "Alpha"},
+		{InverseGamma{Alpha: 1, Beta: 0}, "Beta"},
+		{Kumaraswamy{A: 0, B: 1}, "A"},
+		{Kumaraswamy{A: 1, B: nan}, "B"},
+		{GeneralizedExtremeValue{Mu: nan, Sigma: 1, Xi: 0}, "Mu"},
+		{GeneralizedExtremeValue{Mu: 0, Sigma: -1, Xi: 0}, "Sigma"},
+		{GeneralizedExtremeValue{Mu: 0, Sigma: 1, Xi: nan}, "Xi"},
+		{GeneralizedPareto{Mu: nan, Sigma: 1, Xi: 0}, "Mu"},
+		{GeneralizedPareto{Mu: 0, Sigma: 0, Xi: 0}, "Sigma"},
+		{GeneralizedPareto{Mu: 0, Sigma: 1, Xi: math.Inf(-1)}, "Xi"},
+		{Tweedie{Mu: 0, Phi: 1, Power: 1.5}, "Mu"},
+		{Erlang{K: 0, Lambda: 1}, "K"},
+		{Erlang{K: 2, Lambda: nan}, "λ"},
+	} {
+		err := test.v.Validate()
+		if err == nil {
+			t.Errorf("%d: expected error for %#v", i, test.v)
+			continue
+		}
+		if !strings.Contains(err.Error(), " "+test.param+" ") {
+			t.Errorf("%d: error %q does not name parameter %s", i, err, test.param)
+		}
+	}
+	for i, v := range []Validator{
+		Weibull{K: 1, Lambda: 1},
+		Normal{Mu: 0, Sigma: 1},
+		Uniform{Min: -1, Max: 1},
+		Binomial{N: 0, P: 1},
+		TruncatedNormal{Mu: 0, Sigma: 1, Lower: math.Inf(-1), Upper: math.Inf(1)},
+	} {
+		if err := v.Validate(); err != nil {
+			t.Errorf("%d: unexpected error %v", i, err)
+		}
+	}
+}
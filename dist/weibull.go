@@ -7,7 +7,6 @@ package dist
 import (
 	"math"
 	"math/cmplx"
-	"math/rand"
 )
 
 // Weibull distribution. Valid range for x is [0,+∞).
@@ -19,11 +18,60 @@ type Weibull struct {
 	// Scale parameter of the distribution. Valid range is (0,+∞).
 	Lambda float64
 	// Source of random numbers
-	Source *rand.Rand
+	Source Source
+}
+
+// NewWeibull returns a Weibull distribution with shape k and scale lambda
+// that samples from src. NewWeibull returns an error if k or lambda is not
+// positive and finite.
+func NewWeibull(k, lambda float64, src Source) (Weibull, error) {
+	w := Weibull{K: k, Lambda: lambda, Source: src}
+	if err := w.Validate(); err != nil {
+		return Weibull{}, err
+	}
+	return w, nil
+}
+
+// NewWeibullRate returns a Weibull distribution with shape k and rate beta
+// that samples from src, for the parameterization
+//  CDF(x) = 1 - exp(-(βx)^k)
+// used in some texts. The rate is the reciprocal of the scale, so the result
+// has Lambda = 1/beta. NewWeibullRate returns an error if k or beta is not
+// positive and finite.
+func NewWeibullRate(k, beta float64, src Source) (Weibull, error) {
+	if err := checkPositive("weibull", "Beta", beta); err != nil {
+		return Weibull{}, err
+	}
+	return NewWeibull(k, 1/beta, src)
+}
+
+// WeibullFromQuantiles returns the Weibull distribution with Quantile(p1) = x1
+// and Quantile(p2) = x2, for example from the B10 and B50 lives of a
+// component. Taking logs twice of the CDF gives
+//  log(-log(1-p)) = K (log x - log λ)
+// which is linear in log x, so the two points determine K and λ.
+//
+// WeibullFromQuantiles panics if p1 or p2 is not in (0, 1), if x1 or x2 is
+// not positive, or if the larger percentile does not have the larger value.
+func WeibullFromQuantiles(p1, x1, p2, x2 float64) Weibull {
+	if !(p1 > 0 && p1 < 1 && p2 > 0 && p2 < 1) {
+		panic("weibull: percentile out of bounds")
+	}
+	if !(x1 > 0 && x2 > 0) {
+		panic("weibull: non-positive quantile")
+	}
+	if !((p1 < p2 && x1 < x2) || (p1 > p2 && x1 > x2)) {
+		panic("weibull: quantiles not increasing")
+	}
+	y1 := math.Log(-math.Log1p(-p1))
+	y2 := math.Log(-math.Log1p(-p2))
+	k := (y2 - y1) / (math.Log(x2) - math.Log(x1))
+	return Weibull{K: k, Lambda: x1 * math.Exp(-y1/k)}
 }
 
 // CDF computes the value of the cumulative density function at x.
 func (w Weibull) CDF(x float64) float64 {
+	x = snapNonNegative(x)
 	if x < 0 {
 		return 0
 	} else {
@@ -31,6 +79,71 @@ func (w Weibull) CDF(x float64) float64 {
 	}
 }
 
+// CDFSlice returns the value of the cumulative density function at each of
+// the values in xs. See CDFSliceTo.
+func (w Weibull) CDFSlice(xs []float64) []float64 {
+	return w.CDFSliceTo(make([]float64, len(xs)), xs)
+}
+
+// CDFSliceTo stores in dst the value of the cumulative density function at
+// each of the values in xs, and returns dst. The reciprocal of the
+// scale is computed once for the whole slice.
+// CDFSliceTo panics if len(dst) != len(xs).
+func (w Weibull) CDFSliceTo(dst, xs []float64) []float64 {
+	if len(dst) != len(xs) {
+		panic("dist: slice length mismatch")
+	}
+	invLambda := 1 / w.Lambda
+	for i, x := range xs {
+		x = snapNonNegative(x)
+		if x < 0 {
+			dst[i] = 0
+			continue
+		}
+		dst[i] = 1 - math.Exp(-math.Pow(x*invLambda, w.K))
+	}
+	return dst
+}
+
+// CumHazard returns the cumulative hazard function -log(Survival(x)) at x,
+// which is (x/λ)^K for x ≥ 0.
+func (w Weibull) CumHazard(x float64) float64 {
+	return -w.LogSurvival(x)
+}
+
+// DLogProbDParamSlice stores in grads[i] the derivative of the log of the
+// probability at xs[i] with respect to the parameters, in the order of
+// DLogProbDParam. The logarithm of λ is computed once for the whole slice and
+// (x/λ)^K once per sample. DLogProbDParamSlice panics if len(grads) != len(xs)
+// or if a row of grads does not have length equal to the number of
+// parameters.
+func (w Weibull) DLogProbDParamSlice(xs []float64, grads [][]float64) {
+	if len(grads) != len(xs) {
+		panic("dist: slice length mismatch")
+	}
+	logLambda := math.Log(w.Lambda)
+	invK := 1 / w.K
+	for i, x := range xs {
+		deriv := grads[i]
+		if len(deriv) != w.NumParameters() {
+			panic("weibull: slice length mismatch")
+		}
+		switch {
+		case x > 0:
+			l := math.Log(x) - logLambda
+			z := math.Pow(x/w.Lambda, w.K)
+			deriv[0] = invK + l - l*z
+			deriv[1] = w.K * (z - 1) / w.Lambda
+		case x < 0:
+			deriv[0] = 0
+			deriv[1] = 0
+		default:
+			deriv[0] = math.NaN()
+			deriv[1] = math.NaN()
+		}
+	}
+}
+
 // DLogProbDX returns the derivative of the log of the probability with
 // respect to the input x.
 //
@@ -69,10 +182,38 @@ func (w Weibull) DLogProbDParam(x float64, deriv []float64) {
 		return
 	}
 	deriv[0] = math.NaN()
-	deriv[0] = math.NaN()
+	deriv[1] = math.NaN()
 	return
 }
 
+// DemonstrationTestPlan returns the number of units that must each run for
+// testTime without a failure to demonstrate, at the given confidence level,
+// that the reliability at the mission time is at least reliability. testTime
+// is measured in units of the mission time, so a test as long as the mission
+// has testTime 1. This is the extended success-run formula
+//  n = log(1 - confidence) / (testTime^K log(reliability)),
+// rounded up, which assumes that the failure times are Weibull with the shape
+// K of w; the scale λ is what the test bounds and is not used. A test longer
+// than the mission needs fewer units, by the factor testTime^K. For testTime
+// 1 it is the binomial success-run formula, which does not depend on K.
+//
+// DemonstrationTestPlan panics if confidence or reliability is not in (0, 1)
+// or if testTime is not positive and finite.
+func (w Weibull) DemonstrationTestPlan(confidence, reliability float64, testTime float64) (nUnits int) {
+	if !(confidence > 0 && confidence < 1) {
+		panic("weibull: confidence out of range")
+	}
+	if !(reliability > 0 && reliability < 1) {
+		panic("weibull: reliability out of range")
+	}
+	if !(testTime > 0) || math.IsInf(testTime, 1) {
+		panic("weibull: test time not positive and finite")
+	}
+	n := math.Log1p(-confidence) / (math.Pow(testTime, w.K) * math.Log(reliability))
+	// Remove the rounding error of an exact integer before rounding up.
+	return int(math.Ceil(n * (1 - 1e-12)))
+}
+
 // Entropy returns the entropy of the distribution.
 func (w Weibull) Entropy() float64 {
 	return eulerGamma*(1-1/w.K) + math.Log(w.Lambda/w.K) + 1
@@ -80,16 +221,159 @@ func (w Weibull) Entropy() float64 {
 
 // ExKurtosis returns the excess kurtosis of the distribution.
 func (w Weibull) ExKurtosis() float64 {
-	return (-6*w.gammaIPow(1, 4) + 12*w.gammaIPow(1, 2)*math.Gamma(1+2/w.K) - 3*w.gammaIPow(2, 2) - 4*math.Gamma(1+1/w.K)*math.Gamma(1+3/w.K) + math.Gamma(1+4/w.K)) / math.Pow(math.Gamma(1+2/w.K)-w.gammaIPow(1, 2), 2)
+	g1, g2, g3, g4 := w.gammaTerms()
+	v := g2 - g1*g1
+	return (-6*g1*g1*g1*g1 + 12*g1*g1*g2 - 3*g2*g2 - 4*g1*g3 + g4) / (v * v)
+}
+
+// gammaTerms returns Γ(1+i/K) for i = 1, 2, 3, 4, the terms from which the
+// moments of the distribution are computed.
+func (w Weibull) gammaTerms() (g1, g2, g3, g4 float64) {
+	return math.Gamma(1 + 1/w.K), math.Gamma(1 + 2/w.K), math.Gamma(1 + 3/w.K), math.Gamma(1 + 4/w.K)
+}
+
+// FitInterval sets the parameters of the distribution to the maximum
+// likelihood estimates from interval-censored observations, where
+// observation i is only known to lie in [lower[i], upper[i]]. See
+// FitIntervalMLE for the likelihood. Exact observations are given by equal
+// bounds, right-censored ones by an upper bound of +Inf and left-censored
+// ones by a lower bound of 0. If weights is nil, then all the weights are 1.
+//
+// The maximization starts from K = 1 and λ equal to the weighted mean of the
+// interval midpoints, with the lower bound used for right-censored
+// observations. FitInterval panics if the lengths of lower, upper and a
+// non-nil weights differ, or if lower[i] > upper[i] for some i.
+func (w *Weibull) FitInterval(lower, upper, weights []float64) {
+	if len(lower) != len(upper) || (weights != nil && len(weights) != len(lower)) {
+		panic("dist: slice length mismatch")
+	}
+	var sum, sumWeights float64
+	for i, l := range lower {
+		x := math.Max(l, 0)
+		if !math.IsInf(upper[i], 1) {
+			x = (x + upper[i]) / 2
+		}
+		wt := 1.0
+		if weights != nil {
+			wt = weights[i]
+		}
+		sum += wt * x
+		sumWeights += wt
+	}
+	w.K = 1
+	w.Lambda = sum / sumWeights
+	FitIntervalMLE(w, lower, upper, weights)
+}
+
+// FitLeftCensored sets the parameters of the distribution to the maximum
+// likelihood estimates from observations with a detection limit, where the
+// observations with belowLimit[i] true are only known to be below limit. See
+// the FitLeftCensored function for the likelihood. If weights is nil, then
+// all the weights are 1.
+//
+// The maximization starts from K = 1 and λ equal to the weighted mean of the
+// values with limit/2 substituted for the non-detects. FitLeftCensored panics
+// if the lengths of values, belowLimit and a non-nil weights differ.
+func (w *Weibull) FitLeftCensored(values []float64, belowLimit []bool, limit float64, weights []float64) {
+	if len(values) != len(belowLimit) || (weights != nil && len(weights) != len(values)) {
+		panic("dist: slice length mismatch")
+	}
+	var sum, sumWeights float64
+	for i, x := range values {
+		if belowLimit[i] {
+			x = limit / 2
+		}
+		wt := 1.0
+		if weights != nil {
+			wt = weights[i]
+		}
+		sum += wt * x
+		sumWeights += wt
+	}
+	w.K = 1
+	w.Lambda = sum / sumWeights
+	FitLeftCensored(w, values, belowLimit, limit, weights)
+}
+
+// FitLeftTruncated sets the parameters of the distribution to the maximum
+// likelihood estimates from samples that could only be observed above the
+// truncation point, as with failures recorded only after a unit enters
+// observation or concentrations above a detection threshold with the
+// quantity below it never reported. Each sample contributes
+//  LogProb(x_i) - LogSurvival(truncation)
+// to the log-likelihood, the density conditional on exceeding the
+// truncation point. Unlike censoring, nothing is known about the number of
+// values below the point. If weights is nil, then all the weights are 1.
+//
+// The maximization starts from K = 1 and λ equal to the weighted mean excess
+// over the truncation point, which is the estimate for an exponential.
+// FitLeftTruncated panics if a non-nil weights has a different length than
+// samples, if truncation is negative or not finite, or if a sample is below
+// the truncation point.
+func (w *Weibull) FitLeftTruncated(samples []float64, truncation float64, weights []float64) {
+	if weights != nil && len(weights) != len(samples) {
+		panic("dist: slice length mismatch")
+	}
+	if !(truncation >= 0) || math.IsInf(truncation, 1) {
+		panic("weibull: truncation not non-negative and finite")
+	}
+	var sum, sumWeights float64
+	for i, x := range samples {
+		if !(x >= truncation) {
+			panic("weibull: sample below truncation point")
+		}
+		wt := 1.0
+		if weights != nil {
+			wt = weights[i]
+		}
+		sum += wt * (x - truncation)
+		sumWeights += wt
+	}
+	w.K = 1
+	w.Lambda = sum / sumWeights
+	maximize(w, func() float64 {
+		return logLikelihood(w, samples, weights) - sumWeights*w.LogSurvival(truncation)
+	}, nil, nil)
+}
+
+// FitScaleOnly sets K to the known shape k and λ to its maximum likelihood
+// estimate given k,
+//  λ = (Σ w_i x_i^k / Σ w_i)^(1/k),
+// for example when the shape is known from the failure mechanism. Since
+// (x/λ)^k is exponential with unit mean, λ^k is estimated without bias. If
+// weights is nil, then all the weights are 1. FitScaleOnly panics if a
+// non-nil weights has a different length than samples.
+func (w *Weibull) FitScaleOnly(samples, weights []float64, k float64) {
+	if weights != nil && len(weights) != len(samples) {
+		panic("dist: slice length mismatch")
+	}
+	var sum, sumWeights float64
+	for i, x := range samples {
+		wt := 1.0
+		if weights != nil {
+			wt = weights[i]
+		}
+		sum += wt * math.Pow(x, k)
+		sumWeights += wt
+	}
+	w.K = k
+	w.Lambda = math.Pow(sum/sumWeights, 1/k)
 }
 
-// gammIPow is a shortcut for computing the gamma function to a power.
-func (w Weibull) gammaIPow(i, pow float64) float64 {
-	return math.Pow(math.Gamma(1+i/w.K), pow)
+// IntegrateProb returns the integral of Prob over [lo, hi], which may be
+// infinite, computed by integrateProb with n intervals.
+func (w Weibull) IntegrateProb(lo, hi float64, n int) float64 {
+	return integrateProb(w, lo, hi, n)
+}
+
+// InverseSurvival returns the inverse of the survival function.
+func (w Weibull) InverseSurvival(q float64) float64 {
+	return w.QuantileUpper(q)
 }
 
 // LogCDF computes the value of the log of the cumulative density function at x.
 func (w Weibull) LogCDF(x float64) complex128 {
+	x = snapNonNegative(x)
 	if x < 0 {
 		return 0
 	} else {
@@ -98,7 +382,7 @@ func (w Weibull) LogCDF(x float64) complex128 {
 }
 
 // LogProb computes the natural logarithm of the value of the probability
-// density function at x. Zero is returned if x is less than zero.
+// density function at x. -Inf is returned if x is less than zero.
 //
 // Special cases occur when x == 0, and the result depends on the shape
 // parameter as follows:
@@ -106,15 +390,45 @@ func (w Weibull) LogCDF(x float64) complex128 {
 //  If K == 1, LogProb returns 0.
 //  If K > 1, LogProb returns -Inf.
 func (w Weibull) LogProb(x float64) float64 {
-	if x < 0 {
-		return 0
+	x = snapNonNegative(x)
+	if x < 0 || math.IsInf(x, 1) {
+		return math.Inf(-1)
 	} else {
 		return math.Log(w.K) - math.Log(w.Lambda) + (w.K-1)*(math.Log(x)-math.Log(w.Lambda)) - math.Pow(x/w.Lambda, w.K)
 	}
 }
 
+// LogProbSlice returns the log of the probability density function at each
+// of the values in xs. See LogProbSliceTo.
+func (w Weibull) LogProbSlice(xs []float64) []float64 {
+	return w.LogProbSliceTo(make([]float64, len(xs)), xs)
+}
+
+// LogProbSliceTo stores in dst the log of the probability density function
+// at each of the values in xs, and returns dst. The logarithms of the
+// parameters are computed once for the whole slice, so evaluating many points
+// is cheaper than calling LogProb for each, and the results are identical.
+// LogProbSliceTo panics if len(dst) != len(xs).
+func (w Weibull) LogProbSliceTo(dst, xs []float64) []float64 {
+	if len(dst) != len(xs) {
+		panic("dist: slice length mismatch")
+	}
+	logLambda := math.Log(w.Lambda)
+	logNorm := math.Log(w.K) - logLambda
+	for i, x := range xs {
+		x = snapNonNegative(x)
+		if x < 0 || math.IsInf(x, 1) {
+			dst[i] = math.Inf(-1)
+			continue
+		}
+		dst[i] = logNorm + (w.K-1)*(math.Log(x)-logLambda) - math.Pow(x/w.Lambda, w.K)
+	}
+	return dst
+}
+
 // Survival returns the log of the survival function (complementary CDF) at x.
 func (w Weibull) LogSurvival(x float64) float64 {
+	x = snapNonNegative(x)
 	if x < 0 {
 		return 0
 	} else {
@@ -159,6 +473,40 @@ func (w Weibull) Mode() float64 {
 	}
 }
 
+// Moment returns the k-th raw moment E[X^k] of the distribution, or the k-th
+// central moment E[(X-μ)^k] if central is true. The raw moments are
+// λ^k Γ(1+k/K), and the central moments are computed from them. Moment
+// panics if k is negative.
+func (w Weibull) Moment(k int, central bool) float64 {
+	checkMomentOrder(k)
+	raw := func(j int) float64 {
+		return math.Pow(w.Lambda, float64(j)) * math.Gamma(1+float64(j)/w.K)
+	}
+	if central {
+		return centralFromRaw(k, raw)
+	}
+	return raw(k)
+}
+
+// Moments returns the mean, variance, skewness and excess kurtosis of the
+// distribution. The gamma function terms shared by the moments are computed
+// once, so Moments is cheaper than calling the individual methods.
+func (w Weibull) Moments() (mean, variance, skewness, exKurtosis float64) {
+	g1, g2, g3, g4 := w.gammaTerms()
+	v := g2 - g1*g1
+	mean = w.Lambda * g1
+	variance = w.Lambda * w.Lambda * v
+	skewness = (2*g1*g1*g1 - 3*g1*g2 + g3) / math.Pow(v, 1.5)
+	exKurtosis = (-6*g1*g1*g1*g1 + 12*g1*g1*g2 - 3*g2*g2 - 4*g1*g3 + g4) / (v * v)
+	return mean, variance, skewness, exKurtosis
+}
+
+// NormalizationCheck returns the integral of Prob over the support computed by
+// normalization, which is 1 up to the integration error.
+func (w Weibull) NormalizationCheck() float64 {
+	return normalization(w)
+}
+
 // NumParameters returns the number of parameters in the distribution.
 func (Weibull) NumParameters() int {
 	return 2
@@ -166,6 +514,7 @@ func (Weibull) NumParameters() int {
 
 // Prob computes the value of the probability density function at x.
 func (w Weibull) Prob(x float64) float64 {
+	x = snapNonNegative(x)
 	if x < 0 {
 		return 0
 	} else {
@@ -173,33 +522,111 @@ func (w Weibull) Prob(x float64) float64 {
 	}
 }
 
+// ProbBetween returns the probability that a sample lies in the interval (a, b].
+// The upper tail is computed from the survival function to avoid cancellation.
+//
+// ProbBetween panics if a > b.
+func (w Weibull) ProbBetween(a, b float64) float64 {
+	return ProbBetween(w, a, b)
+}
+
+// ProbSlice returns the value of the probability density function at each
+// of the values in xs. See ProbSliceTo.
+func (w Weibull) ProbSlice(xs []float64) []float64 {
+	return w.ProbSliceTo(make([]float64, len(xs)), xs)
+}
+
+// ProbSliceTo stores in dst the value of the probability density function at
+// each of the values in xs, and returns dst. The logarithms of the
+// parameters and the reciprocal of the scale are computed once for the
+// whole slice.
+// ProbSliceTo panics if len(dst) != len(xs).
+func (w Weibull) ProbSliceTo(dst, xs []float64) []float64 {
+	if len(dst) != len(xs) {
+		panic("dist: slice length mismatch")
+	}
+	logLambda := math.Log(w.Lambda)
+	logNorm := math.Log(w.K) - logLambda
+	invLambda := 1 / w.Lambda
+	for i, x := range xs {
+		x = snapNonNegative(x)
+		if x < 0 || math.IsInf(x, 1) {
+			dst[i] = 0
+			continue
+		}
+		dst[i] = math.Exp(logNorm + (w.K-1)*(math.Log(x)-logLambda) - math.Pow(x*invLambda, w.K))
+	}
+	return dst
+}
+
 // Quantile returns the inverse of the cumulative probability distribution.
+// Quantile(0) is 0 and Quantile(1) is +Inf, the bounds of the support.
 func (w Weibull) Quantile(p float64) float64 {
-	if p < 0 || p > 1 {
+	if !(p >= 0 && p <= 1) {
 		panic("weibull: percentile out of bounds")
 	}
+	if p == 0 {
+		return 0
+	}
 	return w.Lambda * math.Pow(-math.Log(1-p), 1/w.K)
 }
 
+// QuantileFunc returns a function that computes Quantile for the current
+// parameters of the distribution, with 1/K computed once when QuantileFunc
+// is called rather than on each evaluation. It is intended for tight loops
+// such as inverse-transform sampling. The returned function is not affected
+// by later changes to w, and panics as Quantile does.
+func (w Weibull) QuantileFunc() func(p float64) float64 {
+	lambda, invK := w.Lambda, 1/w.K
+	return func(p float64) float64 {
+		if !(p >= 0 && p <= 1) {
+			panic("weibull: percentile out of bounds")
+		}
+		if p == 0 {
+			return 0
+		}
+		return lambda * math.Pow(-math.Log(1-p), invK)
+	}
+}
+
+// QuantileUpper returns the quantile with upper-tail probability q, the x
+// at which Survival(x) = q,
+//  x = λ (-log q)^(1/K).
+// It equals Quantile(1-q), but keeps full relative precision for small q,
+// where forming 1-q first would lose the low digits of q. QuantileUpper(0)
+// is +Inf and QuantileUpper(1) is 0.
+func (w Weibull) QuantileUpper(q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		panic("weibull: percentile out of bounds")
+	}
+	if q == 1 {
+		return 0
+	}
+	return w.Lambda * math.Pow(-math.Log(q), 1/w.K)
+}
+
 // Rand returns a random sample drawn from the distribution.
 func (w Weibull) Rand() float64 {
-	var rnd float64
-	if w.Source == nil {
-		rnd = rand.Float64()
-	} else {
-		rnd = w.Source.Float64()
-	}
+	rnd := randFloat64(w.Source)
 	return w.Quantile(rnd)
 }
 
+// RandAntithetic returns n samples drawn from the distribution in antithetic
+// pairs, as described for randAntithetic.
+func (w Weibull) RandAntithetic(n int) []float64 {
+	return randAntithetic(w, n, w.Source)
+}
+
+// Rate returns the rate parameter β = 1/λ of the distribution, for the
+// parameterization used by NewWeibullRate.
+func (w Weibull) Rate() float64 {
+	return 1 / w.Lambda
+}
+
 // Skewness returns the skewness of the distribution.
 func (w Weibull) Skewness() float64 {
-	stdDev := w.StdDev()
-	firstGamma, firstGammaSign := math.Lgamma(1 + 3/w.K)
-	logFirst := firstGamma + 3*(math.Log(w.Lambda)-math.Log(stdDev))
-	logSecond := math.Log(3) + math.Log(w.Mean()) + 2*math.Log(stdDev) - 3*math.Log(stdDev)
-	logThird := 3 * (math.Log(w.Mean()) - math.Log(stdDev))
-	return float64(firstGammaSign)*math.Exp(logFirst) - math.Exp(logSecond) - math.Exp(logThird)
+	g1, g2, g3, _ := w.gammaTerms()
+	return (2*g1*g1*g1 - 3*g1*g2 + g3) / math.Pow(g2-g1*g1, 1.5)
 }
 
 // StdDev returns the standard deviation of the probability distribution.
@@ -207,6 +634,21 @@ func (w Weibull) StdDev() float64 {
 	return math.Sqrt(w.Variance())
 }
 
+// StratifiedSample returns n stratified samples from the distribution, as
+// described for stratifiedSample, drawing uniforms from src or, if src is nil,
+// from the Source of the distribution.
+func (w Weibull) StratifiedSample(n int, src Source) []float64 {
+	if src == nil {
+		src = w.Source
+	}
+	return stratifiedSample(w, n, src)
+}
+
+// String implements the fmt.Stringer interface.
+func (w Weibull) String() string {
+	return formatParams(&w)
+}
+
 // Survival returns the survival function (complementary CDF) at x.
 func (w Weibull) Survival(x float64) float64 {
 	return math.Exp(w.LogSurvival(x))
@@ -220,14 +662,34 @@ func (w *Weibull) UnmarshalParameters(p []Parameter) {
 	if p[0].Name != "K" {
 		panic("weibull: " + panicNameMismatch)
 	}
-	if p[1].Name != "λ" {
+	if !isLambda(p[1].Name) {
 		panic("weibull: " + panicNameMismatch)
 	}
 	w.K = p[0].Value
 	w.Lambda = p[1].Value
 }
 
+// VaR returns the value at risk at level alpha, the loss that is exceeded
+// with probability 1-alpha when the distribution models losses. It is
+// Quantile(alpha), so VaR(0.99) is the 99% value at risk. See also
+// ExpectedShortfall for the mean loss beyond it.
+func (w Weibull) VaR(alpha float64) float64 {
+	return w.Quantile(alpha)
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if K and λ are positive and
+// finite.
+func (w Weibull) Validate() error {
+	return firstError(
+		checkPositive("weibull", "K", w.K),
+		checkPositive("weibull", "λ", w.Lambda),
+	)
+}
+
 // Variance returns the variance of the probability distribution.
 func (w Weibull) Variance() float64 {
-	return math.Pow(w.Lambda, 2) * (math.Gamma(1+2/w.K) - w.gammaIPow(1, 2))
+	g1 := math.Gamma(1 + 1/w.K)
+	g2 := math.Gamma(1 + 2/w.K)
+	return w.Lambda * w.Lambda * (g2 - g1*g1)
 }
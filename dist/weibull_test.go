@@ -6,6 +6,8 @@ package dist
 
 import (
 	"math"
+	"math/rand"
+	"strings"
 	"testing"
 )
 
@@ -21,7 +23,7 @@ func TestHalfKStandardWeibullProb(t *testing.T) {
 			loc:     -1,
 			prob:    0,
 			cumProb: 0,
-			logProb: 0,
+			logProb: math.Inf(-1),
 		},
 		univariateProbPoint{
 			loc:     1,
@@ -51,7 +53,7 @@ func TestExponentialStandardWeibullProb(t *testing.T) {
 			loc:     -1,
 			prob:    0,
 			cumProb: 0,
-			logProb: 0,
+			logProb: math.Inf(-1),
 		},
 		univariateProbPoint{
 			loc:     1,
@@ -81,7 +83,7 @@ func TestRayleighStandardWeibullProb(t *testing.T) {
 			loc:     -1,
 			prob:    0,
 			cumProb: 0,
-			logProb: 0,
+			logProb: math.Inf(-1),
 		},
 		univariateProbPoint{
 			loc:     1,
@@ -111,7 +113,7 @@ func TestFiveKStandardWeibullProb(t *testing.T) {
 			loc:     -1,
 			prob:    0,
 			cumProb: 0,
-			logProb: 0,
+			logProb: math.Inf(-1),
 		},
 		univariateProbPoint{
 			loc:     1,
@@ -141,7 +143,7 @@ func TestScaledUpHalfKStandardWeibullProb(t *testing.T) {
 			loc:     -1,
 			prob:    0,
 			cumProb: 0,
-			logProb: 0,
+			logProb: math.Inf(-1),
 		},
 		univariateProbPoint{
 			loc:     1,
@@ -171,7 +173,7 @@ func TestScaledDownHalfKStandardWeibullProb(t *testing.T) {
 			loc:     -1,
 			prob:    0,
 			cumProb: 0,
-			logProb: 0,
+			logProb: math.Inf(-1),
 		},
 		univariateProbPoint{
 			loc:     1,
@@ -188,3 +190,531 @@ func TestScaledDownHalfKStandardWeibullProb(t *testing.T) {
 	}
 	testDistributionProbs(t, Weibull{K: 0.5, Lambda: 0.5}, "0.5K 0.5λ Weibull", pts)
 }
+
+func TestWeibullProbBetween(t *testing.T) {
+	for _, test := range []struct {
+		w    Weibull
+		a, b float64
+	}{
+		{Weibull{K: 1, Lambda: 1}, 0, 1},
+		{Weibull{K: 2, Lambda: 1}, 0.5, 1.5},
+		{Weibull{K: 5, Lambda: 2}, 1, 2.5},
+		{Weibull{K: 0.5, Lambda: 1.5}, 1, 20},
+		{Weibull{K: 1.5, Lambda: 3}, 6, 12},
+	} {
+		got := test.w.ProbBetween(test.a, test.b)
+		want := integrate(test.w.Prob, test.a, test.b, 2000)
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("ProbBetween mismatch for %#v on [%v, %v]. Expected %v, Found %v", test.w, test.a, test.b, want, got)
+		}
+	}
+
+	// Far in the upper tail the difference of CDFs cancels completely.
+	w := Weibull{K: 1, Lambda: 1}
+	got := w.ProbBetween(30, 31)
+	want := math.Exp(-30) - math.Exp(-31)
+	if math.Abs(got-want)/want > 1e-12 {
+		t.Errorf("Upper tail ProbBetween mismatch. Expected %v, Found %v", want, got)
+	}
+}
+
+func TestWeibullMoments(t *testing.T) {
+	for _, test := range []struct {
+		k, lambda          float64
+		skewness, kurtosis float64
+	}{
+		{1, 1, 2, 6},
+		{2, 1, 2 * math.Sqrt(math.Pi) * (math.Pi - 3) / math.Pow(4-math.Pi, 1.5), -(6*math.Pi*math.Pi - 24*math.Pi + 16) / ((4 - math.Pi) * (4 - math.Pi))},
+		{5, 3, -0.2541096037068576, -0.11970993621688579},
+		{0.5, 2, 6.618761213399377, 84.72},
+	} {
+		w := Weibull{K: test.k, Lambda: test.lambda}
+		mean, variance, skewness, kurtosis := w.Moments()
+		if math.Abs(mean-w.Mean()) > 1e-12*w.Mean() {
+			t.Errorf("Mean mismatch for K = %v. Expected %v, Found %v", test.k, w.Mean(), mean)
+		}
+		if math.Abs(variance-w.Variance()) > 1e-12*w.Variance() {
+			t.Errorf("Variance mismatch for K = %v. Expected %v, Found %v", test.k, w.Variance(), variance)
+		}
+		if math.Abs(skewness-w.Skewness()) > 1e-12 {
+			t.Errorf("Skewness mismatch for K = %v. Expected %v, Found %v", test.k, w.Skewness(), skewness)
+		}
+		if math.Abs(kurtosis-w.ExKurtosis()) > 1e-12 {
+			t.Errorf("ExKurtosis mismatch for K = %v. Expected %v, Found %v", test.k, w.ExKurtosis(), kurtosis)
+		}
+		if math.Abs(skewness-test.skewness) > 1e-10 {
+			t.Errorf("Skewness incorrect for K = %v. Expected %v, Found %v", test.k, test.skewness, skewness)
+		}
+		if math.Abs(kurtosis-test.kurtosis) > 1e-10*math.Max(1, test.kurtosis) {
+			t.Errorf("ExKurtosis incorrect for K = %v. Expected %v, Found %v", test.k, test.kurtosis, kurtosis)
+		}
+	}
+}
+
+func TestWeibullLogProbSlice(t *testing.T) {
+	xs := []float64{-3, -0.5, 0, 1e-300, 0.1, 0.5, 1, 1.5, 2, 4, 20, 1e10}
+	for _, w := range []Weibull{
+		{K: 1.5, Lambda: 2},
+		{K: 0.5, Lambda: 0.5},
+		{K: 5, Lambda: 10},
+	} {
+		got := w.LogProbSlice(xs)
+		for i, x := range xs {
+			if want := w.LogProb(x); got[i] != want {
+				t.Errorf("LogProbSlice mismatch for %v at %v. Expected %v, Found %v", w, x, want, got[i])
+			}
+		}
+	}
+}
+
+func TestWeibullDLogProbDParamSlice(t *testing.T) {
+	xs := []float64{-3, 0, 1e-300, 0.1, 0.5, 1, 1.5, 2, 4, 20}
+	for _, w := range []Weibull{
+		{K: 1.5, Lambda: 2},
+		{K: 0.5, Lambda: 0.5},
+		{K: 5, Lambda: 10},
+	} {
+		grads := make([][]float64, len(xs))
+		for i := range grads {
+			grads[i] = make([]float64, w.NumParameters())
+		}
+		w.DLogProbDParamSlice(xs, grads)
+		want := make([]float64, w.NumParameters())
+		for i, x := range xs {
+			w.DLogProbDParam(x, want)
+			for j, v := range want {
+				got := grads[i][j]
+				if math.IsNaN(v) != math.IsNaN(got) || (!math.IsNaN(v) && !equalRel(got, v, 1e-12) && math.Abs(got-v) > 1e-12) {
+					t.Errorf("DLogProbDParamSlice mismatch for %v at %v, parameter %v. Expected %v, Found %v", w, x, j, v, got)
+				}
+			}
+		}
+	}
+	if !panics(func() { Weibull{K: 1, Lambda: 1}.DLogProbDParamSlice([]float64{1}, [][]float64{{0}}) }) {
+		t.Errorf("expected panic for short gradient row")
+	}
+}
+
+func TestWeibullFitLeftCensored(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	truth := Weibull{K: 1.5, Lambda: 2, Source: src}
+	const (
+		n     = 5000
+		limit = 1.0
+	)
+	values := make([]float64, n)
+	below := make([]bool, n)
+	for i := range values {
+		values[i] = truth.Rand()
+		below[i] = values[i] < limit
+	}
+	var w Weibull
+	w.FitLeftCensored(values, below, limit, nil)
+	if math.Abs(w.K-truth.K) > 0.1 || math.Abs(w.Lambda-truth.Lambda) > 0.1 {
+		t.Errorf("FitLeftCensored mismatch. Expected %v, Found %v", truth, w)
+	}
+	// The values of the non-detects are ignored.
+	for i := range values {
+		if below[i] {
+			values[i] = math.NaN()
+		}
+	}
+	var v Weibull
+	v.FitLeftCensored(values, below, limit, nil)
+	if !equalRel(v.K, w.K, 1e-6) || !equalRel(v.Lambda, w.Lambda, 1e-6) {
+		t.Errorf("FitLeftCensored depends on non-detect values. Expected %v, Found %v", w, v)
+	}
+}
+
+func TestWeibullFitLeftTruncated(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	truth := Weibull{K: 2, Lambda: 3, Source: src}
+	const truncation = 2
+	var samples []float64
+	for len(samples) < 5000 {
+		if x := truth.Rand(); x >= truncation {
+			samples = append(samples, x)
+		}
+	}
+	var w Weibull
+	w.FitLeftTruncated(samples, truncation, nil)
+	if math.Abs(w.K-truth.K) > 0.15 || math.Abs(w.Lambda-truth.Lambda) > 0.1 {
+		t.Errorf("FitLeftTruncated mismatch. Expected %v, Found %v", truth, w)
+	}
+	// Ignoring the truncation overestimates the scale and the shape, since
+	// the small values are missing.
+	naive := Weibull{K: 1, Lambda: 1}
+	maximizeLikelihood(&naive, samples, nil, nil)
+	if naive.Lambda < truth.Lambda+0.2 || naive.K < truth.K+0.5 {
+		t.Errorf("Naive fit of truncated data not biased as expected. Found %v", naive)
+	}
+
+	// With no truncation the fit is the maximum likelihood fit.
+	for i := range samples {
+		samples[i] = truth.Rand()
+	}
+	w.FitLeftTruncated(samples, 0, nil)
+	full := Weibull{K: 1, Lambda: 1}
+	maximizeLikelihood(&full, samples, nil, nil)
+	if !equalRel(w.K, full.K, 1e-6) || !equalRel(w.Lambda, full.Lambda, 1e-6) {
+		t.Errorf("FitLeftTruncated mismatch without truncation. Expected %v, Found %v", full, w)
+	}
+	if !panics(func() { w.FitLeftTruncated([]float64{1, 3}, 2, nil) }) {
+		t.Errorf("FitLeftTruncated did not panic with a sample below the truncation point")
+	}
+}
+
+func TestWeibullFitScaleOnly(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	truth := Weibull{K: 2.5, Lambda: 3, Source: src}
+	const reps = 2000
+	var meanPow, meanLambda float64
+	samples := make([]float64, 20)
+	for r := 0; r < reps; r++ {
+		for i := range samples {
+			samples[i] = truth.Rand()
+		}
+		var w Weibull
+		w.FitScaleOnly(samples, nil, truth.K)
+		if w.K != truth.K {
+			t.Fatalf("FitScaleOnly changed the shape. Expected %v, Found %v", truth.K, w.K)
+		}
+		meanPow += math.Pow(w.Lambda, w.K) / reps
+		meanLambda += w.Lambda / reps
+	}
+	// λ^K is unbiased with standard error λ^K/sqrt(n·reps).
+	want := math.Pow(truth.Lambda, truth.K)
+	if math.Abs(meanPow-want) > 4*want/math.Sqrt(20*reps) {
+		t.Errorf("FitScaleOnly λ^K biased. Expected %v, Found %v", want, meanPow)
+	}
+	// λ itself has the small bias of a power of a gamma variable,
+	//  E[λ̂] = λ Γ(n+1/K) / (Γ(n) n^(1/K)).
+	n := float64(len(samples))
+	lg1, _ := math.Lgamma(n + 1/truth.K)
+	lg0, _ := math.Lgamma(n)
+	wantLambda := truth.Lambda * math.Exp(lg1-lg0) / math.Pow(n, 1/truth.K)
+	if se := truth.Lambda / (truth.K * math.Sqrt(n*reps)); math.Abs(meanLambda-wantLambda) > 4*se {
+		t.Errorf("FitScaleOnly λ mean mismatch. Expected %v, Found %v", wantLambda, meanLambda)
+	}
+
+	// At the maximum likelihood shape the scale-only fit gives the maximum
+	// likelihood scale.
+	samples = make([]float64, 500)
+	weights := make([]float64, len(samples))
+	for i := range samples {
+		samples[i] = truth.Rand()
+		weights[i] = 0.5 + src.Float64()
+	}
+	full := Weibull{K: 1, Lambda: 1}
+	maximizeLikelihood(&full, samples, weights, nil)
+	var scale Weibull
+	scale.FitScaleOnly(samples, weights, full.K)
+	if !equalRel(scale.Lambda, full.Lambda, 1e-8) {
+		t.Errorf("FitScaleOnly mismatch at the MLE shape. Expected %v, Found %v", full.Lambda, scale.Lambda)
+	}
+}
+
+func TestWeibullFitInterval(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	truth := Weibull{K: 1.8, Lambda: 3, Source: src}
+	samples := make([]float64, 200)
+	for i := range samples {
+		samples[i] = truth.Rand()
+	}
+	exact := Weibull{K: 1, Lambda: 1}
+	maximizeLikelihood(&exact, samples, nil, nil)
+
+	// Very narrow intervals reproduce the exact-observation estimates.
+	const eps = 1e-6
+	lower := make([]float64, len(samples))
+	upper := make([]float64, len(samples))
+	for i, x := range samples {
+		lower[i] = x - eps
+		upper[i] = x + eps
+	}
+	var w Weibull
+	w.FitInterval(lower, upper, nil)
+	if math.Abs(w.K-exact.K) > 1e-4*exact.K || math.Abs(w.Lambda-exact.Lambda) > 1e-4*exact.Lambda {
+		t.Errorf("Narrow interval fit mismatch. Expected %v, Found %v", exact, w)
+	}
+
+	// Right-censoring the largest observations at a fixed time.
+	const cens = 4.0
+	for i, x := range samples {
+		if x > cens {
+			lower[i], upper[i] = cens, math.Inf(1)
+		} else {
+			lower[i], upper[i] = x, x
+		}
+	}
+	w.FitInterval(lower, upper, nil)
+	if math.Abs(w.K-truth.K) > 0.3 || math.Abs(w.Lambda-truth.Lambda) > 0.3 {
+		t.Errorf("Right-censored fit mismatch. Expected %v, Found %v", truth, w)
+	}
+}
+
+func TestWeibullRate(t *testing.T) {
+	for _, test := range []struct {
+		k, beta float64
+	}{
+		{1.5, 0.5},
+		{0.7, 3},
+		{4, 1},
+	} {
+		r, err := NewWeibullRate(test.k, test.beta, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := Weibull{K: test.k, Lambda: 1 / test.beta}
+		if r.Rate() != test.beta {
+			t.Errorf("Rate mismatch. Expected %v, Found %v", test.beta, r.Rate())
+		}
+		for _, x := range []float64{0, 0.1, 0.5, 1, 2, 5, 10} {
+			want := 1 - math.Exp(-math.Pow(test.beta*x, test.k))
+			if got := r.CDF(x); !equalRel(got, want, 1e-14) || got != w.CDF(x) {
+				t.Errorf("CDF mismatch for rate %v at %v. Expected %v, Found %v", test.beta, x, want, got)
+			}
+		}
+	}
+	if _, err := NewWeibullRate(1, 0, nil); err == nil || !strings.Contains(err.Error(), "Beta") {
+		t.Errorf("expected error naming Beta for zero rate, found %v", err)
+	}
+	if _, err := NewWeibullRate(-1, 1, nil); err == nil {
+		t.Errorf("expected error for negative shape")
+	}
+}
+
+func TestWeibullFromQuantiles(t *testing.T) {
+	for _, test := range []struct {
+		p1, x1, p2, x2 float64
+	}{
+		{0.1, 1000, 0.5, 4000},
+		{0.5, 4000, 0.1, 1000},
+		{0.01, 0.2, 0.99, 3},
+		{0.3, 1, 0.4, 1.01},
+	} {
+		w := WeibullFromQuantiles(test.p1, test.x1, test.p2, test.x2)
+		if got := w.Quantile(test.p1); !equalRel(got, test.x1, 1e-12) {
+			t.Errorf("Quantile(%v) mismatch. Expected %v, Found %v", test.p1, test.x1, got)
+		}
+		if got := w.Quantile(test.p2); !equalRel(got, test.x2, 1e-12) {
+			t.Errorf("Quantile(%v) mismatch. Expected %v, Found %v", test.p2, test.x2, got)
+		}
+	}
+	w := Weibull{K: 1.7, Lambda: 3}
+	got := WeibullFromQuantiles(0.1, w.Quantile(0.1), 0.9, w.Quantile(0.9))
+	if !equalRel(got.K, w.K, 1e-12) || !equalRel(got.Lambda, w.Lambda, 1e-12) {
+		t.Errorf("WeibullFromQuantiles mismatch. Expected %v, Found %v", w, got)
+	}
+	if !panics(func() { WeibullFromQuantiles(0.1, 2, 0.5, 1) }) {
+		t.Errorf("expected panic for decreasing quantiles")
+	}
+	if !panics(func() { WeibullFromQuantiles(0, 1, 0.5, 2) }) {
+		t.Errorf("expected panic for zero percentile")
+	}
+}
+
+var momentsSink float64
+
+// BenchmarkWeibullMoments computes Γ(1+i/K) four times per iteration.
+func BenchmarkWeibullMoments(b *testing.B) {
+	w := Weibull{K: 1.5, Lambda: 2}
+	for i := 0; i < b.N; i++ {
+		mean, variance, skewness, kurtosis := w.Moments()
+		momentsSink += mean + variance + skewness + kurtosis
+	}
+}
+
+// BenchmarkWeibullMomentsSeparate computes the same quantities with the
+// individual methods, which evaluate Γ(1+i/K) eleven times per iteration.
+func BenchmarkWeibullMomentsSeparate(b *testing.B) {
+	w := Weibull{K: 1.5, Lambda: 2}
+	for i := 0; i < b.N; i++ {
+		momentsSink += w.Mean() + w.Variance() + w.Skewness() + w.ExKurtosis()
+	}
+}
+
+var sliceSink []float64
+
+func BenchmarkWeibullProbSlice(b *testing.B) {
+	w := Weibull{K: 1.5, Lambda: 2}
+	xs := make([]float64, 1000)
+	for i := range xs {
+		xs[i] = float64(i) / 100
+	}
+	dst := make([]float64, len(xs))
+	for i := 0; i < b.N; i++ {
+		sliceSink = w.ProbSliceTo(dst, xs)
+	}
+}
+
+func BenchmarkWeibullProbLoop(b *testing.B) {
+	w := Weibull{K: 1.5, Lambda: 2}
+	xs := make([]float64, 1000)
+	for i := range xs {
+		xs[i] = float64(i) / 100
+	}
+	dst := make([]float64, len(xs))
+	for i := 0; i < b.N; i++ {
+		for j, x := range xs {
+			dst[j] = w.Prob(x)
+		}
+		sliceSink = dst
+	}
+}
+
+func BenchmarkWeibullQuantile(b *testing.B) {
+	w := Weibull{K: 1.5, Lambda: 2}
+	ps := make([]float64, 1000)
+	for i := range ps {
+		ps[i] = (float64(i) + 0.5) / float64(len(ps))
+	}
+	dst := make([]float64, len(ps))
+	for i := 0; i < b.N; i++ {
+		for j, p := range ps {
+			dst[j] = w.Quantile(p)
+		}
+		sliceSink = dst
+	}
+}
+
+func BenchmarkWeibullQuantileFunc(b *testing.B) {
+	q := Weibull{K: 1.5, Lambda: 2}.QuantileFunc()
+	ps := make([]float64, 1000)
+	for i := range ps {
+		ps[i] = (float64(i) + 0.5) / float64(len(ps))
+	}
+	dst := make([]float64, len(ps))
+	for i := 0; i < b.N; i++ {
+		for j, p := range ps {
+			dst[j] = q(p)
+		}
+		sliceSink = dst
+	}
+}
+
+func BenchmarkWeibullLogProbSlice(b *testing.B) {
+	w := Weibull{K: 1.5, Lambda: 2}
+	xs := make([]float64, 1000)
+	for i := range xs {
+		xs[i] = float64(i) / 100
+	}
+	dst := make([]float64, len(xs))
+	for i := 0; i < b.N; i++ {
+		sliceSink = w.LogProbSliceTo(dst, xs)
+	}
+}
+
+func BenchmarkWeibullLogProbLoop(b *testing.B) {
+	w := Weibull{K: 1.5, Lambda: 2}
+	xs := make([]float64, 1000)
+	for i := range xs {
+		xs[i] = float64(i) / 100
+	}
+	dst := make([]float64, len(xs))
+	for i := 0; i < b.N; i++ {
+		for j, x := range xs {
+			dst[j] = w.LogProb(x)
+		}
+		sliceSink = dst
+	}
+}
+
+func TestWeibullQuantileFunc(t *testing.T) {
+	w := Weibull{K: 1.5, Lambda: 2}
+	q := w.QuantileFunc()
+	for _, p := range []float64{0, 1e-10, 0.1, 0.5, 0.9, 1 - 1e-10, 1} {
+		if got, want := q(p), w.Quantile(p); got != want {
+			t.Errorf("QuantileFunc mismatch at %v. Expected %v, Found %v", p, want, got)
+		}
+	}
+	w.K = 3
+	if got, want := q(0.5), (Weibull{K: 1.5, Lambda: 2}).Quantile(0.5); got != want {
+		t.Errorf("QuantileFunc changed with the distribution. Expected %v, Found %v", want, got)
+	}
+	if !panics(func() { q(1.5) }) {
+		t.Errorf("QuantileFunc did not panic with p out of bounds")
+	}
+}
+
+func TestWeibullQuantileUpper(t *testing.T) {
+	for _, w := range []Weibull{
+		{K: 0.5, Lambda: 1},
+		{K: 1, Lambda: 2},
+		{K: 3, Lambda: 10},
+	} {
+		for _, q := range []float64{1e-300, 1e-12, 1e-6, 0.1, 0.5, 0.9} {
+			// The condition number of exp(-y) is y, about 700 for
+			// the smallest q.
+			x := w.QuantileUpper(q)
+			if got := w.Survival(x); !equalRel(got, q, 1e-12) {
+				t.Errorf("Survival(QuantileUpper(%v)) mismatch for %v. Expected %v, Found %v", q, w, q, got)
+			}
+		}
+		for _, p := range []float64{0.1, 0.5, 0.9} {
+			if got, want := w.QuantileUpper(1-p), w.Quantile(p); !equalRel(got, want, 1e-14) {
+				t.Errorf("QuantileUpper mismatch for %v at %v. Expected %v, Found %v", w, 1-p, want, got)
+			}
+		}
+		// 1-1e-12 is not exactly representable, so the naive quantile
+		// is computed for a tail probability with a relative error of
+		// about 1e-4.
+		const q = 1e-12
+		exact := w.Lambda * math.Pow(12*math.Ln10, 1/w.K)
+		upper, naive := w.QuantileUpper(q), w.Quantile(1-q)
+		if !equalRel(upper, exact, 1e-14) {
+			t.Errorf("QuantileUpper mismatch for %v at %v. Expected %v, Found %v", w, q, exact, upper)
+		}
+		if errUpper, errNaive := math.Abs(upper-exact), math.Abs(naive-exact); errNaive < 1e6*errUpper || errNaive < 1e-8*exact {
+			t.Errorf("QuantileUpper not more accurate than Quantile(1-q) for %v. Found errors %v and %v", w, errUpper, errNaive)
+		}
+	}
+	w := Weibull{K: 2, Lambda: 3}
+	if got := w.QuantileUpper(0); !math.IsInf(got, 1) {
+		t.Errorf("QuantileUpper(0) mismatch. Expected +Inf, Found %v", got)
+	}
+	if got := w.QuantileUpper(1); got != 0 || math.Signbit(got) {
+		t.Errorf("QuantileUpper(1) mismatch. Expected 0, Found %v", got)
+	}
+	if !panics(func() { w.QuantileUpper(math.NaN()) }) {
+		t.Errorf("QuantileUpper did not panic with NaN")
+	}
+}
+
+func TestWeibullDemonstrationTestPlan(t *testing.T) {
+	for _, test := range []struct {
+		k, confidence, reliability, testTime float64
+		want                                 int
+	}{
+		// The binomial success-run sample sizes, which do not depend on K
+		// when the test is as long as the mission.
+		{2, 0.9, 0.9, 1, 22},
+		{0.5, 0.9, 0.9, 1, 22},
+		{2, 0.95, 0.9, 1, 29},
+		{2, 0.9, 0.99, 1, 230},
+		// A test twice as long as the mission needs 2^K times fewer units.
+		{2, 0.9, 0.9, 2, 6},
+		{1.5, 0.9, 0.9, 2, 8},
+		// A test half as long as the mission needs 2^K times more units.
+		{3, 0.9, 0.9, 0.5, 175},
+	} {
+		w := Weibull{K: test.k, Lambda: 1}
+		if got := w.DemonstrationTestPlan(test.confidence, test.reliability, test.testTime); got != test.want {
+			t.Errorf("DemonstrationTestPlan mismatch for K = %v, C = %v, R = %v, test time %v. Expected %v, Found %v",
+				test.k, test.confidence, test.reliability, test.testTime, test.want, got)
+		}
+	}
+	// With the planned units surviving the test, the one-sided confidence
+	// bound P(no failures | R(mission) = reliability) ≤ 1 - confidence holds.
+	w := Weibull{K: 2, Lambda: 1}
+	n := w.DemonstrationTestPlan(0.9, 0.9, 1.5)
+	if p := math.Pow(0.9, float64(n)*math.Pow(1.5, 2)); p > 0.1 {
+		t.Errorf("DemonstrationTestPlan too few units. Probability of passing %v", p)
+	}
+	if p := math.Pow(0.9, float64(n-1)*math.Pow(1.5, 2)); p <= 0.1 {
+		t.Errorf("DemonstrationTestPlan too many units. Probability of passing with one fewer %v", p)
+	}
+	if !panics(func() { w.DemonstrationTestPlan(1, 0.9, 1) }) {
+		t.Errorf("DemonstrationTestPlan did not panic with confidence 1")
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// WeibullMixture represents a finite mixture of Weibull distributions, the
+// usual model for lifetimes with competing failure modes such as early
+// defects and wear-out. A sample is drawn from Components[i] with probability
+// proportional to Weights[i].
+// Valid range for x is [0,+∞).
+type WeibullMixture struct {
+	Weights    []float64
+	Components []Weibull
+	Source     Source
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (m WeibullMixture) CDF(x float64) float64 {
+	var cdf float64
+	for i, c := range m.Components {
+		cdf += m.Weights[i] * c.CDF(x)
+	}
+	return cdf / m.sum()
+}
+
+// Fit sets the mixture to k Weibull components fitted to the samples by the
+// expectation-maximization algorithm of FitEM. The iteration starts from
+// components fitted separately to the k groups of consecutive order
+// statistics, and stops when the log-likelihood no longer increases.
+//
+// The likelihood of a mixture has local maxima, and the starting point
+// favours components with separated quantiles, as in the common case of
+// early and late failures. Fit panics if k is less than 1, if there are fewer
+// than 2k samples, or if a sample is not positive and finite.
+func (m *WeibullMixture) Fit(samples []float64, k int) {
+	if k < 1 {
+		panic("weibullmixture: non-positive component count")
+	}
+	if len(samples) < 2*k {
+		panic("weibullmixture: too few samples")
+	}
+	for _, x := range samples {
+		if !(x > 0) || math.IsInf(x, 1) {
+			panic("weibullmixture: sample not positive and finite")
+		}
+	}
+	n := len(samples)
+	sorted := make([]float64, n)
+	copy(sorted, samples)
+	sort.Float64s(sorted)
+	m.Components = make([]Weibull, k)
+	ds := make([]ParametricDist, k)
+	for j := range m.Components {
+		group := sorted[j*n/k : (j+1)*n/k]
+		var mean float64
+		for _, x := range group {
+			mean += x
+		}
+		m.Components[j] = Weibull{K: 1, Lambda: mean / float64(len(group))}
+		maximizeLikelihood(&m.Components[j], group, nil, nil)
+		ds[j] = &m.Components[j]
+	}
+	m.Weights = FitEM(samples, ds, 500, 1e-9)
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (m WeibullMixture) LogProb(x float64) float64 {
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	max := math.Inf(-1)
+	lp := make([]float64, len(m.Components))
+	for i, c := range m.Components {
+		lp[i] = math.Log(m.Weights[i]) + c.LogProb(x)
+		max = math.Max(max, lp[i])
+	}
+	if math.IsInf(max, 0) {
+		return max
+	}
+	var sum float64
+	for _, v := range lp {
+		sum += math.Exp(v - max)
+	}
+	return max + math.Log(sum/m.sum())
+}
+
+// Mean returns the mean of the probability distribution.
+func (m WeibullMixture) Mean() float64 {
+	var mean float64
+	for i, c := range m.Components {
+		mean += m.Weights[i] * c.Mean()
+	}
+	return mean / m.sum()
+}
+
+// Prob computes the value of the probability density function at x.
+func (m WeibullMixture) Prob(x float64) float64 {
+	return math.Exp(m.LogProb(x))
+}
+
+// Rand returns a random sample drawn from the distribution. The component is
+// chosen and sampled using Source; the Source fields of the components are
+// not used.
+func (m WeibullMixture) Rand() float64 {
+	u := randFloat64(m.Source) * m.sum()
+	j := len(m.Components) - 1
+	for i, w := range m.Weights {
+		u -= w
+		if u < 0 {
+			j = i
+			break
+		}
+	}
+	c := m.Components[j]
+	c.Source = m.Source
+	return c.Rand()
+}
+
+// sum returns the sum of the weights.
+func (m WeibullMixture) sum() float64 {
+	var s float64
+	for _, w := range m.Weights {
+		s += w
+	}
+	return s
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (m WeibullMixture) Survival(x float64) float64 {
+	var sf float64
+	for i, c := range m.Components {
+		sf += m.Weights[i] * c.Survival(x)
+	}
+	return sf / m.sum()
+}
+
+// Validate returns an error if the parameters of the distribution are outside
+// their valid range. The parameters are valid if there is at least one
+// component, there is a weight for each component, the weights are
+// non-negative and finite with a positive sum, and the components are valid.
+func (m WeibullMixture) Validate() error {
+	if len(m.Components) == 0 {
+		return errors.New("weibullmixture: no components")
+	}
+	if len(m.Weights) != len(m.Components) {
+		return fmt.Errorf("weibullmixture: %d weights for %d components", len(m.Weights), len(m.Components))
+	}
+	for i, w := range m.Weights {
+		if err := checkNonNegative("weibullmixture", fmt.Sprintf("Weights[%d]", i), w); err != nil {
+			return err
+		}
+	}
+	if s := m.sum(); !(s > 0) || math.IsInf(s, 1) {
+		return fmt.Errorf("weibullmixture: Weights must have a positive finite sum, found %v", s)
+	}
+	for _, c := range m.Components {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestWeibullMixtureFit(t *testing.T) {
+	truth := WeibullMixture{
+		Weights:    []float64{0.3, 0.7},
+		Components: []Weibull{{K: 1.2, Lambda: 1}, {K: 4, Lambda: 10}},
+		Source:     rand.New(rand.NewSource(1)),
+	}
+	x := make([]float64, 4000)
+	for i := range x {
+		x[i] = truth.Rand()
+	}
+	var m WeibullMixture
+	m.Fit(x, 2)
+	if err := m.Validate(); err != nil {
+		t.Fatalf("Fitted mixture is not valid: %v", err)
+	}
+	// The starting point orders the components by scale.
+	if m.Components[0].Lambda > m.Components[1].Lambda {
+		m.Components[0], m.Components[1] = m.Components[1], m.Components[0]
+		m.Weights[0], m.Weights[1] = m.Weights[1], m.Weights[0]
+	}
+	for i, want := range truth.Components {
+		got := m.Components[i]
+		if math.Abs(got.K-want.K) > 0.1*want.K || math.Abs(got.Lambda-want.Lambda) > 0.05*want.Lambda {
+			t.Errorf("Component %d mismatch. Expected %v, Found %v", i, want, got)
+		}
+		if math.Abs(m.Weights[i]-truth.Weights[i]) > 0.03 {
+			t.Errorf("Weight %d mismatch. Expected %v, Found %v", i, truth.Weights[i], m.Weights[i])
+		}
+	}
+
+	// The fit is at least as likely as the true parameters.
+	var llFit, llTrue float64
+	for _, v := range x {
+		llFit += m.LogProb(v)
+		llTrue += truth.LogProb(v)
+	}
+	if llFit < llTrue {
+		t.Errorf("Fitted log-likelihood %v below that of the true parameters %v", llFit, llTrue)
+	}
+
+	if !panics(func() { m.Fit(x[:3], 2) }) {
+		t.Errorf("Fit did not panic with too few samples")
+	}
+	if !panics(func() { m.Fit([]float64{1, 2, -1, 3}, 1) }) {
+		t.Errorf("Fit did not panic with a negative sample")
+	}
+}
+
+func TestWeibullMixture(t *testing.T) {
+	m := WeibullMixture{
+		Weights:    []float64{1, 3},
+		Components: []Weibull{{K: 0.8, Lambda: 2}, {K: 3, Lambda: 5}},
+	}
+	for _, x := range []float64{0.1, 1, 3, 5, 10} {
+		want := (m.Components[0].Prob(x) + 3*m.Components[1].Prob(x)) / 4
+		if got := m.Prob(x); !equalRel(got, want, 1e-14) {
+			t.Errorf("Prob mismatch at %v. Expected %v, Found %v", x, want, got)
+		}
+		if got := m.CDF(x) + m.Survival(x); !equalRel(got, 1, 1e-14) {
+			t.Errorf("CDF and Survival do not sum to 1 at %v. Found %v", x, got)
+		}
+	}
+	if got, want := m.LogProb(-1), math.Inf(-1); got != want {
+		t.Errorf("LogProb mismatch outside the support. Expected %v, Found %v", want, got)
+	}
+	if want := (m.Components[0].Mean() + 3*m.Components[1].Mean()) / 4; !equalRel(m.Mean(), want, 1e-14) {
+		t.Errorf("Mean mismatch. Expected %v, Found %v", want, m.Mean())
+	}
+	for _, bad := range []WeibullMixture{
+		{},
+		{Weights: []float64{1}, Components: []Weibull{{K: 1, Lambda: 1}, {K: 1, Lambda: 2}}},
+		{Weights: []float64{0}, Components: []Weibull{{K: 1, Lambda: 1}}},
+		{Weights: []float64{1}, Components: []Weibull{{K: 0, Lambda: 1}}},
+	} {
+		if bad.Validate() == nil {
+			t.Errorf("Validate did not flag %v", bad)
+		}
+	}
+}
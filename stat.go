@@ -22,6 +22,48 @@ const (
 	Empirical CumulantKind = 1
 )
 
+// IntervalKind specifies the interval computed by CredibleInterval.
+type IntervalKind int
+
+const (
+	// EqualTailed is the interval between the (1-level)/2 and (1+level)/2
+	// quantiles, which leaves equal probability in each tail.
+	EqualTailed IntervalKind = iota + 1
+	// HighestDensity is the shortest interval that contains the fraction
+	// level of the samples, which approximates the highest posterior density
+	// interval of a unimodal posterior.
+	HighestDensity
+)
+
+// AutoCorrelation returns the sample autocorrelation of the series x at lags
+// 0 through maxLag,
+//  ρ_k = Σ_{i=k}^{n-1} (x_i - mean)(x_{i-k} - mean) / Σ_{i=0}^{n-1} (x_i - mean)²,
+// so ρ_0 is 1. The normalization by the full sum of squares rather than by the
+// number of terms at each lag biases ρ_k towards zero for large k, but keeps
+// the sequence positive semi-definite. AutoCorrelation panics if maxLag is
+// negative or not less than len(x).
+func AutoCorrelation(x []float64, maxLag int) []float64 {
+	if maxLag < 0 || maxLag >= len(x) {
+		panic("stat: lag out of range")
+	}
+	mean := Mean(x, nil)
+	c0 := autoCovariance(x, mean, 0)
+	rho := make([]float64, maxLag+1)
+	for k := range rho {
+		rho[k] = autoCovariance(x, mean, k) / c0
+	}
+	return rho
+}
+
+// autoCovariance returns Σ_{i=lag}^{n-1} (x_i - mean)(x_{i-lag} - mean).
+func autoCovariance(x []float64, mean float64, lag int) float64 {
+	var c float64
+	for i := lag; i < len(x); i++ {
+		c += (x[i] - mean) * (x[i-lag] - mean)
+	}
+	return c
+}
+
 // bhattacharyyaCoeff computes the Bhattacharyya Coefficient for probability distributions given by:
 //  \sum_i \sqrt{p_i q_i}
 //
@@ -50,6 +92,46 @@ func Bhattacharyya(p, q []float64) float64 {
 	return -math.Log(bc)
 }
 
+// BinnedMode returns an estimate of the mode of the continuous distribution
+// from which the samples in x are drawn. The range of x is divided into nbins
+// bins of equal width, and the center of the bin containing the most samples
+// is returned. If several bins have the most samples, the center of the
+// lowest is returned. The estimate is within half a bin width of the mode of
+// the histogram, so nbins trades resolution against noise in the counts.
+// Use Mode for the most common value of discrete data.
+//
+// The data need not be sorted. BinnedMode panics if nbins is less than 1,
+// and returns NaN if x is empty.
+func BinnedMode(x []float64, nbins int) float64 {
+	if nbins < 1 {
+		panic("stat: too few bins")
+	}
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	min, _ := floats.Min(x)
+	max, _ := floats.Max(x)
+	if min == max {
+		return min
+	}
+	width := (max - min) / float64(nbins)
+	counts := make([]int, nbins)
+	for _, v := range x {
+		i := int((v - min) / width)
+		if i >= nbins {
+			i = nbins - 1
+		}
+		counts[i]++
+	}
+	var best int
+	for i, c := range counts {
+		if c > counts[best] {
+			best = i
+		}
+	}
+	return min + (float64(best)+0.5)*width
+}
+
 // CDF returns the empirical cumulative distribution function value of x, that is
 // the fraction of the samples less than or equal to q. The
 // exact behavior is determined by the CumulantKind. CDF is theoretically
@@ -108,6 +190,30 @@ func CDF(q float64, c CumulantKind, x, weights []float64) float64 {
 	}
 }
 
+// CDFCalibration returns the Cramér-von Mises statistic of samples against
+// the hypothesized cumulative distribution function cdf,
+//  W² = n ∫ (F_n(x) - cdf(x))² dcdf(x) = 1/(12n) + Σ_i ((2i-1)/(2n) - cdf(x_(i)))²,
+// where F_n is the empirical CDF of the samples and x_(i) is the i-th smallest
+// sample. It measures the mean squared difference between the two CDFs and,
+// unlike KolmogorovSmirnov, is sensitive to misfit over the whole range rather
+// than at the single point of largest difference. For samples drawn from a
+// continuous cdf the distribution of W² does not depend on cdf; its mean is
+// 1/6 and its 95th percentile is about 0.461. samples is not modified.
+// CDFCalibration returns NaN if samples is empty.
+func CDFCalibration(samples []float64, cdf func(float64) float64) float64 {
+	if len(samples) == 0 {
+		return math.NaN()
+	}
+	x := sortedCopy(samples)
+	n := float64(len(x))
+	w2 := 1 / (12 * n)
+	for i, v := range x {
+		d := (2*float64(i)+1)/(2*n) - cdf(v)
+		w2 += d * d
+	}
+	return w2
+}
+
 // ChiSquare computes the chi-square distance between the observed frequences 'obs' and
 // expected frequences 'exp' given by:
 //  \sum_i (obs_i-exp_i)^2 / exp_i
@@ -128,6 +234,48 @@ func ChiSquare(obs, exp []float64) float64 {
 	return result
 }
 
+// CircularMean returns the mean direction of the angles, in radians,
+//  atan2(Σ sin θ_i, Σ cos θ_i),
+// which is in [-π, π]. Unlike the arithmetic mean it is not affected by the
+// wrap around at ±π. The mean direction is undefined if the resultant of the
+// angles is zero, in which case CircularMean returns 0.
+func CircularMean(angles []float64) float64 {
+	c, s := meanResultant(angles)
+	return math.Atan2(s, c)
+}
+
+// CircularStdDev returns the circular standard deviation of the angles, in
+// radians,
+//  sqrt(-2 log R̄),
+// where R̄ is the mean resultant length described in CircularVariance. For
+// concentrated angles it is close to the ordinary standard deviation.
+func CircularStdDev(angles []float64) float64 {
+	c, s := meanResultant(angles)
+	return math.Sqrt(-2 * math.Log(math.Hypot(c, s)))
+}
+
+// CircularVariance returns the circular variance of the angles, in radians,
+//  1 - R̄,
+// where the mean resultant length
+//  R̄ = |Σ exp(iθ_i)| / n
+// is 1 if all angles are equal and near 0 if they are spread around the
+// circle. The circular variance is therefore in [0, 1].
+func CircularVariance(angles []float64) float64 {
+	c, s := meanResultant(angles)
+	return 1 - math.Hypot(c, s)
+}
+
+// meanResultant returns the mean of the cosines and sines of the angles.
+func meanResultant(angles []float64) (c, s float64) {
+	for _, a := range angles {
+		sa, ca := math.Sincos(a)
+		c += ca
+		s += sa
+	}
+	n := float64(len(angles))
+	return c / n, s / n
+}
+
 // Correlation returns the weighted correlation between the samples of x and y
 // with the given means.
 //  sum_i {w_i (x_i - meanX) * (y_i - meanY)} / ((sum_j {w_j} - 1) * stdX * stdY)
@@ -168,6 +316,58 @@ func Covariance(x []float64, meanX float64, y []float64, meanY float64, weights
 	return s / (sumWeights - 1)
 }
 
+// CRPSNormal returns the continuous ranked probability score of the normal
+// forecast with mean mu and standard deviation sigma at the observed value,
+//  CRPS = σ (z (2Φ(z) - 1) + 2φ(z) - 1/sqrt(π)),  z = (observed - μ)/σ,
+// where Φ and φ are the standard normal CDF and density, from Gneiting and
+// Raftery (2007). The score has the units of the observation, and is lower
+// for sharper forecasts centred nearer the observation.
+func CRPSNormal(mu, sigma, observed float64) float64 {
+	z := (observed - mu) / sigma
+	cdf := 0.5 * math.Erfc(-z/math.Sqrt2)
+	pdf := math.Exp(-z*z/2) / math.Sqrt(2*math.Pi)
+	return sigma * (z*(2*cdf-1) + 2*pdf - 1/math.SqrtPi)
+}
+
+// CredibleInterval returns a credible interval at the given level, such as
+// 0.95, from draws from a posterior distribution, as produced by MCMC or by
+// sampling a conjugate posterior. The kind selects the interval:
+//  - EqualTailed: the (1-level)/2 and (1+level)/2 Empirical quantiles of the
+//  samples.
+//  - HighestDensity: the shortest interval [x_(i), x_(i+m-1)] between order
+//  statistics that contains m = ceil(level*n) of the n samples.
+// The two agree for a symmetric posterior. For a skewed one the highest
+// density interval is shorter, and is shifted towards the mode. The highest
+// density interval of a multimodal posterior may be a union of intervals,
+// which the single interval returned covers.
+//
+// The samples need not be sorted, and are not modified. CredibleInterval
+// returns NaN for both bounds if there are no samples, and panics if level is
+// not in (0, 1) or kind is not a known interval kind.
+func CredibleInterval(posteriorSamples []float64, level float64, kind IntervalKind) (lo, hi float64) {
+	if !(level > 0 && level < 1) {
+		panic("stat: credible level out of range")
+	}
+	if kind != EqualTailed && kind != HighestDensity {
+		panic("stat: bad interval kind")
+	}
+	if len(posteriorSamples) == 0 {
+		return math.NaN(), math.NaN()
+	}
+	x := sortedCopy(posteriorSamples)
+	if kind == EqualTailed {
+		return Quantile((1-level)/2, Empirical, x, nil), Quantile((1+level)/2, Empirical, x, nil)
+	}
+	m := int(math.Ceil(level * float64(len(x))))
+	lo, hi = x[0], x[m-1]
+	for i := 1; i+m-1 < len(x); i++ {
+		if w := x[i+m-1] - x[i]; w < hi-lo {
+			lo, hi = x[i], x[i+m-1]
+		}
+	}
+	return lo, hi
+}
+
 // CrossEntropy computes the cross-entropy between the two distributions specified
 // in p and q.
 func CrossEntropy(p, q []float64) float64 {
@@ -185,6 +385,42 @@ func CrossEntropy(p, q []float64) float64 {
 	return ce
 }
 
+// EffectiveSampleSize estimates the number of independent samples carrying
+// the same information about the mean as the correlated series x, such as the
+// output of a Markov chain Monte Carlo sampler. It returns n/τ, where the
+// integrated autocorrelation time
+//  τ = -1 + 2 Σ_{k=0}^{m} Γ_k,  Γ_k = ρ_{2k} + ρ_{2k+1},
+// is computed with Geyer's initial monotone sequence estimator: the sum stops
+// before the first Γ_k that is not positive, and each Γ_k is reduced to at most
+// Γ_{k-1} (Geyer, Practical Markov Chain Monte Carlo, Statistical Science,
+// 1992). The autocorrelations ρ are those of AutoCorrelation. The estimate is
+// close to len(x) for independent samples, and may exceed it for negatively
+// correlated ones. EffectiveSampleSize returns NaN if len(x) < 2 or x is
+// constant.
+func EffectiveSampleSize(x []float64) float64 {
+	n := len(x)
+	if n < 2 {
+		return math.NaN()
+	}
+	mean := Mean(x, nil)
+	c0 := autoCovariance(x, mean, 0)
+	if c0 == 0 {
+		return math.NaN()
+	}
+	var sum float64
+	prev := math.Inf(1)
+	for k := 0; 2*k+1 < n; k++ {
+		gamma := (autoCovariance(x, mean, 2*k) + autoCovariance(x, mean, 2*k+1)) / c0
+		if !(gamma > 0) {
+			break
+		}
+		gamma = math.Min(gamma, prev)
+		sum += gamma
+		prev = gamma
+	}
+	return float64(n) / (2*sum - 1)
+}
+
 // Entropy computes the Shannon entropy of a distribution or the distance between
 // two distributions. The natural logarithm is used.
 //  - sum_i (p_i * log_e(p_i))
@@ -388,6 +624,85 @@ func Histogram(count, dividers, x, weights []float64) []float64 {
 	return count
 }
 
+// HuberLocation returns the Huber M-estimate of the location of x, together
+// with the scale used to compute it. The scale is the median absolute
+// deviation of x multiplied by MADScale, and the location μ solves
+//  \sum_i ψ((x_i - μ) / scale) = 0,  ψ(r) = max(-k, min(k, r))
+// by iterative reweighting, starting from the median. Residuals within k
+// scale units of μ receive full weight and larger residuals are down-weighted,
+// so a few outliers have bounded influence on the estimate. k = 1.345 gives
+// 95% efficiency relative to the mean for normally distributed data.
+//
+// The data need not be sorted, and x is not modified. HuberLocation panics if
+// k is not positive, and returns the median and zero scale if more than half
+// of the data are equal.
+func HuberLocation(x []float64, k float64) (mu, scale float64) {
+	if !(k > 0) {
+		panic("stat: non-positive Huber threshold")
+	}
+	mu = WeightedMedian(x, nil)
+	scale = MADScale * MAD(x)
+	if scale == 0 || math.IsNaN(scale) {
+		return mu, scale
+	}
+	const (
+		huberTol     = 1e-10
+		huberMaxIter = 100
+	)
+	for iter := 0; iter < huberMaxIter; iter++ {
+		lo := mu - k*scale
+		hi := mu + k*scale
+		var sum float64
+		for _, v := range x {
+			sum += math.Max(lo, math.Min(hi, v))
+		}
+		next := sum / float64(len(x))
+		done := math.Abs(next-mu) <= huberTol*scale
+		mu = next
+		if done {
+			break
+		}
+	}
+	return mu, scale
+}
+
+// Jackknife returns the leave-one-out jackknife estimate of statistic and the
+// jackknife estimate of its variance. With θ the statistic of x and θ_i the
+// statistic of x with element i removed,
+//  estimate = n θ - (n-1) θ̄,  variance = (n-1)/n Σ_i (θ_i - θ̄)²,
+// where θ̄ is the mean of the θ_i. The estimate removes the bias of θ to first
+// order in 1/n. For the sample mean the estimate is the mean and the variance
+// is the usual s²/n. The jackknife needs n+1 evaluations of statistic, fewer
+// than a bootstrap, but is only reliable for smooth statistics; for the
+// median, for example, the variance estimate is inconsistent.
+//
+// statistic is called with a slice that is reused between calls, so it must
+// not retain or modify it. Jackknife panics if len(x) < 2.
+func Jackknife(x []float64, statistic func([]float64) float64) (estimate, variance float64) {
+	n := len(x)
+	if n < 2 {
+		panic("stat: too few samples for jackknife")
+	}
+	theta := make([]float64, n)
+	sub := make([]float64, n-1)
+	copy(sub, x[1:])
+	for i := range x {
+		// sub holds x without element i.
+		if i > 0 {
+			sub[i-1] = x[i-1]
+		}
+		theta[i] = statistic(sub)
+	}
+	mean := Mean(theta, nil)
+	for _, v := range theta {
+		variance += (v - mean) * (v - mean)
+	}
+	nf := float64(n)
+	variance *= (nf - 1) / nf
+	estimate = nf*statistic(x) - (nf-1)*mean
+	return estimate, variance
+}
+
 // JensenShannon computes the JensenShannon divergence between the distributions
 // p and q. The Jensen-Shannon divergence is defined as
 //  m = 0.5 * (p + q)
@@ -414,6 +729,46 @@ func JensenShannon(p, q []float64) float64 {
 	return js
 }
 
+// KaplanMeier computes the Kaplan-Meier estimate of the survival function
+// from right-censored observations. censored[i] is true if times[i] is a
+// censoring time, after which the subject was no longer observed, and false
+// if it is a failure time. If censored is nil, all observations are failures.
+// The returned t holds the distinct failure times in increasing order, and
+// survival[j] is the estimated probability of surviving beyond t[j],
+//  S(t_j) = Π_{i≤j} (1 - d_i / n_i)
+// where d_i is the number of failures at t_i and n_i is the number of
+// observations with times at or after t_i. Observations censored at a failure
+// time are counted as at risk at that time.
+func KaplanMeier(times []float64, censored []bool) (t, survival []float64) {
+	if censored != nil && len(censored) != len(times) {
+		panic("stat: slice length mismatch")
+	}
+	x := make([]float64, len(times))
+	copy(x, times)
+	failed := make([]float64, len(times))
+	for i := range failed {
+		if censored == nil || !censored[i] {
+			failed[i] = 1
+		}
+	}
+	SortWeighted(x, failed)
+	s := 1.0
+	for i := 0; i < len(x); {
+		var d float64
+		j := i
+		for ; j < len(x) && x[j] == x[i]; j++ {
+			d += failed[j]
+		}
+		if d > 0 {
+			s *= 1 - d/float64(len(x)-i)
+			t = append(t, x[i])
+			survival = append(survival, s)
+		}
+		i = j
+	}
+	return t, survival
+}
+
 // KolmogorovSmirnov computes the largest distance between the empirical CDFs
 // of the two datasets. x and y consist of the sample locations with sample counts.
 // xWeights and yWeights respectively. x and y must each be sorted.
@@ -569,6 +924,25 @@ func KullbackLeibler(p, q []float64) float64 {
 	return kl
 }
 
+// MADScale is the factor that makes the median absolute deviation a
+// consistent estimator of the standard deviation for normally distributed
+// data. It is 1/Φ⁻¹(3/4), where Φ⁻¹ is the standard normal quantile function.
+const MADScale = 1.482602218505602
+
+// MAD returns the median absolute deviation of x,
+//  median_i |x_i - median(x)|
+// The data need not be sorted, and x is not modified. Multiply the result by
+// MADScale to estimate the standard deviation of normally distributed data.
+// MAD returns NaN if x is empty.
+func MAD(x []float64) float64 {
+	m := WeightedMedian(x, nil)
+	dev := make([]float64, len(x))
+	for i, v := range x {
+		dev[i] = math.Abs(v - m)
+	}
+	return WeightedMedian(dev, nil)
+}
+
 // Mean computes the weighted mean of the data set.
 //  sum_i {w_i * x_i} / sum_i {w_i}
 // If weights is nil then all of the weights are 1. If weights is not nil, then
@@ -652,6 +1026,40 @@ func Moment(moment float64, x []float64, mean float64, weights []float64) float6
 	return m / sumWeights
 }
 
+// PIT returns the probability integral transform of the samples under the
+// hypothesized cumulative distribution function cdf, the values cdf(x_i) in
+// the order of the samples. If the samples are drawn from a continuous cdf the
+// values are uniformly distributed on [0, 1], which UniformityTest checks; a
+// forecast that is too narrow piles the values up at 0 and 1, and a biased one
+// skews them to one side.
+func PIT(samples []float64, cdf func(float64) float64) []float64 {
+	u := make([]float64, len(samples))
+	for i, x := range samples {
+		u[i] = cdf(x)
+	}
+	return u
+}
+
+// PPPoints returns the coordinates of the probability-probability plot of
+// samples against the hypothesized cumulative distribution function cdf. For
+// the i-th smallest sample x_(i), i = 1, …, n, the empirical probability is
+// the plotting position (i - 1/2)/n and the theoretical probability is
+// cdf(x_(i)), so both are in increasing order. If the samples are drawn from
+// cdf the points lie close to the line y = x. Unlike a quantile-quantile
+// plot, which emphasizes the tails, a PP plot shows misfit near the center of
+// the distribution most clearly. samples is not modified.
+func PPPoints(samples []float64, cdf func(float64) float64) (empirical, theoretical []float64) {
+	x := sortedCopy(samples)
+	n := float64(len(x))
+	empirical = make([]float64, len(x))
+	theoretical = make([]float64, len(x))
+	for i, v := range x {
+		empirical[i] = (float64(i) + 0.5) / n
+		theoretical[i] = cdf(v)
+	}
+	return empirical, theoretical
+}
+
 // Quantile returns the sample of x such that x is greater than or
 // equal to the fraction p of samples. The exact behavior is determined by the
 // CumulantKind, and p should be a number between 0 and 1. Quantile is theoretically
@@ -704,6 +1112,122 @@ func Quantile(p float64, c CumulantKind, x, weights []float64) float64 {
 	}
 }
 
+// RankedProbabilityScore returns an approximation to the continuous ranked
+// probability score of the forecast with cumulative distribution function cdf
+// at the observed value,
+//  CRPS = ∫ (cdf(x) - 1{x ≥ observed})² dx,
+// computed by the trapezoidal rule over the points of grid, with observed
+// added as a node so that the step of the indicator is integrated exactly.
+// The integral is truncated to [grid[0], grid[len(grid)-1]], which should
+// cover the observation and the bulk of the forecast. For a normal forecast
+// CRPSNormal gives the exact score. RankedProbabilityScore panics if grid has
+// fewer than two points or is not sorted in increasing order.
+func RankedProbabilityScore(cdf func(float64) float64, observed float64, grid []float64) float64 {
+	if len(grid) < 2 {
+		panic("stat: too few grid points")
+	}
+	if !sort.Float64sAreSorted(grid) {
+		panic("stat: grid points are not sorted")
+	}
+	// f returns the integrand at x, taking the indicator from the left if
+	// left is true.
+	f := func(x float64, left bool) float64 {
+		d := cdf(x)
+		if x > observed || (x == observed && !left) {
+			d--
+		}
+		return d * d
+	}
+	var score float64
+	for i := 1; i < len(grid); i++ {
+		a, b := grid[i-1], grid[i]
+		if a < observed && observed < b {
+			score += (observed - a) * (f(a, false) + f(observed, true)) / 2
+			score += (b - observed) * (f(observed, false) + f(b, true)) / 2
+			continue
+		}
+		score += (b - a) * (f(a, false) + f(b, true)) / 2
+	}
+	return score
+}
+
+// RayleighTest tests the angles, in radians, for uniformity around the
+// circle against a unimodal alternative. It returns the Rayleigh statistic
+//  z = n R̄²,
+// where R̄ is the mean resultant length described in CircularVariance, and
+// its p-value from the approximation
+//  p = exp(sqrt(1 + 4n + 4(n² - (nR̄)²)) - (1 + 2n))
+// of Zar, Biostatistical Analysis (1999), which is accurate for n ≥ 10. A
+// small p-value is evidence that the angles are concentrated about a mean
+// direction.
+func RayleighTest(angles []float64) (z, pValue float64) {
+	c, s := meanResultant(angles)
+	n := float64(len(angles))
+	r2 := c*c + s*s
+	z = n * r2
+	pValue = math.Exp(math.Sqrt(1+4*n+4*n*n*(1-r2)) - (1 + 2*n))
+	return z, math.Min(1, pValue)
+}
+
+// SampleExKurtosis computes the excess kurtosis of x from its central
+// moments m_k = Σ (x_i - mean)^k / n. If bias is true it returns the biased
+// population estimate
+//  g2 = m4 / m2² - 3,
+// and if bias is false the bias-corrected sample estimate
+//  G2 = ((n+1) g2 + 6) (n-1) / ((n-2)(n-3)),
+// which are the conventions of scipy.stats.kurtosis with bias=True and
+// bias=False. The corrected estimate is unbiased for normal data. SampleExKurtosis
+// returns NaN if x is empty, or if bias is false and len(x) < 4.
+func SampleExKurtosis(x []float64, bias bool) float64 {
+	n := float64(len(x))
+	if len(x) == 0 || (!bias && len(x) < 4) {
+		return math.NaN()
+	}
+	m2, _, m4 := centralMoments(x)
+	g2 := m4/(m2*m2) - 3
+	if bias {
+		return g2
+	}
+	return ((n+1)*g2 + 6) * (n - 1) / ((n - 2) * (n - 3))
+}
+
+// SampleSkewness computes the skewness of x from its central moments
+// m_k = Σ (x_i - mean)^k / n. If bias is true it returns the biased
+// population estimate
+//  g1 = m3 / m2^(3/2),
+// and if bias is false the adjusted Fisher-Pearson sample estimate
+//  G1 = g1 sqrt(n(n-1)) / (n-2),
+// which are the conventions of scipy.stats.skew with bias=True and
+// bias=False. SampleSkewness returns NaN if x is empty, or if bias is false
+// and len(x) < 3.
+func SampleSkewness(x []float64, bias bool) float64 {
+	n := float64(len(x))
+	if len(x) == 0 || (!bias && len(x) < 3) {
+		return math.NaN()
+	}
+	m2, m3, _ := centralMoments(x)
+	g1 := m3 / math.Pow(m2, 1.5)
+	if bias {
+		return g1
+	}
+	return g1 * math.Sqrt(n*(n-1)) / (n - 2)
+}
+
+// centralMoments returns the second, third and fourth central moments of x,
+// normalized by len(x).
+func centralMoments(x []float64) (m2, m3, m4 float64) {
+	mean := Mean(x, nil)
+	for _, v := range x {
+		d := v - mean
+		d2 := d * d
+		m2 += d2
+		m3 += d2 * d
+		m4 += d2 * d2
+	}
+	n := float64(len(x))
+	return m2 / n, m3 / n, m4 / n
+}
+
 // Skew computes the skewness of the sample data.
 // If weights is nil then all of the weights are 1. If weights is not nil, then
 // len(x) must equal len(weights).
@@ -736,8 +1260,33 @@ func skewCorrection(n float64) float64 {
 	return (n / (n - 1)) * (1 / (n - 2))
 }
 
+// Softmax returns the normalized exponentials of the logits,
+//  p_i = exp(l_i - max) / Σ_j exp(l_j - max),
+// which are positive and sum to 1, where max is the largest logit. Subtracting
+// the maximum leaves the result unchanged, since softmax is invariant to a
+// shift of all the logits, and keeps the exponentials from overflowing. A
+// logit of -Inf has probability 0. The result contains NaN if a logit is NaN
+// or +Inf, or if all the logits are -Inf. logits is not modified.
+func Softmax(logits []float64) []float64 {
+	p := make([]float64, len(logits))
+	if len(logits) == 0 {
+		return p
+	}
+	max, _ := floats.Max(logits)
+	var sum float64
+	for i, l := range logits {
+		p[i] = math.Exp(l - max)
+		sum += p[i]
+	}
+	for i := range p {
+		p[i] /= sum
+	}
+	return p
+}
+
 // SortWeighted rearranges the data in x along with their corresponding
-// weights so that the x data are sorted. The data is sorted in place.
+// weights so that the x data are sorted. The data is sorted in place, and the
+// sort is stable so that the weights of tied data keep their relative order.
 // Weights may be nil, but if weights is non-nil then it must have the same
 // length as x.
 func SortWeighted(x, weights []float64) {
@@ -748,7 +1297,7 @@ func SortWeighted(x, weights []float64) {
 	if len(x) != len(weights) {
 		panic("stat: slice length mismatch")
 	}
-	sort.Sort(weightSorter{
+	sort.Stable(weightSorter{
 		x: x,
 		w: weights,
 	})
@@ -772,6 +1321,16 @@ func (w weightSorter) Len() int {
 	return len(w.x)
 }
 
+// sortedCopy returns a copy of x sorted in increasing order, leaving x
+// unchanged. Functions that need sorted data but accept unsorted input use it
+// so that the caller's slice is never reordered.
+func sortedCopy(x []float64) []float64 {
+	s := make([]float64, len(x))
+	copy(s, x)
+	sort.Stable(sort.Float64Slice(s))
+	return s
+}
+
 // StdDev returns the population standard deviation with the provided mean.
 func StdDev(x []float64, mean float64, weights []float64) float64 {
 	return math.Sqrt(Variance(x, mean, weights))
@@ -789,6 +1348,63 @@ func StdScore(x, mean, variance float64) float64 {
 	return (x - mean) / variance
 }
 
+// TrimmedMean returns the mean of x after discarding the floor(trimFraction*n)
+// smallest and as many largest values, where n is len(x). Trimming makes the
+// mean robust to outliers and to heavy tails, where the plain mean is dragged
+// by a few extreme values, while using more of the data than the median, which
+// is the limit as trimFraction approaches 0.5. It is a stable starting point
+// for fitting heavy-tailed distributions.
+//
+// The data need not be sorted, and x is not modified. TrimmedMean returns NaN
+// if x is empty, and panics if trimFraction is outside [0, 0.5).
+func TrimmedMean(x []float64, trimFraction float64) float64 {
+	if !(trimFraction >= 0 && trimFraction < 0.5) {
+		panic("stat: trim fraction out of range")
+	}
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	xs := sortedCopy(x)
+	k := int(trimFraction * float64(len(xs)))
+	return Mean(xs[k:len(xs)-k], nil)
+}
+
+// UniformityTest tests the values u, such as the output of PIT, for
+// uniformity on [0, 1]. It returns the one-sample Kolmogorov-Smirnov statistic
+//  D = max_i max(i/n - u_(i), u_(i) - (i-1)/n),
+// where u_(i) is the i-th smallest value, and its p-value from the asymptotic
+// Kolmogorov distribution
+//  p = 2 Σ_{j≥1} (-1)^(j-1) exp(-2 j² λ²),  λ = (sqrt(n) + 0.12 + 0.11/sqrt(n)) D,
+// with the correction of Stephens (1970), which is accurate for n ≥ 5. A small
+// p-value is evidence that the values are not uniform. u is not modified.
+// UniformityTest returns NaN for both if u is empty.
+func UniformityTest(u []float64) (d, pValue float64) {
+	if len(u) == 0 {
+		return math.NaN(), math.NaN()
+	}
+	x := sortedCopy(u)
+	n := float64(len(x))
+	for i, v := range x {
+		d = math.Max(d, math.Max(float64(i+1)/n-v, v-float64(i)/n))
+	}
+	sn := math.Sqrt(n)
+	lambda := (sn + 0.12 + 0.11/sn) * d
+	if lambda < 0.2 {
+		// The series converges slowly, and p is 1 to within 1e-10.
+		return d, 1
+	}
+	sign := 1.0
+	for j := 1; j <= 100; j++ {
+		term := math.Exp(-2 * float64(j*j) * lambda * lambda)
+		pValue += sign * term
+		if term < 1e-16*pValue {
+			break
+		}
+		sign = -sign
+	}
+	return d, math.Max(0, math.Min(1, 2*pValue))
+}
+
 // Variance computes the weighted sample variance with the provided mean.
 //  \sum_i w_i (x_i - mean)^2 / (sum_i w_i - 1)
 // If weights is nil then all of the weights are 1. If weights is not nil, then
@@ -814,3 +1430,88 @@ func Variance(x []float64, mean float64, weights []float64) float64 {
 	}
 	return ss / (sumWeights - 1)
 }
+
+// WeightedMedian returns the weighted median of x, the value at which the
+// cumulative weight of the sorted data first exceeds half of the total
+// weight. If the cumulative weight equals exactly half of the total at some
+// value, the median is the midpoint of that value and the next value with
+// positive weight, so that with unit weights an even number of samples gives
+// the usual average of the two middle values.
+//
+// The data need not be sorted, and x and weights are not modified. If weights
+// is nil then all of the weights are 1. If weights is not nil, then len(x)
+// must equal len(weights). WeightedMedian returns NaN if x is empty.
+func WeightedMedian(x, weights []float64) float64 {
+	if weights != nil && len(x) != len(weights) {
+		panic("stat: slice length mismatch")
+	}
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	xs := make([]float64, len(x))
+	copy(xs, x)
+	var ws []float64
+	if weights != nil {
+		ws = make([]float64, len(weights))
+		copy(ws, weights)
+	}
+	SortWeighted(xs, ws)
+	weight := func(i int) float64 {
+		if ws == nil {
+			return 1
+		}
+		return ws[i]
+	}
+	var sumWeights float64
+	if ws == nil {
+		sumWeights = float64(len(xs))
+	} else {
+		sumWeights = floats.Sum(ws)
+	}
+	half := sumWeights / 2
+	var cumsum float64
+	for i, v := range xs {
+		w := weight(i)
+		if w == 0 {
+			continue
+		}
+		cumsum += w
+		if cumsum > half {
+			return v
+		}
+		if cumsum == half {
+			for j := i + 1; j < len(xs); j++ {
+				if weight(j) > 0 {
+					return (v + xs[j]) / 2
+				}
+			}
+			return v
+		}
+	}
+	return xs[len(xs)-1]
+}
+
+// Winsorize returns a copy of x in which the values below the lowerP quantile
+// of x are replaced by that quantile, and the values above the upperP quantile
+// are replaced by that quantile. The quantiles are computed by Quantile with
+// the Empirical kind, so for example lowerP = 0.05 and upperP = 0.95 clamp
+// about 5% of the data at each end. Winsorizing limits the influence of
+// extreme values while keeping the number of samples.
+//
+// The data need not be sorted, and x is not modified. Winsorize panics if
+// lowerP or upperP is outside [0, 1] or if lowerP > upperP.
+func Winsorize(x []float64, lowerP, upperP float64) []float64 {
+	if !(0 <= lowerP && lowerP <= upperP && upperP <= 1) {
+		panic("stat: percentile out of bounds")
+	}
+	w := sortedCopy(x)
+	if len(x) == 0 {
+		return w
+	}
+	lo := Quantile(lowerP, Empirical, w, nil)
+	hi := Quantile(upperP, Empirical, w, nil)
+	for i, v := range x {
+		w[i] = math.Max(lo, math.Min(hi, v))
+	}
+	return w
+}
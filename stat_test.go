@@ -7,6 +7,8 @@ package stat
 import (
 	"fmt"
 	"math"
+	"math/rand"
+	"sort"
 	"testing"
 
 	"github.com/gonum/floats"
@@ -38,6 +40,189 @@ func ExampleCorrelation() {
 	// Correlation with computed standard deviatons is 0.39644
 }
 
+func TestAutoCorrelation(t *testing.T) {
+	got := AutoCorrelation([]float64{1, 2, 3, 4}, 3)
+	want := []float64{1, 0.25, -0.3, -0.45}
+	if !floats.EqualApprox(got, want, 1e-14) {
+		t.Errorf("AutoCorrelation mismatch. Expected %v, found %v", want, got)
+	}
+	for _, lag := range []int{-1, 4} {
+		lag := lag
+		if !panics(func() { AutoCorrelation([]float64{1, 2, 3, 4}, lag) }) {
+			t.Errorf("AutoCorrelation did not panic with lag %d", lag)
+		}
+	}
+}
+
+func TestEffectiveSampleSize(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	const n = 100000
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = rnd.NormFloat64()
+	}
+	if got := EffectiveSampleSize(x); math.Abs(got-n) > 0.1*n {
+		t.Errorf("EffectiveSampleSize mismatch for independent samples. Expected close to %v, found %v", n, got)
+	}
+
+	// For an AR(1) series x_i = φ x_{i-1} + e_i the autocorrelation at lag
+	// k is φ^k, and the integrated autocorrelation time is (1+φ)/(1-φ).
+	const phi = 0.9
+	for i := 1; i < n; i++ {
+		x[i] = phi*x[i-1] + rnd.NormFloat64()
+	}
+	rho := AutoCorrelation(x, 5)
+	for k, r := range rho {
+		if want := math.Pow(phi, float64(k)); math.Abs(r-want) > 0.02 {
+			t.Errorf("AutoCorrelation mismatch for AR(1) at lag %d. Expected close to %v, found %v", k, want, r)
+		}
+	}
+	want := n * (1 - phi) / (1 + phi)
+	if got := EffectiveSampleSize(x); math.Abs(got-want) > 0.2*want {
+		t.Errorf("EffectiveSampleSize mismatch for AR(1) series. Expected close to %v, found %v", want, got)
+	}
+
+	if got := EffectiveSampleSize([]float64{2, 2, 2}); !math.IsNaN(got) {
+		t.Errorf("EffectiveSampleSize mismatch for constant series. Expected NaN, found %v", got)
+	}
+}
+
+func TestCDFCalibration(t *testing.T) {
+	uniform := func(x float64) float64 { return math.Max(0, math.Min(1, x)) }
+	if got, want := CDFCalibration([]float64{0.75, 0.25}, uniform), 1.0/24; math.Abs(got-want) > 1e-15 {
+		t.Errorf("CDFCalibration mismatch. Expected %v, found %v", want, got)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	normal := func(x float64) float64 { return 0.5 * math.Erfc(-x/math.Sqrt2) }
+	x := make([]float64, 1000)
+	for i := range x {
+		x[i] = rnd.NormFloat64()
+	}
+	if got := CDFCalibration(x, normal); got > 0.461 {
+		t.Errorf("CDFCalibration too large for samples from the hypothesized distribution. Found %v", got)
+	}
+	for i := range x {
+		x[i] += 0.5
+	}
+	if got := CDFCalibration(x, normal); got < 5 {
+		t.Errorf("CDFCalibration too small for shifted samples. Found %v", got)
+	}
+	if got := CDFCalibration(nil, normal); !math.IsNaN(got) {
+		t.Errorf("CDFCalibration mismatch for no samples. Expected NaN, found %v", got)
+	}
+}
+
+func TestCRPS(t *testing.T) {
+	// At the mean of a standard normal the score is (√2-1)/√π.
+	if got, want := CRPSNormal(0, 1, 0), (math.Sqrt2-1)/math.SqrtPi; math.Abs(got-want) > 1e-15 {
+		t.Errorf("CRPSNormal mismatch. Expected %v, found %v", want, got)
+	}
+	mu, sigma := 1.0, 2.0
+	normal := func(x float64) float64 { return 0.5 * math.Erfc(-(x-mu)/(sigma*math.Sqrt2)) }
+	grid := make([]float64, 2001)
+	for i := range grid {
+		grid[i] = -20 + 42*float64(i)/float64(len(grid)-1)
+	}
+	for _, obs := range []float64{0.3, 1, 4.123, grid[1000]} {
+		got := RankedProbabilityScore(normal, obs, grid)
+		want := CRPSNormal(mu, sigma, obs)
+		if math.Abs(got-want) > 1e-4 {
+			t.Errorf("RankedProbabilityScore mismatch at %v. Expected %v, found %v", obs, want, got)
+		}
+	}
+	// The score grows as the observation moves away from the forecast.
+	if CRPSNormal(mu, sigma, 5) <= CRPSNormal(mu, sigma, 2) {
+		t.Errorf("CRPSNormal did not increase away from the mean")
+	}
+	if !panics(func() { RankedProbabilityScore(normal, 0, []float64{1, 0}) }) {
+		t.Errorf("RankedProbabilityScore did not panic with an unsorted grid")
+	}
+}
+
+func TestCredibleInterval(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]float64, 100000)
+	for i := range x {
+		x[i] = rnd.ExpFloat64()
+	}
+	quantile := func(p float64) float64 { return -math.Log1p(-p) }
+	lo, hi := CredibleInterval(x, 0.9, EqualTailed)
+	if math.Abs(lo-quantile(0.05)) > 0.005 || math.Abs(hi-quantile(0.95)) > 0.05 {
+		t.Errorf("Equal-tailed interval mismatch. Expected [%v, %v], found [%v, %v]", quantile(0.05), quantile(0.95), lo, hi)
+	}
+	// The highest density interval of the exponential starts at the mode 0.
+	hlo, hhi := CredibleInterval(x, 0.9, HighestDensity)
+	if hlo > 0.001 || math.Abs(hhi-quantile(0.9)) > 0.05 {
+		t.Errorf("Highest density interval mismatch. Expected [0, %v], found [%v, %v]", quantile(0.9), hlo, hhi)
+	}
+	if hhi-hlo >= hi-lo {
+		t.Errorf("Highest density interval not narrower for a skewed posterior. Found widths %v and %v", hhi-hlo, hi-lo)
+	}
+
+	// For a symmetric posterior the intervals agree.
+	for i := range x {
+		x[i] = rnd.NormFloat64()
+	}
+	lo, hi = CredibleInterval(x, 0.95, EqualTailed)
+	hlo, hhi = CredibleInterval(x, 0.95, HighestDensity)
+	if math.Abs(lo-hlo) > 0.05 || math.Abs(hi-hhi) > 0.05 || math.Abs(hi-1.96) > 0.05 {
+		t.Errorf("Intervals mismatch for a normal posterior. Found [%v, %v] and [%v, %v]", lo, hi, hlo, hhi)
+	}
+
+	if lo, hi := CredibleInterval(nil, 0.9, EqualTailed); !math.IsNaN(lo) || !math.IsNaN(hi) {
+		t.Errorf("CredibleInterval mismatch for no samples. Expected NaN, found [%v, %v]", lo, hi)
+	}
+	if !panics(func() { CredibleInterval(x, 1, EqualTailed) }) {
+		t.Errorf("CredibleInterval did not panic with level 1")
+	}
+	if !panics(func() { CredibleInterval(x, 0.9, 0) }) {
+		t.Errorf("CredibleInterval did not panic with an unknown kind")
+	}
+}
+
+func TestCircular(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, mu := range []float64{1, math.Pi - 0.05, -2} {
+		x := make([]float64, 500)
+		for i := range x {
+			x[i] = math.Remainder(mu+0.1*rnd.NormFloat64(), 2*math.Pi)
+		}
+		if got := CircularMean(x); math.Abs(math.Remainder(got-mu, 2*math.Pi)) > 0.02 {
+			t.Errorf("CircularMean mismatch for clustered angles. Expected close to %v, found %v", mu, got)
+		}
+		// For wrapped normal angles R̄ = exp(-σ²/2).
+		if got, want := CircularVariance(x), 1-math.Exp(-0.005); math.Abs(got-want) > 0.002 {
+			t.Errorf("CircularVariance mismatch for clustered angles. Expected close to %v, found %v", want, got)
+		}
+		if got := CircularStdDev(x); math.Abs(got-0.1) > 0.01 {
+			t.Errorf("CircularStdDev mismatch for clustered angles. Expected close to 0.1, found %v", got)
+		}
+		if _, p := RayleighTest(x); p > 1e-10 {
+			t.Errorf("RayleighTest did not reject uniformity for clustered angles. Found p = %v", p)
+		}
+	}
+
+	x := make([]float64, 500)
+	for i := range x {
+		x[i] = 2 * math.Pi * rnd.Float64()
+	}
+	if got := CircularVariance(x); got < 0.9 {
+		t.Errorf("CircularVariance mismatch for uniform angles. Expected close to 1, found %v", got)
+	}
+	if _, p := RayleighTest(x); p < 0.05 {
+		t.Errorf("RayleighTest rejected uniformity for uniform angles. Found p = %v", p)
+	}
+
+	// Angles on both sides of ±π average to π, not 0.
+	if got := CircularMean([]float64{math.Pi - 0.1, -math.Pi + 0.1}); math.Abs(math.Abs(got)-math.Pi) > 1e-12 {
+		t.Errorf("CircularMean mismatch across the wrap. Expected ±π, found %v", got)
+	}
+	if got := CircularVariance([]float64{0, math.Pi / 2, math.Pi, -math.Pi / 2}); math.Abs(got-1) > 1e-15 {
+		t.Errorf("CircularVariance mismatch for opposed angles. Expected 1, found %v", got)
+	}
+}
+
 func TestCorrelation(t *testing.T) {
 	for i, test := range []struct {
 		x   []float64
@@ -320,6 +505,72 @@ the count field in order to avoid extra garbage`)
 	// Weighted Hist = [77 175 275 375 423 627 675 775 783 1067]
 }
 
+func TestHuberLocation(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]float64, 200)
+	for i := range x {
+		x[i] = 5 + 2*rnd.NormFloat64()
+	}
+	mean := Mean(x, nil)
+	mu, scale := HuberLocation(x, 1.345)
+	if math.Abs(mu-mean) > 0.05 {
+		t.Errorf("HuberLocation mismatch on clean data. Expected close to %v, found %v", mean, mu)
+	}
+	if math.Abs(scale-2) > 0.3 {
+		t.Errorf("HuberLocation scale mismatch on clean data. Expected close to 2, found %v", scale)
+	}
+
+	contaminated := append(append([]float64(nil), x...), 1000, 2000, -500, 5000)
+	muOut, _ := HuberLocation(contaminated, 1.345)
+	if math.Abs(muOut-mu) > 0.1 {
+		t.Errorf("HuberLocation moved with outliers. Expected close to %v, found %v", mu, muOut)
+	}
+	if meanOut := Mean(contaminated, nil); math.Abs(meanOut-mean) < 10 {
+		t.Errorf("Mean unexpectedly robust to outliers. Found %v", meanOut)
+	}
+
+	mu, scale = HuberLocation([]float64{1, 3, 3, 3, 100}, 1.5)
+	if mu != 3 || scale != 0 {
+		t.Errorf("HuberLocation mismatch with zero MAD. Expected 3 and 0, found %v and %v", mu, scale)
+	}
+}
+
+func TestJackknife(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]float64, 50)
+	for i := range x {
+		x[i] = rnd.ExpFloat64()
+	}
+	mean := Mean(x, nil)
+	v := Variance(x, mean, nil)
+	n := float64(len(x))
+
+	est, variance := Jackknife(x, func(s []float64) float64 { return Mean(s, nil) })
+	if math.Abs(est-mean) > 1e-13 {
+		t.Errorf("Jackknife estimate of the mean mismatch. Expected %v, found %v", mean, est)
+	}
+	if want := v / n; math.Abs(variance-want) > 1e-13 {
+		t.Errorf("Jackknife variance of the mean mismatch. Expected %v, found %v", want, variance)
+	}
+
+	// The jackknife removes the bias of the variance with divisor n.
+	biased := func(s []float64) float64 {
+		m := Mean(s, nil)
+		var ss float64
+		for _, v := range s {
+			ss += (v - m) * (v - m)
+		}
+		return ss / float64(len(s))
+	}
+	if est, _ := Jackknife(x, biased); math.Abs(est-v) > 1e-12 {
+		t.Errorf("Jackknife estimate of the variance mismatch. Expected %v, found %v", v, est)
+	}
+
+	if !panics(func() { Jackknife([]float64{1}, biased) }) {
+		t.Errorf("Jackknife did not panic with one sample")
+	}
+}
+
 func TestJensenShannon(t *testing.T) {
 	for i, test := range []struct {
 		p []float64
@@ -367,6 +618,37 @@ func TestJensenShannon(t *testing.T) {
 	}
 }
 
+func TestKaplanMeier(t *testing.T) {
+	times, surv := KaplanMeier(
+		[]float64{5, 2, 1, 4, 2, 3},
+		[]bool{false, true, false, true, false, false},
+	)
+	wantTimes := []float64{1, 2, 3, 5}
+	wantSurv := []float64{5.0 / 6, 2.0 / 3, 4.0 / 9, 0}
+	if !floats.Equal(times, wantTimes) {
+		t.Errorf("KaplanMeier times mismatch. Expected %v, found %v", wantTimes, times)
+	}
+	if !floats.EqualApprox(surv, wantSurv, 1e-14) {
+		t.Errorf("KaplanMeier survival mismatch. Expected %v, found %v", wantSurv, surv)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]float64, 10000)
+	for i := range x {
+		x[i] = rnd.ExpFloat64()
+	}
+	times, surv = KaplanMeier(x, nil)
+	if len(times) != len(x) {
+		t.Errorf("KaplanMeier length mismatch. Expected %v, found %v", len(x), len(times))
+	}
+	for i, v := range times {
+		if want := math.Exp(-v); math.Abs(surv[i]-want) > 0.02 {
+			t.Errorf("KaplanMeier mismatch at %v without censoring. Expected close to %v, found %v", v, want, surv[i])
+			break
+		}
+	}
+}
+
 func TestKolmogorovSmirnov(t *testing.T) {
 	for i, test := range []struct {
 		x        []float64
@@ -579,6 +861,36 @@ func TestChiSquare(t *testing.T) {
 	}
 }
 
+func TestBinnedMode(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	normal := make([]float64, 20000)
+	gamma := make([]float64, 20000)
+	for i := range normal {
+		normal[i] = 3 + rnd.NormFloat64()
+		// The sum of three standard exponentials has a gamma distribution
+		// with shape 3 and mode 2.
+		gamma[i] = rnd.ExpFloat64() + rnd.ExpFloat64() + rnd.ExpFloat64()
+	}
+	for i, test := range []struct {
+		x     []float64
+		nbins int
+		mode  float64
+		tol   float64
+	}{
+		{normal, 40, 3, 0.3},
+		{gamma, 40, 2, 0.4},
+		{[]float64{1, 2, 2, 2.1, 5}, 4, 2.5, 1e-15},
+		{[]float64{4, 4, 4}, 10, 4, 0},
+	} {
+		if m := BinnedMode(test.x, test.nbins); math.Abs(m-test.mode) > test.tol {
+			t.Errorf("BinnedMode mismatch case %d. Expected %v, found %v", i, test.mode, m)
+		}
+	}
+	if m := BinnedMode(nil, 10); !math.IsNaN(m) {
+		t.Errorf("BinnedMode mismatch for empty data. Expected NaN, found %v", m)
+	}
+}
+
 func TestBhattacharyya(t *testing.T) {
 	for i, test := range []struct {
 		p   []float64
@@ -665,6 +977,28 @@ func ExampleMean() {
 	// The weights act as if there were more samples of that number
 }
 
+func TestMAD(t *testing.T) {
+	for i, test := range []struct {
+		x   []float64
+		ans float64
+	}{
+		{[]float64{1, 1, 2, 2, 4, 6, 9}, 1},
+		{[]float64{3, -1, 8, 2}, 2},
+		{[]float64{5}, 0},
+	} {
+		x := append([]float64(nil), test.x...)
+		if m := MAD(x); m != test.ans {
+			t.Errorf("MAD mismatch case %d. Expected %v, found %v", i, test.ans, m)
+		}
+		if !floats.Equal(x, test.x) {
+			t.Errorf("MAD modified the data case %d", i)
+		}
+	}
+	if m := MAD(nil); !math.IsNaN(m) {
+		t.Errorf("MAD mismatch for empty data. Expected NaN, found %v", m)
+	}
+}
+
 func TestMode(t *testing.T) {
 	for i, test := range []struct {
 		x       []float64
@@ -763,6 +1097,84 @@ func TestCDF(t *testing.T) {
 	}
 }
 
+func TestPIT(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	normal := func(x float64) float64 { return 0.5 * math.Erfc(-x/math.Sqrt2) }
+	x := make([]float64, 1000)
+	for i := range x {
+		x[i] = rnd.NormFloat64()
+	}
+	u := PIT(x, normal)
+	for i, v := range u {
+		if v != normal(x[i]) {
+			t.Fatalf("PIT mismatch at %d. Expected %v, found %v", i, normal(x[i]), v)
+		}
+	}
+	if _, p := UniformityTest(u); p < 0.05 {
+		t.Errorf("UniformityTest rejected the PIT of correctly modeled data. Found p = %v", p)
+	}
+	// A model that is too narrow piles the values up at 0 and 1.
+	narrow := func(x float64) float64 { return normal(2 * x) }
+	if _, p := UniformityTest(PIT(x, narrow)); p > 1e-6 {
+		t.Errorf("UniformityTest did not reject the PIT of mismodeled data. Found p = %v", p)
+	}
+
+	// A single value at 1/2 has D = 1/2.
+	if d, _ := UniformityTest([]float64{0.5}); d != 0.5 {
+		t.Errorf("UniformityTest statistic mismatch. Expected 0.5, found %v", d)
+	}
+	if d, p := UniformityTest(nil); !math.IsNaN(d) || !math.IsNaN(p) {
+		t.Errorf("UniformityTest mismatch for no values. Expected NaN, found %v, %v", d, p)
+	}
+}
+
+func TestPPPoints(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	normal := func(x float64) float64 { return 0.5 * math.Erfc(-x/math.Sqrt2) }
+	x := make([]float64, 1000)
+	for i := range x {
+		x[i] = rnd.NormFloat64()
+	}
+	orig := make([]float64, len(x))
+	copy(orig, x)
+	emp, theo := PPPoints(x, normal)
+	if !floats.Equal(x, orig) {
+		t.Errorf("PPPoints modified the samples")
+	}
+	if len(emp) != len(x) || len(theo) != len(x) {
+		t.Fatalf("PPPoints length mismatch. Expected %v, found %v and %v", len(x), len(emp), len(theo))
+	}
+	// The largest vertical distance from y = x is below the 95% critical
+	// value of the Kolmogorov-Smirnov statistic, about 1.36/√n.
+	var dist float64
+	for i := range emp {
+		if i > 0 && !(emp[i] > emp[i-1] && theo[i] >= theo[i-1]) {
+			t.Fatalf("PPPoints not in increasing order at %d", i)
+		}
+		dist = math.Max(dist, math.Abs(emp[i]-theo[i]))
+	}
+	if limit := 1.36 / math.Sqrt(float64(len(x))); dist > limit {
+		t.Errorf("PPPoints far from y = x for null data. Found distance %v, limit %v", dist, limit)
+	}
+	// Points for a mismodeled spread leave the line.
+	_, theo = PPPoints(x, func(x float64) float64 { return normal(2 * x) })
+	dist = 0
+	for i := range emp {
+		dist = math.Max(dist, math.Abs(emp[i]-theo[i]))
+	}
+	if dist < 0.1 {
+		t.Errorf("PPPoints close to y = x for mismodeled data. Found distance %v", dist)
+	}
+
+	emp, theo = PPPoints([]float64{2, 1}, func(x float64) float64 { return x / 4 })
+	if want := []float64{0.25, 0.75}; !floats.Equal(emp, want) {
+		t.Errorf("PPPoints empirical mismatch. Expected %v, found %v", want, emp)
+	}
+	if want := []float64{0.25, 0.5}; !floats.Equal(theo, want) {
+		t.Errorf("PPPoints theoretical mismatch. Expected %v, found %v", want, theo)
+	}
+}
+
 func TestQuantile(t *testing.T) {
 	cumulantKinds := []CumulantKind{Empirical}
 	for i, test := range []struct {
@@ -836,6 +1248,61 @@ func ExampleStdErr() {
 	// is likely 4.1667 ± 2.4921.
 }
 
+func TestSampleSkewnessKurtosis(t *testing.T) {
+	// The expected values follow the conventions of scipy.stats.skew and
+	// scipy.stats.kurtosis, computed in exact arithmetic.
+	for i, test := range []struct {
+		x                    []float64
+		skewBiased, skew     float64
+		exKurtBiased, exKurt float64
+	}{
+		{
+			x:            []float64{1, 2, 3, 4, 10, 2.5, -1, 7},
+			skewBiased:   0.6909457468363055,
+			skew:         0.8617607525033667,
+			exKurtBiased: -0.39083989283111104,
+			exKurt:       0.5792362250546669,
+		},
+		{
+			x:            []float64{0.5, 0.1, 0.9, 0.3, 0.3, 2.0, 1.1},
+			skewBiased:   1.0003624497353456,
+			skew:         1.2966179282317683,
+			exKurtBiased: -0.1297385514128973,
+			exKurt:       1.4886274766090464,
+		},
+		{
+			x:            []float64{1, 2, 3, 4, 5},
+			skewBiased:   0,
+			skew:         0,
+			exKurtBiased: -1.3,
+			exKurt:       -1.2,
+		},
+	} {
+		for _, v := range []struct {
+			name      string
+			got, want float64
+		}{
+			{"biased skewness", SampleSkewness(test.x, true), test.skewBiased},
+			{"skewness", SampleSkewness(test.x, false), test.skew},
+			{"biased excess kurtosis", SampleExKurtosis(test.x, true), test.exKurtBiased},
+			{"excess kurtosis", SampleExKurtosis(test.x, false), test.exKurt},
+		} {
+			if math.Abs(v.got-v.want) > 1e-13 {
+				t.Errorf("Case %d: %s mismatch. Expected %v, found %v", i, v.name, v.want, v.got)
+			}
+		}
+	}
+	if !math.IsNaN(SampleSkewness([]float64{1, 2}, false)) {
+		t.Errorf("Expected NaN skewness for two samples")
+	}
+	if !math.IsNaN(SampleExKurtosis([]float64{1, 2, 3}, false)) {
+		t.Errorf("Expected NaN excess kurtosis for three samples")
+	}
+	if !math.IsNaN(SampleSkewness(nil, true)) {
+		t.Errorf("Expected NaN skewness for no samples")
+	}
+}
+
 func TestSkew(t *testing.T) {
 	for i, test := range []struct {
 		x       []float64
@@ -867,6 +1334,133 @@ func TestSkew(t *testing.T) {
 	}
 }
 
+func TestSoftmax(t *testing.T) {
+	logits := []float64{1, 2, 3}
+	e := math.Exp(1)
+	z := 1 + e + e*e
+	want := []float64{1 / z, e / z, e * e / z}
+	got := Softmax(logits)
+	var sum float64
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > 1e-15 {
+			t.Errorf("Softmax mismatch at %d. Expected %v, found %v", i, want[i], got[i])
+		}
+		sum += got[i]
+	}
+	if math.Abs(sum-1) > 1e-15 {
+		t.Errorf("Softmax does not sum to 1. Found %v", sum)
+	}
+	// Shifting the logits, even far enough to overflow exp, leaves the
+	// probabilities unchanged.
+	shifted := Softmax([]float64{1001, 1002, 1003})
+	for i := range shifted {
+		if math.Abs(shifted[i]-want[i]) > 1e-15 {
+			t.Errorf("Softmax is not shift-invariant at %d. Expected %v, found %v", i, want[i], shifted[i])
+		}
+	}
+	if got := Softmax([]float64{math.Inf(-1), 0}); got[0] != 0 || got[1] != 1 {
+		t.Errorf("Softmax mismatch with a -Inf logit. Expected [0 1], found %v", got)
+	}
+	if got := Softmax(nil); len(got) != 0 {
+		t.Errorf("Softmax mismatch for no logits. Expected empty, found %v", got)
+	}
+}
+
+func TestSortingInputUnchanged(t *testing.T) {
+	x := []float64{3, -1, 4, 1, -5, 9, 2, 6, 5, 3, 5, 0, -0.5, 8}
+	u := []float64{0.9, 0.1, 0.5, 0.3, 0.7, 0.2}
+	weights := []float64{1, 2, 1, 3, 1, 1, 2, 1, 1, 2, 1, 1, 3, 1}
+	censored := make([]bool, len(x))
+	censored[2] = true
+	cdf := func(v float64) float64 { return 0.5 * math.Erfc(-v/(4*math.Sqrt2)) }
+	for _, test := range []struct {
+		name string
+		data []float64
+		f    func([]float64)
+	}{
+		{"BinnedMode", x, func(x []float64) { BinnedMode(x, 4) }},
+		{"CDFCalibration", x, func(x []float64) { CDFCalibration(x, cdf) }},
+		{"CredibleInterval(EqualTailed)", x, func(x []float64) { CredibleInterval(x, 0.8, EqualTailed) }},
+		{"CredibleInterval(HighestDensity)", x, func(x []float64) { CredibleInterval(x, 0.8, HighestDensity) }},
+		{"HuberLocation", x, func(x []float64) { HuberLocation(x, 1.5) }},
+		{"KaplanMeier", x, func(x []float64) { KaplanMeier(x, censored) }},
+		{"MAD", x, func(x []float64) { MAD(x) }},
+		{"PPPoints", x, func(x []float64) { PPPoints(x, cdf) }},
+		{"TrimmedMean", x, func(x []float64) { TrimmedMean(x, 0.2) }},
+		{"UniformityTest", u, func(u []float64) { UniformityTest(u) }},
+		{"WeightedMedian", x, func(x []float64) { WeightedMedian(x, weights) }},
+		{"Winsorize", x, func(x []float64) { Winsorize(x, 0.1, 0.9) }},
+	} {
+		data := make([]float64, len(test.data))
+		copy(data, test.data)
+		test.f(data)
+		if !floats.Equal(data, test.data) {
+			t.Errorf("%s modified its input. Expected %v, found %v", test.name, test.data, data)
+		}
+	}
+	if !floats.Equal(weights, []float64{1, 2, 1, 3, 1, 1, 2, 1, 1, 2, 1, 1, 3, 1}) {
+		t.Errorf("WeightedMedian modified its weights")
+	}
+}
+
+func TestSortedCopy(t *testing.T) {
+	x := []float64{2, -1, 3, -1, 0}
+	s := sortedCopy(x)
+	if want := []float64{-1, -1, 0, 2, 3}; !floats.Equal(s, want) {
+		t.Errorf("sortedCopy mismatch. Expected %v, found %v", want, s)
+	}
+	if want := []float64{2, -1, 3, -1, 0}; !floats.Equal(x, want) {
+		t.Errorf("sortedCopy modified its input. Expected %v, found %v", want, x)
+	}
+	if s := sortedCopy(nil); s == nil || len(s) != 0 {
+		t.Errorf("sortedCopy mismatch for no data. Expected empty slice, found %v", s)
+	}
+}
+
+func TestSortWeightedStable(t *testing.T) {
+	// Tied data keep the relative order of their weights. There are enough
+	// data that an unstable sort does not fall back to insertion sort.
+	const n = 100
+	x := make([]float64, n)
+	w := make([]float64, n)
+	for i := range x {
+		x[i] = float64(i % 3)
+		w[i] = float64(i)
+	}
+	SortWeighted(x, w)
+	if !sort.Float64sAreSorted(x) {
+		t.Errorf("SortWeighted data not sorted")
+	}
+	for i := 1; i < n; i++ {
+		if x[i] == x[i-1] && w[i] < w[i-1] {
+			t.Fatalf("SortWeighted reordered the weights of tied data at %d", i)
+		}
+	}
+}
+
+func TestTrimmedMean(t *testing.T) {
+	x := []float64{3, 1, 2, 5, 4, 1e6, -1e6, 2.5, 3.5, 1e9}
+	if got, want := TrimmedMean(x, 0.2), 20.0/6; math.Abs(got-want) > 1e-15 {
+		t.Errorf("TrimmedMean mismatch. Expected %v, found %v", want, got)
+	}
+	// The plain mean is dragged by the outliers.
+	if got := TrimmedMean(x, 0); got < 1e8 {
+		t.Errorf("TrimmedMean with no trimming mismatch. Expected the mean, found %v", got)
+	}
+	// A fraction too small to remove a value leaves the mean unchanged.
+	if got, want := TrimmedMean([]float64{1, 2, 6}, 0.3), 3.0; got != want {
+		t.Errorf("TrimmedMean mismatch. Expected %v, found %v", want, got)
+	}
+	if got := TrimmedMean(nil, 0.1); !math.IsNaN(got) {
+		t.Errorf("TrimmedMean mismatch for no values. Expected NaN, found %v", got)
+	}
+	for _, f := range []float64{-0.1, 0.5, math.NaN()} {
+		if !panics(func() { TrimmedMean(x, f) }) {
+			t.Errorf("TrimmedMean did not panic with trim fraction %v", f)
+		}
+	}
+}
+
 func TestVariance(t *testing.T) {
 	for i, test := range []struct {
 		x       []float64
@@ -911,3 +1505,75 @@ func ExampleVariance() {
 	// The variance of the samples is 77.5000
 	// The weighted variance of the samples is 111.7941
 }
+
+func TestWeightedMedian(t *testing.T) {
+	for i, test := range []struct {
+		x       []float64
+		weights []float64
+		ans     float64
+	}{
+		{x: []float64{3, 1, 2}, ans: 2},
+		{x: []float64{4, 1, 3, 2}, ans: 2.5},
+		{x: []float64{3, 1, 2, 10}, weights: []float64{1, 1, 4, 1}, ans: 2},
+		{x: []float64{1, 2, 3, 4}, weights: []float64{1, 2, 0, 3}, ans: 3},
+		{x: []float64{1, 2, 3, 4}, weights: []float64{0.1, 0.1, 0.1, 0.8}, ans: 4},
+		{x: []float64{5, 1, 9}, weights: []float64{0, 3, 1}, ans: 1},
+	} {
+		x := append([]float64(nil), test.x...)
+		if m := WeightedMedian(x, test.weights); m != test.ans {
+			t.Errorf("WeightedMedian mismatch case %d. Expected %v, found %v", i, test.ans, m)
+		}
+		if !floats.Equal(x, test.x) {
+			t.Errorf("WeightedMedian modified the data case %d", i)
+		}
+	}
+	if m := WeightedMedian(nil, nil); !math.IsNaN(m) {
+		t.Errorf("WeightedMedian mismatch for empty data. Expected NaN, found %v", m)
+	}
+}
+
+func TestWinsorize(t *testing.T) {
+	x := make([]float64, 100)
+	for i := range x {
+		// A permutation of 1, …, 100.
+		x[i] = float64((i*37)%100 + 1)
+	}
+	orig := append([]float64(nil), x...)
+	w := Winsorize(x, 0.05, 0.95)
+	if !floats.Equal(x, orig) {
+		t.Errorf("Winsorize modified the data")
+	}
+	var changed int
+	for i, v := range w {
+		if v != x[i] {
+			changed++
+		}
+		if v < 5 || v > 95 {
+			t.Errorf("Winsorize value out of range at %d. Found %v", i, v)
+		}
+	}
+	// 1-4 are raised to 5 and 96-100 are lowered to 95.
+	if changed != 9 {
+		t.Errorf("Winsorize changed count mismatch. Expected 9, found %v", changed)
+	}
+
+	outliers := append(append([]float64(nil), orig...), -1e6, 1e9)
+	w = Winsorize(outliers, 0.05, 0.95)
+	if w[100] != 5 || w[101] != 96 {
+		t.Errorf("Winsorize did not pull in outliers. Found %v and %v", w[100], w[101])
+	}
+
+	if w := Winsorize(orig, 0, 1); !floats.Equal(w, orig) {
+		t.Errorf("Winsorize with full range changed the data")
+	}
+}
+
+func panics(f func()) (b bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			b = true
+		}
+	}()
+	f()
+	return
+}